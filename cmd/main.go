@@ -13,10 +13,13 @@ import (
 	config2 "pr-reviewer-service/pkg/config"
 
 	_ "pr-reviewer-service/docs"
+	"pr-reviewer-service/internal/gitlab"
 	"pr-reviewer-service/internal/handler"
+	"pr-reviewer-service/internal/mail"
 	"pr-reviewer-service/internal/repository"
 	"pr-reviewer-service/internal/router"
 	"pr-reviewer-service/internal/service"
+	"pr-reviewer-service/internal/worker"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -56,6 +59,15 @@ func main() {
 	userRepo := repository.NewUserRepository(pool)
 	prRepo := repository.NewPRRepository(pool)
 	statsRepo := repository.NewStatisticsRepository(pool)
+	codeOwnersRepo := repository.NewCodeOwnersRepository(pool)
+	availabilityRepo := repository.NewAvailabilityRepository(pool)
+	auditRepo := repository.NewAuditRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
+	integrationRepo := repository.NewIntegrationRepository(pool)
+	chatRepo := repository.NewChatRepository(pool)
+	notificationRepo := repository.NewNotificationRepository(pool)
+	slaRepo := repository.NewSLARepository(pool)
 
 	// Initialize validator
 	validate := validator.New()
@@ -63,9 +75,42 @@ func main() {
 	// Initialize services
 	authService := service.NewAuthService(authRepo, userRepo, cfg.JWTSecret)
 	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, userRepo, prRepo)
-	prService := service.NewPRService(prRepo, userRepo)
-	statsService := service.NewStatisticsService(statsRepo)
+	seeds := service.NewRandomSeedSource()
+	if cfg.AssignmentSeed != nil {
+		seeds = service.NewSeedSource(*cfg.AssignmentSeed)
+	}
+	webhookService := service.NewWebhookService(webhookRepo, &http.Client{Timeout: cfg.WebhookTimeout})
+	reviewerAssigner := service.NewReviewerAssigner(userRepo, prRepo, teamRepo, codeOwnersRepo, availabilityRepo)
+	userService := service.NewUserService(userRepo, userRepo, prRepo, availabilityRepo, reviewerAssigner, seeds)
+	prService := service.NewPRService(prRepo, userRepo, reviewerAssigner, seeds)
+	statsService := service.NewStatisticsService(statsRepo, teamRepo)
+	codeOwnersService := service.NewCodeOwnersService(codeOwnersRepo)
+	auditService := service.NewAuditService(auditRepo)
+	chatService := service.NewChatService(chatRepo, teamRepo, userRepo, prRepo, &http.Client{Timeout: cfg.WebhookTimeout})
+	publishers := []service.EventPublisher{webhookService, chatService}
+	// mailer stays nil without SMTP, digests can only be previewed then
+	var mailer service.Mailer
+	var emailService *service.EmailService
+	if cfg.SMTPHost != "" {
+		mailer = mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		emailService = service.NewEmailService(notificationRepo, userRepo, prRepo, mailer)
+		publishers = append(publishers, emailService)
+	}
+	outboxService := service.NewOutboxService(outboxRepo, publishers...)
+	digestService := service.NewDigestService(notificationRepo, userRepo, prRepo, mailer)
+	slaService := service.NewSLAService(slaRepo)
+	var gitlabReviewers service.MergeRequestReviewers
+	if cfg.GitLabURL != "" && cfg.GitLabAPIToken != "" {
+		gitlabReviewers = gitlab.NewClient(cfg.GitLabURL, cfg.GitLabAPIToken, &http.Client{Timeout: cfg.WebhookTimeout})
+	}
+	integrationService := service.NewIntegrationService(
+		integrationRepo,
+		userRepo,
+		prService,
+		cfg.GitHubWebhookSecret,
+		cfg.GitLabWebhookToken,
+		gitlabReviewers,
+	)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, validate)
@@ -74,6 +119,13 @@ func main() {
 	prHandler := handler.NewPRHandler(prService, validate)
 	healthHandler := handler.NewHealthHandler()
 	statisticsHandler := handler.NewStatisticsHandler(statsService)
+	codeOwnersHandler := handler.NewCodeOwnersHandler(codeOwnersService, validate)
+	auditHandler := handler.NewAuditHandler(auditService)
+	webhookHandler := handler.NewWebhookHandler(webhookService, validate)
+	integrationHandler := handler.NewIntegrationHandler(integrationService, validate)
+	chatHandler := handler.NewChatHandler(chatService, validate)
+	digestHandler := handler.NewDigestHandler(digestService)
+	slaHandler := handler.NewSLAHandler(slaService)
 
 	slog.Info("successfully configured services and handlers")
 
@@ -85,9 +137,37 @@ func main() {
 		prHandler,
 		healthHandler,
 		statisticsHandler,
+		codeOwnersHandler,
+		auditHandler,
+		webhookHandler,
+		integrationHandler,
+		chatHandler,
+		digestHandler,
+		slaHandler,
 		authService,
+		auditService,
 	)
 
+	// Start background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.NewHandoverWorker(userService, cfg.HandoverInterval).Run(workersCtx)
+	go worker.NewOutboxWorker(outboxService, cfg.OutboxInterval).Run(workersCtx)
+	go worker.NewWebhookWorker(webhookService, cfg.WebhookInterval).Run(workersCtx)
+	go worker.NewChatWorker(chatService, cfg.WebhookInterval).Run(workersCtx)
+	go worker.NewSLAWorker(slaService, cfg.SLACheckInterval).Run(workersCtx)
+	if gitlabReviewers != nil {
+		go worker.NewGitLabSyncWorker(integrationService, cfg.WebhookInterval).Run(workersCtx)
+	}
+	// email is the only channel of digests, team chats do not get them
+	if mailer != nil {
+		go worker.NewEmailWorker(emailService, cfg.EmailInterval).Run(workersCtx)
+		go worker.NewDigestWorker(digestService, cfg.DigestInterval).Run(workersCtx)
+	} else {
+		slog.Info("SMTP is not configured, email notifications and daily digests are disabled")
+	}
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         ":" + cfg.Port,
@@ -111,6 +191,7 @@ func main() {
 	<-quit
 
 	slog.Info("shutting down server...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
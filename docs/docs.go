@@ -15,29 +15,93 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/teams": {
+        "/admin/audit": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of all teams with their members",
+                "description": "List mutating API calls newest first with their actor, request ID, target, changed fields and outcome.\nPass next_cursor of the response as cursor to get the next page.\nWith format=jsonl every matching entry is exported as JSON lines, limit and cursor are ignored",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Teams"
+                    "Admin"
+                ],
+                "summary": "Get the audit log (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User who made the call",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP method and path, e.g. POST /users/setIsActive",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type of the changed object: team, user, absence, pull_request, codeowners, deactivated_users",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the changed object",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "success or failure",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound of the call time, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound of the call time, RFC 3339, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-500, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all teams (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Teams retrieved successfully",
+                        "description": "Audit log retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.AllTeamsResponse"
+                            "$ref": "#/definitions/response.AuditLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -47,7 +111,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Admin access required",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -58,17 +122,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/admin/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get list of all users in the system",
+                ]
+            }
+        },
+        "/admin/integrations/accounts": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -76,14 +139,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Integrations"
+                ],
+                "summary": "List external login mappings (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider, github or gitlab",
+                        "name": "provider",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all users (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Users retrieved successfully",
+                        "description": "Mappings retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.AllUsersResponse"
+                            "$ref": "#/definitions/response.IntegrationAccountsResponse"
                         }
                     },
                     "401": {
@@ -104,12 +175,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/auth/login": {
-            "post": {
-                "description": "Generate authentication token for a user by user_id",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Link a GitHub login or a GitLab username to a user, webhooks of the login's PRs are attributed to the user. Logins are case-insensitive",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,25 +191,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Integrations"
                 ],
-                "summary": "Generate JWT token for user",
+                "summary": "Map an external login to a user (Admin only)",
                 "parameters": [
                     {
-                        "description": "Login request",
+                        "description": "Login mapping",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
+                            "$ref": "#/definitions/request.SetIntegrationAccountRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token generated successfully",
+                        "description": "Login mapped successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.LoginResponse"
+                            "$ref": "#/definitions/response.IntegrationAccountResponse"
                         }
                     },
                     "400": {
@@ -144,23 +218,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pullRequest/create": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a PR and automatically assign up to 2 reviewers from author's team",
+                ]
+            }
+        },
+        "/admin/pullRequest/explain": {
+            "get": {
+                "description": "Show the recorded seed, strategy and candidates of the initial reviewer pick and of later replacements picked by a strategy, and replay them",
                 "consumes": [
                     "application/json"
                 ],
@@ -170,23 +262,21 @@ const docTemplate = `{
                 "tags": [
                     "PullRequests"
                 ],
-                "summary": "Create a new pull request",
+                "summary": "Explain reviewer assignment (Admin only)",
                 "parameters": [
                     {
-                        "description": "PR creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreatePRRequest"
-                        }
+                        "type": "string",
+                        "description": "Pull request ID",
+                        "name": "pull_request_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "PR created successfully",
+                    "200": {
+                        "description": "Assignment explained successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.PRResponse"
+                            "$ref": "#/definitions/response.AssignmentExplanationResponse"
                         }
                     },
                     "400": {
@@ -201,14 +291,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Author not found",
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "PR already exists",
+                    "404": {
+                        "description": "PR not found or assignment was not recorded",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -219,17 +309,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pullRequest/merge": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mark PR as MERGED (idempotent operation)",
+                ]
+            }
+        },
+        "/admin/sla/breaches": {
+            "get": {
+                "description": "List breaches of the team review SLAs newest first. A breach is open while the review is pending and\nresolved once the review is submitted, the reviewer is removed or the PR is no longer open.\nPass next_cursor of the response as cursor to get the next page",
                 "consumes": [
                     "application/json"
                 ],
@@ -237,25 +327,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PullRequests"
+                    "Admin"
                 ],
-                "summary": "Merge a pull request",
+                "summary": "Get review SLA breaches (Admin only)",
                 "parameters": [
                     {
-                        "description": "Merge PR request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.MergePRRequest"
-                        }
+                        "type": "string",
+                        "description": "Reviewer's team",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reviewer ID",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "open or resolved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PR merged successfully",
+                        "description": "Breaches retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.PRResponse"
+                            "$ref": "#/definitions/response.SLABreachesResponse"
                         }
                     },
                     "400": {
@@ -270,8 +381,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "PR not found",
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -282,17 +393,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/pullRequest/reassign": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Replace one reviewer with another from the same team",
+                ]
+            }
+        },
+        "/admin/teams": {
+            "get": {
+                "description": "Get list of all teams with their members",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,31 +411,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PullRequests"
-                ],
-                "summary": "Reassign a reviewer on PR",
-                "parameters": [
-                    {
-                        "description": "Reassign reviewer request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.ReassignPRRequest"
-                        }
-                    }
+                    "Teams"
                 ],
+                "summary": "List all teams (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Reviewer reassigned successfully",
-                        "schema": {
-                            "$ref": "#/definitions/response.ReassignResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Teams retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/response.AllTeamsResponse"
                         }
                     },
                     "401": {
@@ -333,14 +427,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "PR or user not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Cannot reassign (PR merged, user not assigned, or no candidates)",
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -351,17 +439,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/statistics": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get comprehensive statistics about PRs, users, teams, and reviewer assignments",
+                ]
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "description": "Get list of all users in the system",
                 "consumes": [
                     "application/json"
                 ],
@@ -369,14 +457,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics"
+                    "Users"
                 ],
-                "summary": "Get service statistics",
+                "summary": "List all users (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Statistics retrieved successfully",
+                        "description": "Users retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.StatisticsResponse"
+                            "$ref": "#/definitions/response.AllUsersResponse"
                         }
                     },
                     "401": {
@@ -385,23 +473,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/team/add": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a team and add/update users as members",
+                ]
+            }
+        },
+        "/admin/users/digest": {
+            "get": {
+                "description": "Build the daily digest of the user as it would be emailed now: their OPEN reviews with how long each has been waiting,\nthe longest waiting first, and the notifications queued for the digest. Nothing is sent or marked as sent.\nEmail is the only digest channel, team chats do not get digests. will_send is false with a reason when the user would get no digest",
                 "consumes": [
                     "application/json"
                 ],
@@ -409,29 +503,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Users"
                 ],
-                "summary": "Create a new team with members",
+                "summary": "Preview user daily digest (Admin only)",
                 "parameters": [
                     {
-                        "description": "Team creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateTeamRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Team created successfully",
+                    "200": {
+                        "description": "Digest preview",
                         "schema": {
-                            "$ref": "#/definitions/response.TeamResponse"
+                            "$ref": "#/definitions/response.DigestPreviewResponse"
                         }
                     },
                     "400": {
-                        "description": "Team already exists or validation error",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -442,23 +534,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/team/get": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get team information with all members",
+                ]
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "description": "Get all registered webhooks including disabled ones, secrets are not returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -466,54 +570,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
-                ],
-                "summary": "Get team by name",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Team name",
-                        "name": "team_name",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "List webhooks (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Team retrieved successfully",
+                        "description": "Webhooks retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.TeamDTO"
+                            "$ref": "#/definitions/response.WebhooksResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Team not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/batchDeactivateTeam": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Deactivate all members of a team and safely reassign their open PRs",
+                ]
+            },
+            "post": {
+                "description": "Subscribe a URL to events: pr.created, pr.ready, reviewer.assigned, reviewer.reassigned, pr.merged, user.deactivated, sla.breached, sla.escalated.\nEvery request carries the X-Webhook-Signature-256 header, \"sha256=\" followed by the hex HMAC-SHA256 of the body with the secret.\nThe secret is returned only in this response",
                 "consumes": [
                     "application/json"
                 ],
@@ -521,25 +614,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Batch deactivate team members (Admin only)",
+                "summary": "Register a webhook (Admin only)",
                 "parameters": [
                     {
-                        "description": "Batch deactivate team request",
+                        "description": "Webhook to register",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.BatchDeactivateTeamRequest"
+                            "$ref": "#/definitions/request.CreateWebhookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Team members deactivated and PRs reassigned",
+                    "201": {
+                        "description": "Webhook registered successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.BatchDeactivateResponse"
+                            "$ref": "#/definitions/response.WebhookCreatedResponse"
                         }
                     },
                     "400": {
@@ -555,7 +648,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Admin access required or cannot deactivate admin team",
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -566,17 +659,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/batchDeactivateUsers": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Deactivate specified users and safely reassign their open PRs",
+                ]
+            },
+            "delete": {
+                "description": "Stop sending events to the webhook. Its delivery log is kept, pending deliveries are not sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -584,26 +675,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Batch deactivate users (Admin only)",
+                "summary": "Disable a webhook (Admin only)",
                 "parameters": [
                     {
-                        "description": "Batch deactivate users request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BatchDeactivateUsersRequest"
-                        }
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Users deactivated and PRs reassigned",
-                        "schema": {
-                            "$ref": "#/definitions/response.BatchDeactivateResponse"
-                        }
+                    "204": {
+                        "description": "Webhook disabled successfully"
                     },
                     "400": {
                         "description": "Invalid request",
@@ -623,23 +709,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Webhook not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/getReview": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get list of pull requests where user is assigned as reviewer",
+                ]
+            }
+        },
+        "/admin/webhooks/deliveries": {
+            "get": {
+                "description": "List deliveries newest first with their status, attempts and the last response of the receiver.\nPass next_cursor of the response as cursor to get the next page",
                 "consumes": [
                     "application/json"
                 ],
@@ -647,23 +733,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Get PRs assigned to user",
+                "summary": "Get the webhook delivery log (Admin only)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "pending, delivered or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type, e.g. pr.created",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-200, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User reviews retrieved successfully",
+                        "description": "Deliveries retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/response.UserReviewsResponse"
+                            "$ref": "#/definitions/response.WebhookDeliveriesResponse"
                         }
                     },
                     "400": {
@@ -678,23 +787,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/setIsActive": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update user's active status. Admin users cannot be deactivated.",
+                ]
+            }
+        },
+        "/admin/webhooks/deliveries/redeliver": {
+            "post": {
+                "description": "Queue a new delivery of the same event to the same webhook, the payload and the event ID are unchanged",
                 "consumes": [
                     "application/json"
                 ],
@@ -702,25 +817,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Set user active status (Admin only)",
+                "summary": "Redeliver a webhook event (Admin only)",
                 "parameters": [
                     {
-                        "description": "Set active request",
+                        "description": "Delivery to repeat",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.SetUserActiveRequest"
+                            "$ref": "#/definitions/request.RedeliverRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User status updated successfully",
+                    "202": {
+                        "description": "Redelivery queued",
                         "schema": {
-                            "$ref": "#/definitions/response.UserResponse"
+                            "$ref": "#/definitions/response.WebhookDeliveryResponse"
                         }
                     },
                     "400": {
@@ -736,13 +851,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - Admin access required or cannot deactivate admin",
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Delivery not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -753,145 +868,4163 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dto.ErrorDetail": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
                 },
-                "message": {
-                    "type": "string"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/dto.ErrorDetail"
+        "/auth/login": {
+            "post": {
+                "description": "Generate authentication token for a user by user_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Generate JWT token for user",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token generated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.PullRequestDTO": {
-            "type": "object",
-            "properties": {
-                "assigned_reviewers": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "author_id": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "mergedAt": {
-                    "type": "string"
-                },
-                "pull_request_id": {
-                    "type": "string"
-                },
+        "/codeowners": {
+            "get": {
+                "description": "Get path ownership rules used for reviewer assignment, in file order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CodeOwners"
+                ],
+                "summary": "Get code owner rules",
+                "responses": {
+                    "200": {
+                        "description": "Rules retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.CodeOwnersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replace path ownership rules with the content of a file in GitHub CODEOWNERS syntax. Owners @org/team are teams, @user are users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CodeOwners"
+                ],
+                "summary": "Upload code owner rules (Admin only)",
+                "parameters": [
+                    {
+                        "description": "CODEOWNERS file content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UploadCodeOwnersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rules uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.CodeOwnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or CODEOWNERS syntax",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/integrations/github/webhook": {
+            "post": {
+                "description": "Apply pull_request events of GitHub: opened creates the PR (as a draft for draft PRs), ready_for_review marks it ready,\nclosed merges or closes it and reopened reopens it. Other events and actions are ignored.\nThe PR ID is \"\u003cowner\u003e/\u003crepo\u003e#\u003cnumber\u003e\", the author is resolved through the github integration accounts.\nThe body must be signed with the configured secret in X-Hub-Signature-256. A delivery is applied once per X-GitHub-Delivery",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integrations"
+                ],
+                "summary": "Receive a GitHub webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event name",
+                        "name": "X-GitHub-Event",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "X-GitHub-Delivery",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256= followed by the hex HMAC-SHA256 of the body",
+                        "name": "X-Hub-Signature-256",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "GitHub webhook payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GitHubPullRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event applied, ignored or already applied",
+                        "schema": {
+                            "$ref": "#/definitions/response.IngestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR or author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR cannot change its status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "GitHub login is not mapped to a user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "GitHub integration is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/integrations/gitlab/webhook": {
+            "post": {
+                "description": "Apply Merge Request Hook events of GitLab: open creates the PR (as a draft for draft merge requests),\nan update that removes the draft flag marks it ready, close closes, merge merges and reopen reopens it. Other events and actions are ignored.\nThe PR ID is \"\u003cproject path\u003e!\u003ciid\u003e\", the author is resolved through the gitlab integration accounts.\nX-Gitlab-Token must be the configured secret token. A delivery is applied once per X-Gitlab-Event-UUID.\nWhen a GitLab API token is configured, the assigned reviewers are set as reviewers of the merge request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integrations"
+                ],
+                "summary": "Receive a GitLab webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event name",
+                        "name": "X-Gitlab-Event",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "X-Gitlab-Event-UUID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret token of the webhook",
+                        "name": "X-Gitlab-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "GitLab webhook payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GitLabMergeRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event applied, ignored or already applied",
+                        "schema": {
+                            "$ref": "#/definitions/response.IngestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR or author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR cannot change its status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "GitLab username is not mapped to a user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "GitLab integration is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/addReviewer": {
+            "post": {
+                "description": "Assign one more reviewer chosen by the caller.\nThe user must be active, must not be the author and must not be assigned already",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Add a reviewer to PR",
+                "parameters": [
+                    {
+                        "description": "Add reviewer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddReviewerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviewer added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot add (PR merged, user is the author, inactive or already assigned)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/close": {
+            "post": {
+                "description": "Close an open PR or a draft without merging it (idempotent operation). Reviewers stay assigned but the PR no longer counts towards their load",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Close a pull request",
+                "parameters": [
+                    {
+                        "description": "Close PR request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClosePRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR closed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR already merged",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/create": {
+            "post": {
+                "description": "Create a PR and automatically assign reviewers from author's team according to the team settings.\nReviewers covering required_skills are preferred, skills nobody covers are listed in assignment.missing_skills.\nDrafts are created without reviewers, they are assigned when the PR is marked ready",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Create a new pull request",
+                "parameters": [
+                    {
+                        "description": "PR creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "PR created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR already exists, not enough reviewers, reviewers at capacity or no senior reviewer",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/get": {
+            "get": {
+                "description": "Get the PR with its reviewers and their review states",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Get a pull request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pull request ID",
+                        "name": "pull_request_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/list": {
+            "get": {
+                "description": "List PRs newest first with optional filters. Time ranges include the lower bound and exclude the upper one.\nPass next_cursor of the response as cursor to get the next page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "List pull requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Author ID",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assigned reviewer ID",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team of the author",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "OPEN",
+                            "MERGED",
+                            "CLOSED",
+                            "DRAFT"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merged at or after (RFC 3339)",
+                        "name": "merged_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merged before (RFC 3339)",
+                        "name": "merged_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PRs retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/markReady": {
+            "post": {
+                "description": "Move a draft to OPEN and assign reviewers according to the team settings (idempotent operation)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Mark a draft ready for review",
+                "parameters": [
+                    {
+                        "description": "Mark ready request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MarkReadyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR marked ready successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR or author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR is closed or merged, not enough reviewers, reviewers at capacity or no senior reviewer",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/merge": {
+            "post": {
+                "description": "Mark PR as MERGED (idempotent operation). The merge policy of the author's team has to be satisfied",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Merge a pull request",
+                "parameters": [
+                    {
+                        "description": "Merge PR request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MergePRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR merged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Merge policy is not satisfied or PR is closed or a draft",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/reassign": {
+            "post": {
+                "description": "Replace one reviewer with new_user_id or, when it is omitted, with another member of the same team picked by the team's strategy.\nThe chosen user must be active, must not be the author and must not be assigned already",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Reassign a reviewer on PR",
+                "parameters": [
+                    {
+                        "description": "Reassign reviewer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReassignPRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviewer reassigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReassignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot reassign (PR merged, user not assigned, chosen user is the author, inactive or already assigned, no candidates, candidates at capacity or no senior to replace a senior)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/removeReviewer": {
+            "post": {
+                "description": "Unassign the reviewer without a replacement. The PR keeps at least min_reviewers and min_senior_reviewers of the author's team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Remove a reviewer from PR",
+                "parameters": [
+                    {
+                        "description": "Remove reviewer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemoveReviewerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviewer removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot remove (PR merged, user not assigned, not enough reviewers or seniors left)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/reopen": {
+            "post": {
+                "description": "Return a closed PR to OPEN (idempotent operation). A PR closed before it got reviewers returns to DRAFT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Reopen a pull request",
+                "parameters": [
+                    {
+                        "description": "Reopen PR request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReopenPRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR reopened successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR already merged",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/review": {
+            "post": {
+                "description": "Set the review state of the current user on the PR: pending, approved, changes_requested or commented",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Submit a review",
+                "parameters": [
+                    {
+                        "description": "Submit review request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SubmitReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review submitted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR is not open or user is not assigned",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/pullRequest/timeline": {
+            "get": {
+                "description": "Get every change of the PR oldest first: creation, reviewer assignments, removals and reassignments\nwith their reason, reviews, merge and status changes. actor_id is omitted for changes made by background jobs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Get the history of a pull request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pull request ID",
+                        "name": "pull_request_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR timeline retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PRTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/statistics": {
+            "get": {
+                "description": "Get comprehensive statistics about PRs, users, teams, and reviewer assignments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get service statistics",
+                "responses": {
+                    "200": {
+                        "description": "Statistics retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.StatisticsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/statistics/pairingDiversity": {
+            "get": {
+                "description": "Weekly ratio of distinct author/reviewer pairs to review assignments of PRs created by the team's members.\n1 means that no author got the same reviewer twice in a week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get pairing diversity of a team (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of weeks to look back (default 12, max 104)",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pairing diversity retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.PairingDiversityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/add": {
+            "post": {
+                "description": "Create a team and add/update users as members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Create a new team with members",
+                "parameters": [
+                    {
+                        "description": "Team creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Team created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Team already exists or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/chat": {
+            "get": {
+                "description": "Get the incoming webhook and the message templates of the team's chat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team chat notifications (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chat settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamChatSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team chat is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Post assignments, reassignments and merges of PRs authored by the team's members to a Slack or Mattermost compatible incoming webhook.\nTemplates use Go text/template syntax with .Event, .PullRequest.ID, .PullRequest.Name, .Author, .Reviewers and .PreviousReviewer,\nusers have .UserID and .Username, {{mention .Author}} renders @username and {{mentions .Reviewers}} joins several mentions.\nOmitted templates use the defaults. Failed notifications are logged and never fail the PR operation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Set team chat notifications (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Chat settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetTeamChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chat settings saved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamChatSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or template",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Stop team chat notifications (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Chat notifications stopped"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team chat is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/fallbacks": {
+            "get": {
+                "description": "Get teams whose members top up reviewers when the team cannot provide enough of them, in priority order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team fallback pools (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fallback teams retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.FallbackTeamsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replace the fallback pools of the team. The first team in the list is used first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Set team fallback pools (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Fallback teams",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetFallbackTeamsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fallback teams updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.FallbackTeamsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/get": {
+            "get": {
+                "description": "Get team information with all members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TeamDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/setLead": {
+            "post": {
+                "description": "Make the member the team's lead, review SLA breaches of the team are escalated to the lead. An empty user_id removes the lead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Set team lead (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Team lead",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetTeamLeadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team lead updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found or user is not a member",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/setReviewerStrategy": {
+            "post": {
+                "description": "Kept for compatibility: sets reviewer_strategy in the team settings, like PUT /team/settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Set team reviewer selection strategy (deprecated, use PUT /team/settings)",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Set reviewer strategy request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetReviewerStrategyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Strategy updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/team/settings": {
+            "get": {
+                "description": "Get reviewer assignment settings of the team: reviewer count, minimum reviewers, strategy and limits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team settings (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team name",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Replace reviewer assignment settings of the team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Update team settings (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Team settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateTeamSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.TeamSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/availability": {
+            "get": {
+                "description": "Get current and upcoming absences of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get own absences",
+                "responses": {
+                    "200": {
+                        "description": "Absences retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.AbsencesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Register a period when the current user is away. Away users are not picked as reviewers. With hand_over_reviews their open reviews are reassigned when the absence starts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Add own absence",
+                "parameters": [
+                    {
+                        "description": "Absence period",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddAbsenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Absence added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.AbsenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Cancel an absence of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete own absence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Absence ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Absence deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Absence not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/batchDeactivateTeam": {
+            "post": {
+                "description": "Deactivate all members of a team and safely reassign their open PRs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Batch deactivate team members (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Batch deactivate team request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchDeactivateTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team members deactivated and PRs reassigned",
+                        "schema": {
+                            "$ref": "#/definitions/response.BatchDeactivateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required or cannot deactivate admin team",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/batchDeactivateUsers": {
+            "post": {
+                "description": "Deactivate specified users and safely reassign their open PRs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Batch deactivate users (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Batch deactivate users request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchDeactivateUsersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users deactivated and PRs reassigned",
+                        "schema": {
+                            "$ref": "#/definitions/response.BatchDeactivateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/getReview": {
+            "get": {
+                "description": "Get list of pull requests where user is assigned as reviewer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get PRs assigned to user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User reviews retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setCapacity": {
+            "post": {
+                "description": "Set the personal limit of open reviews (omit max_open_reviews to remove it) and the review weight used by reviewer selection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user review capacity (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Capacity request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserCapacityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User capacity updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setDigestSchedule": {
+            "post": {
+                "description": "Set the IANA timezone of the user and the hour of their local time the daily digest of pending reviews is sent at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user daily digest schedule (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Digest schedule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserDigestScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User digest schedule updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setIsActive": {
+            "post": {
+                "description": "Update user's active status. Admin users cannot be deactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user active status (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Set active request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User status updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required or cannot deactivate admin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setNotifications": {
+            "post": {
+                "description": "Set the email address and how the user is notified about assignments: immediate sends every email,\ndigest collects notifications for the daily digest and off sends nothing. An empty email removes the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user email notifications (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Notifications request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserNotificationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User notifications updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setSeniority": {
+            "post": {
+                "description": "Set the seniority level (junior, middle, senior, lead) used by the team's senior reviewer policy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user seniority (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Seniority request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserSeniorityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User seniority updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/setSkills": {
+            "post": {
+                "description": "Replace the skill tags of the user (go, postgres, frontend, security...). Tags are lowercased and deduplicated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set user skills (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Skills request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserSkillsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User skills updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/skills": {
+            "get": {
+                "description": "Get the skill tags used to match the user with pull requests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user skills",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User skills retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserSkillsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "dto.AbsenceDTO": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "hand_over_reviews": {
+                    "type": "boolean"
+                },
+                "handed_over_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AssignmentReportDTO": {
+            "type": "object",
+            "properties": {
+                "assigned_reviewers": {
+                    "type": "integer"
+                },
+                "at_capacity": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capacity_exhausted": {
+                    "type": "boolean"
+                },
+                "missing_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requested_reviewers": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "senior_shortfall": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AuditChangeDTO": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "dto.AuditEntryDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.AuditChangeDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChatTemplatesDTO": {
+            "type": "object",
+            "properties": {
+                "assigned": {
+                    "type": "string"
+                },
+                "merged": {
+                    "type": "string"
+                },
+                "reassigned": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CodeOwnerRuleDTO": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DigestDTO": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "local_date": {
+                    "type": "string"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DigestItemDTO"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DigestReviewDTO"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DigestItemDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DigestReviewDTO": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "author": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "waiting_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/dto.ErrorDetail"
+                }
+            }
+        },
+        "dto.IntegrationAccountDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PREventDTO": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous_reviewer_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "review_state": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PairingDiversityPointDTO": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "integer"
+                },
+                "distinct_pairs": {
+                    "type": "integer"
+                },
+                "diversity": {
+                    "type": "number"
+                },
+                "period_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PullRequestDTO": {
+            "type": "object",
+            "properties": {
+                "assigned_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "assignment": {
+                    "description": "Assignment explains the reviewer selection, it is returned only by operations that pick reviewers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.AssignmentReportDTO"
+                        }
+                    ]
+                },
+                "assignment_seed": {
+                    "type": "integer"
+                },
+                "assignment_strategy": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "changed_files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "closedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fallback_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mergedAt": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReviewDTO"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PullRequestShortDTO": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReassignmentExplanationDTO": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReviewerCandidateDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "previous_reviewer_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recorded_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "replayed_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reproducible": {
+                    "type": "boolean"
+                },
+                "requested_count": {
+                    "type": "integer"
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "senior_level": {
+                    "type": "string"
+                },
+                "seniors_needed": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReviewDTO": {
+            "type": "object",
+            "properties": {
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReviewerCandidateDTO": {
+            "type": "object",
+            "properties": {
+                "last_assigned_at": {
+                    "type": "string"
+                },
+                "max_open_reviews": {
+                    "type": "integer"
+                },
+                "open_reviews": {
+                    "type": "integer"
+                },
+                "recent_pairings": {
+                    "type": "integer"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tier": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.SLABreachDTO": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "breached_at": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "escalated_at": {
+                    "type": "string"
+                },
+                "escalated_to": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "sla_hours": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TeamChatSettingsDTO": {
+            "type": "object",
+            "properties": {
+                "team_name": {
+                    "type": "string"
+                },
+                "templates": {
+                    "$ref": "#/definitions/dto.ChatTemplatesDTO"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TeamDTO": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TeamMemberDTO"
+                    }
+                },
+                "reviewer_strategy": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TeamMemberDTO": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_team_lead": {
+                    "description": "IsTeamLead marks the member SLA breaches are escalated to",
+                    "type": "boolean"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TeamSettingsDTO": {
+            "type": "object",
+            "properties": {
+                "code_owners_mode": {
+                    "type": "string"
+                },
+                "max_open_reviews": {
+                    "type": "integer"
+                },
+                "merge_block_on_changes_requested": {
+                    "type": "boolean"
+                },
+                "merge_min_approvals": {
+                    "description": "Merge policy",
+                    "type": "integer"
+                },
+                "merge_require_team_approval": {
+                    "type": "boolean"
+                },
+                "min_reviewers": {
+                    "type": "integer"
+                },
+                "min_senior_reviewers": {
+                    "type": "integer"
+                },
+                "pairing_lookback": {
+                    "type": "integer"
+                },
+                "review_sla_hours": {
+                    "description": "Review SLA in working hours",
+                    "type": "integer"
+                },
+                "reviewer_count": {
+                    "type": "integer"
+                },
+                "reviewer_strategy": {
+                    "type": "string"
+                },
+                "senior_level": {
+                    "type": "string"
+                },
+                "sla_escalation_hours": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserAssignmentStatDTO": {
+            "type": "object",
+            "properties": {
+                "merged_assignments": {
+                    "type": "integer"
+                },
+                "open_assignments": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "total_assignments": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserDTO": {
+            "type": "object",
+            "properties": {
+                "digest_hour": {
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "Email is omitted when the user has no address",
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_open_reviews": {
+                    "type": "integer"
+                },
+                "notification_preference": {
+                    "type": "string"
+                },
+                "review_weight": {
+                    "type": "number"
+                },
+                "seniority": {
+                    "type": "string"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "redelivery_of": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.WebhookSubscriptionDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AddAbsenceRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "hand_over_reviews": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.AddReviewerRequest": {
+            "type": "object",
+            "required": [
+                "pull_request_id",
+                "user_id"
+            ],
+            "properties": {
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.BatchDeactivateTeamRequest": {
+            "type": "object",
+            "required": [
+                "team_name"
+            ],
+            "properties": {
+                "team_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.BatchDeactivateUsersRequest": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "user_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.ChatTemplatesInput": {
+            "type": "object",
+            "properties": {
+                "assigned": {
+                    "type": "string",
+                    "maxLength": 4000
+                },
+                "merged": {
+                    "type": "string",
+                    "maxLength": 4000
+                },
+                "reassigned": {
+                    "type": "string",
+                    "maxLength": 4000
+                }
+            }
+        },
+        "request.ClosePRRequest": {
+            "type": "object",
+            "required": [
+                "pull_request_id"
+            ],
+            "properties": {
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.CreatePRRequest": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "changed_files",
+                "pull_request_id",
+                "pull_request_name",
+                "required_skills"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "changed_files": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "draft": {
+                    "description": "Draft PRs get reviewers only when they are marked ready",
+                    "type": "boolean"
+                },
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
                 "pull_request_name": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1
+                },
+                "required_skills": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.CreateTeamRequest": {
+            "type": "object",
+            "required": [
+                "members",
+                "team_name"
+            ],
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.TeamMemberInput"
+                    }
+                },
+                "reviewer_strategy": {
+                    "type": "string",
+                    "enum": [
+                        "random",
+                        "round_robin",
+                        "least_loaded",
+                        "weighted"
+                    ]
+                },
+                "team_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret signs the payloads, a random one is generated when empty",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "request.GitHubPullRequest": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "type": "boolean"
+                },
+                "merged": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/request.GitHubUserShort"
+                }
+            }
+        },
+        "request.GitHubPullRequestPayload": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "pull_request": {
+                    "$ref": "#/definitions/request.GitHubPullRequest"
+                },
+                "repository": {
+                    "$ref": "#/definitions/request.GitHubRepositoryShort"
+                }
+            }
+        },
+        "request.GitHubRepositoryShort": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GitHubUserShort": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GitLabBoolChange": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
+                },
+                "previous": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.GitLabMergeRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "draft": {
+                    "type": "boolean"
+                },
+                "iid": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "work_in_progress": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.GitLabMergeRequestChange": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "$ref": "#/definitions/request.GitLabBoolChange"
+                },
+                "work_in_progress": {
+                    "$ref": "#/definitions/request.GitLabBoolChange"
+                }
+            }
+        },
+        "request.GitLabMergeRequestPayload": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "$ref": "#/definitions/request.GitLabMergeRequestChange"
+                },
+                "object_attributes": {
+                    "$ref": "#/definitions/request.GitLabMergeRequest"
+                },
+                "object_kind": {
+                    "type": "string"
+                },
+                "project": {
+                    "$ref": "#/definitions/request.GitLabProjectShort"
+                },
+                "user": {
+                    "$ref": "#/definitions/request.GitLabUserShort"
+                }
+            }
+        },
+        "request.GitLabProjectShort": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "path_with_namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GitLabUserShort": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "request.MarkReadyRequest": {
+            "type": "object",
+            "required": [
+                "pull_request_id"
+            ],
+            "properties": {
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.MergePRRequest": {
+            "type": "object",
+            "required": [
+                "pull_request_id"
+            ],
+            "properties": {
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.ReassignPRRequest": {
+            "type": "object",
+            "required": [
+                "old_user_id",
+                "pull_request_id"
+            ],
+            "properties": {
+                "new_user_id": {
+                    "description": "NewUserID is the chosen replacement, when empty it is picked by the team's strategy",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "status": {
-                    "type": "string"
+                "old_user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "dto.PullRequestShortDTO": {
+        "request.RedeliverRequest": {
+            "type": "object",
+            "required": [
+                "delivery_id"
+            ],
+            "properties": {
+                "delivery_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.RemoveReviewerRequest": {
             "type": "object",
+            "required": [
+                "pull_request_id",
+                "user_id"
+            ],
             "properties": {
-                "author_id": {
-                    "type": "string"
-                },
                 "pull_request_id": {
-                    "type": "string"
-                },
-                "pull_request_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "status": {
-                    "type": "string"
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "dto.TeamDTO": {
+        "request.ReopenPRRequest": {
             "type": "object",
+            "required": [
+                "pull_request_id"
+            ],
             "properties": {
-                "members": {
+                "pull_request_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "request.SetFallbackTeamsRequest": {
+            "type": "object",
+            "required": [
+                "fallback_teams",
+                "team_name"
+            ],
+            "properties": {
+                "fallback_teams": {
                     "type": "array",
+                    "maxItems": 10,
                     "items": {
-                        "$ref": "#/definitions/dto.TeamMemberDTO"
+                        "type": "string"
                     }
                 },
                 "team_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "dto.TeamMemberDTO": {
+        "request.SetIntegrationAccountRequest": {
             "type": "object",
+            "required": [
+                "login",
+                "provider",
+                "user_id"
+            ],
             "properties": {
-                "is_active": {
-                    "type": "boolean"
+                "login": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "user_id": {
-                    "type": "string"
+                "provider": {
+                    "type": "string",
+                    "enum": [
+                        "github",
+                        "gitlab"
+                    ]
                 },
-                "username": {
-                    "type": "string"
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "dto.UserAssignmentStatDTO": {
+        "request.SetReviewerStrategyRequest": {
             "type": "object",
+            "required": [
+                "reviewer_strategy",
+                "team_name"
+            ],
             "properties": {
-                "merged_assignments": {
-                    "type": "integer"
-                },
-                "open_assignments": {
-                    "type": "integer"
+                "reviewer_strategy": {
+                    "type": "string",
+                    "enum": [
+                        "random",
+                        "round_robin",
+                        "least_loaded",
+                        "weighted"
+                    ]
                 },
                 "team_name": {
-                    "type": "string"
-                },
-                "total_assignments": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "dto.UserDTO": {
+        "request.SetTeamChatRequest": {
             "type": "object",
+            "required": [
+                "team_name",
+                "webhook_url"
+            ],
             "properties": {
-                "is_active": {
-                    "type": "boolean"
-                },
                 "team_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "user_id": {
-                    "type": "string"
+                "templates": {
+                    "description": "Templates override the default messages, omitted templates use the defaults",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/request.ChatTemplatesInput"
+                        }
+                    ]
                 },
-                "username": {
-                    "type": "string"
+                "webhook_url": {
+                    "type": "string",
+                    "maxLength": 2048
                 }
             }
         },
-        "request.BatchDeactivateTeamRequest": {
+        "request.SetTeamLeadRequest": {
             "type": "object",
             "required": [
                 "team_name"
@@ -901,148 +5034,328 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
+                },
+                "user_id": {
+                    "description": "UserID is empty to remove the lead",
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "request.BatchDeactivateUsersRequest": {
+        "request.SetUserActiveRequest": {
             "type": "object",
             "required": [
-                "user_ids"
+                "user_id"
             ],
             "properties": {
-                "user_ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "is_active": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "request.CreatePRRequest": {
+        "request.SetUserCapacityRequest": {
             "type": "object",
             "required": [
-                "author_id",
-                "pull_request_id",
-                "pull_request_name"
+                "review_weight",
+                "user_id"
             ],
             "properties": {
-                "author_id": {
+                "max_open_reviews": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "review_weight": {
+                    "type": "number",
+                    "maximum": 100
+                },
+                "user_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
+                }
+            }
+        },
+        "request.SetUserDigestScheduleRequest": {
+            "type": "object",
+            "required": [
+                "digest_hour",
+                "timezone",
+                "user_id"
+            ],
+            "properties": {
+                "digest_hour": {
+                    "description": "DigestHour is the hour of the user's local time, nil is rejected so that 0 can be set",
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
                 },
-                "pull_request_id": {
+                "timezone": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "user_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
+                }
+            }
+        },
+        "request.SetUserNotificationsRequest": {
+            "type": "object",
+            "required": [
+                "notification_preference",
+                "user_id"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email is removed when empty",
+                    "type": "string",
+                    "maxLength": 320
                 },
-                "pull_request_name": {
+                "notification_preference": {
                     "type": "string",
-                    "maxLength": 500,
+                    "enum": [
+                        "immediate",
+                        "digest",
+                        "off"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
                     "minLength": 1
                 }
             }
         },
-        "request.CreateTeamRequest": {
+        "request.SetUserSeniorityRequest": {
             "type": "object",
             "required": [
-                "members",
-                "team_name"
+                "seniority",
+                "user_id"
             ],
             "properties": {
-                "members": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/request.TeamMemberInput"
-                    }
+                "seniority": {
+                    "type": "string",
+                    "enum": [
+                        "junior",
+                        "middle",
+                        "senior",
+                        "lead"
+                    ]
                 },
-                "team_name": {
+                "user_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 }
             }
         },
-        "request.LoginRequest": {
+        "request.SetUserSkillsRequest": {
             "type": "object",
             "required": [
+                "skills",
                 "user_id"
             ],
             "properties": {
+                "skills": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "user_id": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "request.MergePRRequest": {
+        "request.SubmitReviewRequest": {
             "type": "object",
             "required": [
-                "pull_request_id"
+                "pull_request_id",
+                "state"
             ],
             "properties": {
                 "pull_request_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "changes_requested",
+                        "commented"
+                    ]
                 }
             }
         },
-        "request.ReassignPRRequest": {
+        "request.TeamMemberInput": {
             "type": "object",
             "required": [
-                "old_user_id",
-                "pull_request_id"
+                "skills",
+                "user_id",
+                "username"
             ],
             "properties": {
-                "old_user_id": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "seniority": {
+                    "type": "string",
+                    "enum": [
+                        "junior",
+                        "middle",
+                        "senior",
+                        "lead"
+                    ]
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "pull_request_id": {
+                "username": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 }
             }
         },
-        "request.SetUserActiveRequest": {
+        "request.UpdateTeamSettingsRequest": {
             "type": "object",
             "required": [
-                "user_id"
+                "reviewer_strategy",
+                "team_name"
             ],
             "properties": {
-                "is_active": {
+                "code_owners_mode": {
+                    "type": "string",
+                    "enum": [
+                        "off",
+                        "prefer",
+                        "require"
+                    ]
+                },
+                "max_open_reviews": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "merge_block_on_changes_requested": {
                     "type": "boolean"
                 },
-                "user_id": {
+                "merge_min_approvals": {
+                    "description": "Merge policy, the defaults allow merging unconditionally",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "merge_require_team_approval": {
+                    "type": "boolean"
+                },
+                "min_reviewers": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "min_senior_reviewers": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "pairing_lookback": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "review_sla_hours": {
+                    "description": "Review SLA in working hours, 0 disables it",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "reviewer_count": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "reviewer_strategy": {
+                    "type": "string",
+                    "enum": [
+                        "random",
+                        "round_robin",
+                        "least_loaded",
+                        "weighted"
+                    ]
+                },
+                "senior_level": {
+                    "type": "string",
+                    "enum": [
+                        "junior",
+                        "middle",
+                        "senior",
+                        "lead"
+                    ]
+                },
+                "sla_escalation_hours": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "team_name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 }
             }
         },
-        "request.TeamMemberInput": {
+        "request.UploadCodeOwnersRequest": {
             "type": "object",
             "required": [
-                "user_id",
-                "username"
+                "content"
             ],
             "properties": {
-                "is_active": {
-                    "type": "boolean"
+                "content": {
+                    "type": "string",
+                    "maxLength": 1048576
+                }
+            }
+        },
+        "response.AbsenceResponse": {
+            "type": "object",
+            "properties": {
+                "absence": {
+                    "$ref": "#/definitions/dto.AbsenceDTO"
+                }
+            }
+        },
+        "response.AbsencesResponse": {
+            "type": "object",
+            "properties": {
+                "absences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AbsenceDTO"
+                    }
                 },
                 "user_id": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "type": "string"
                 }
             }
         },
@@ -1074,9 +5387,87 @@ const docTemplate = `{
                 }
             }
         },
+        "response.AssignmentExplanationResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReviewerCandidateDTO"
+                    }
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "reassignments": {
+                    "description": "Reassignments are the later replacements picked by a strategy, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReassignmentExplanationDTO"
+                    }
+                },
+                "recorded_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "replayed_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reproducible": {
+                    "type": "boolean"
+                },
+                "requested_count": {
+                    "type": "integer"
+                },
+                "required_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "senior_level": {
+                    "type": "string"
+                },
+                "seniors_needed": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditEntryDTO"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to get the next page, it is omitted on the last page",
+                    "type": "integer"
+                }
+            }
+        },
         "response.BatchDeactivateResponse": {
             "type": "object",
             "properties": {
+                "capacity_exhausted_prs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "deactivated_users": {
                     "type": "array",
                     "items": {
@@ -1092,6 +5483,15 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.PRReassignmentInfo"
                     }
                 },
+                "seed": {
+                    "type": "integer"
+                },
+                "senior_missing_prs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "skipped_users": {
                     "type": "array",
                     "items": {
@@ -1103,6 +5503,106 @@ const docTemplate = `{
                 },
                 "total_prs_reassigned": {
                     "type": "integer"
+                },
+                "understaffed_prs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.CodeOwnersResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CodeOwnerRuleDTO"
+                    }
+                }
+            }
+        },
+        "response.DigestPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "$ref": "#/definitions/dto.DigestDTO"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason explains why the digest would not be sent",
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "will_send": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.FallbackTeamsResponse": {
+            "type": "object",
+            "properties": {
+                "fallback_teams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.IngestResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "description": "Outcome is applied, ignored or duplicate",
+                    "type": "string"
+                },
+                "pull_request": {
+                    "description": "PullRequest is the state after an applied event",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PullRequestDTO"
+                        }
+                    ]
+                },
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.IntegrationAccountResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/dto.IntegrationAccountDTO"
+                }
+            }
+        },
+        "response.IntegrationAccountsResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.IntegrationAccountDTO"
+                    }
                 }
             }
         },
@@ -1117,9 +5617,30 @@ const docTemplate = `{
                 }
             }
         },
+        "response.PRListResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to get the next page, it is omitted on the last page",
+                    "type": "string"
+                },
+                "pull_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PullRequestDTO"
+                    }
+                }
+            }
+        },
         "response.PRReassignmentInfo": {
             "type": "object",
             "properties": {
+                "fallback_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "new_reviewers": {
                     "type": "array",
                     "items": {
@@ -1145,6 +5666,40 @@ const docTemplate = `{
                 }
             }
         },
+        "response.PRTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PREventDTO"
+                    }
+                },
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PairingDiversityResponse": {
+            "type": "object",
+            "properties": {
+                "overall": {
+                    "$ref": "#/definitions/dto.PairingDiversityPointDTO"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PairingDiversityPointDTO"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
         "response.ReassignResponse": {
             "type": "object",
             "properties": {
@@ -1156,12 +5711,33 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SLABreachesResponse": {
+            "type": "object",
+            "properties": {
+                "breaches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SLABreachDTO"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to get the next page, it is omitted on the last page",
+                    "type": "integer"
+                }
+            }
+        },
         "response.StatisticsResponse": {
             "type": "object",
             "properties": {
                 "active_users": {
                     "type": "integer"
                 },
+                "closed_prs": {
+                    "type": "integer"
+                },
+                "draft_prs": {
+                    "type": "integer"
+                },
                 "merged_prs": {
                     "type": "integer"
                 },
@@ -1185,6 +5761,14 @@ const docTemplate = `{
                 }
             }
         },
+        "response.TeamChatSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "chat": {
+                    "$ref": "#/definitions/dto.TeamChatSettingsDTO"
+                }
+            }
+        },
         "response.TeamResponse": {
             "type": "object",
             "properties": {
@@ -1193,6 +5777,14 @@ const docTemplate = `{
                 }
             }
         },
+        "response.TeamSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "settings": {
+                    "$ref": "#/definitions/dto.TeamSettingsDTO"
+                }
+            }
+        },
         "response.UserResponse": {
             "type": "object",
             "properties": {
@@ -1214,6 +5806,66 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "response.UserSkillsResponse": {
+            "type": "object",
+            "properties": {
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.WebhookCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "Secret is shown only once, receivers use it to verify X-Webhook-Signature-256",
+                    "type": "string"
+                },
+                "webhook": {
+                    "$ref": "#/definitions/dto.WebhookSubscriptionDTO"
+                }
+            }
+        },
+        "response.WebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to get the next page, it is omitted on the last page",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                }
+            }
+        },
+        "response.WebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookSubscriptionDTO"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,38 @@
+// Package audit passes the details of a change from the services to the audit middleware.
+// Services describe what they change, the middleware adds the actor, the request and the outcome
+package audit
+
+import "context"
+
+type recordKey struct{}
+
+// Record is filled by the services while a mutating request is handled
+type Record struct {
+	TargetType string
+	TargetID   string
+	Before     any
+	After      any
+}
+
+// NewContext returns a context that collects the record of the request
+func NewContext(ctx context.Context) (context.Context, *Record) {
+	record := &Record{}
+	return context.WithValue(ctx, recordKey{}, record), record
+}
+
+// Target names the object the request changes, it is recorded even if the request fails.
+// It does nothing outside of audited requests
+func Target(ctx context.Context, targetType, targetID string) {
+	if record, ok := ctx.Value(recordKey{}).(*Record); ok {
+		record.TargetType = targetType
+		record.TargetID = targetID
+	}
+}
+
+// Change stores the state of the target before and after the request, before is nil for created objects
+func Change(ctx context.Context, before, after any) {
+	if record, ok := ctx.Value(recordKey{}).(*Record); ok {
+		record.Before = before
+		record.After = after
+	}
+}
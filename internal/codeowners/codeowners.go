@@ -0,0 +1,149 @@
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// Parse reads rules written in GitHub CODEOWNERS syntax.
+// Owners in form @org/team are treated as teams, @user as users
+func Parse(content string) ([]domain.CodeOwnerRule, error) {
+	var rules []domain.CodeOwnerRule
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := domain.CodeOwnerRule{
+			Pattern: fields[0],
+			Users:   []string{},
+			Teams:   []string{},
+			Line:    lineNum,
+		}
+		if _, err := compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break // inline comment
+			}
+			if !strings.HasPrefix(owner, "@") || len(owner) == 1 {
+				return nil, fmt.Errorf("line %d: unsupported owner %q: %w", lineNum, owner, my_errors.ErrInvalidCodeOwners)
+			}
+
+			name := owner[1:]
+			if idx := strings.LastIndex(name, "/"); idx >= 0 {
+				rule.Teams = append(rule.Teams, name[idx+1:])
+			} else {
+				rule.Users = append(rule.Users, name)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read code owners: %w", err)
+	}
+
+	return rules, nil
+}
+
+// Matcher finds owners of file paths
+type Matcher struct {
+	rules    []domain.CodeOwnerRule
+	patterns []*regexp.Regexp
+}
+
+func NewMatcher(rules []domain.CodeOwnerRule) (*Matcher, error) {
+	m := &Matcher{
+		rules:    rules,
+		patterns: make([]*regexp.Regexp, len(rules)),
+	}
+	for i, rule := range rules {
+		re, err := compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", rule.Line, err)
+		}
+		m.patterns[i] = re
+	}
+	return m, nil
+}
+
+// Match returns the rule that owns the path. As in GitHub, the last matching rule wins
+func (m *Matcher) Match(path string) *domain.CodeOwnerRule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.patterns[i].MatchString(path) {
+			return &m.rules[i]
+		}
+	}
+	return nil
+}
+
+// compile converts a CODEOWNERS pattern into a regular expression.
+// Patterns follow gitignore rules: a leading or inner slash anchors the pattern to the repository root,
+// a trailing slash matches directory contents, "*" does not cross directories and "**" does
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("unsupported pattern %q: %w", pattern, my_errors.ErrInvalidCodeOwners)
+	}
+
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.Trim(pattern, "/"), "/")
+	p := strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern: %w", my_errors.ErrInvalidCodeOwners)
+	}
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*$")
+	case strings.HasSuffix(p, "/*"):
+		// "docs/*" owns files directly in docs, but not in its subdirectories
+		sb.WriteString("$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,70 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCodeOwners = `
+# default owners
+*                   @acme/backend
+
+*.js                @u5 @u6   # frontend leads
+/docs/*             @u7
+apps/               @acme/apps
+/build/logs/        @u8
+internal/**/sql     @u9
+/scripts/
+`
+
+func TestCodeOwners_Parse(t *testing.T) {
+	rules, err := Parse(sampleCodeOwners)
+	require.NoError(t, err)
+	require.Len(t, rules, 7)
+
+	assert.Equal(t, "*", rules[0].Pattern)
+	assert.Equal(t, []string{"backend"}, rules[0].Teams)
+	assert.Equal(t, []string{"u5", "u6"}, rules[1].Users)
+	assert.Equal(t, 5, rules[1].Line)
+	assert.Empty(t, rules[6].Users)
+	assert.Empty(t, rules[6].Teams)
+
+	_, err = Parse("*.go owner@example.com")
+	assert.Error(t, err)
+
+	_, err = Parse("!*.go @u1")
+	assert.Error(t, err)
+}
+
+func TestCodeOwners_Match(t *testing.T) {
+	rules, err := Parse(sampleCodeOwners)
+	require.NoError(t, err)
+
+	matcher, err := NewMatcher(rules)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path    string
+		pattern string
+	}{
+		{"main.go", "*"},
+		{"web/src/app.js", "*.js"},
+		{"docs/index.md", "/docs/*"},
+		{"docs/api/index.md", "*"},
+		{"apps/web/main.go", "apps/"},
+		{"services/apps/main.go", "apps/"},
+		{"build/logs/out.txt", "/build/logs/"},
+		{"src/build/logs/out.txt", "*"},
+		{"internal/sql", "internal/**/sql"},
+		{"internal/repo/pg/sql", "internal/**/sql"},
+		{"scripts/deploy.sh", "/scripts/"},
+	}
+
+	for _, tc := range testCases {
+		rule := matcher.Match(tc.path)
+		require.NotNil(t, rule, tc.path)
+		assert.Equal(t, tc.pattern, rule.Pattern, tc.path)
+	}
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Reviewer selection strategies
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyWeighted    = "weighted"
+
+	DefaultReviewerStrategy = StrategyLeastLoaded
+
+	DefaultReviewWeight = 1.0
+)
+
+// ReviewerLoad describes the current review load of a user, how much they can take and what they know
+type ReviewerLoad struct {
+	LastAssignedAt *time.Time `json:"last_assigned_at,omitempty"`
+	// MaxOpenReviews is the personal cap of open reviews, nil means no cap
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	OpenReviews    int  `json:"open_reviews"`
+	// Weight is the user's share of reviews relative to teammates, 1 is the default
+	Weight    float64  `json:"weight"`
+	Skills    []string `json:"skills,omitempty"`
+	Seniority string   `json:"seniority,omitempty"`
+}
+
+// Covers reports whether the user has the skill
+func (l ReviewerLoad) Covers(skill string) bool {
+	return slices.Contains(l.Skills, skill)
+}
+
+// IsAtLeast reports whether the user's seniority is level or above
+func (l ReviewerLoad) IsAtLeast(level string) bool {
+	return SeniorityAtLeast(l.Seniority, level)
+}
+
+// ReviewerCandidate is a user that can be picked as a reviewer
+type ReviewerCandidate struct {
+	ReviewerLoad
+	UserID string `json:"user_id"`
+	// Tier defines selection priority: candidates from lower tiers are picked first
+	Tier int `json:"tier"`
+	// RecentPairings is how many of the author's latest PRs the candidate reviewed
+	RecentPairings int `json:"recent_pairings,omitempty"`
+}
+
+// Assignment is the result of reviewer selection
+type Assignment struct {
+	// FallbackReviewers are reviewers taken from the team's fallback pools
+	FallbackReviewers map[string]bool
+	Strategy          string
+	Reviewers         []string
+	// AtCapacity are candidates skipped because they reached their open reviews limit
+	AtCapacity []string
+	// Candidates are the users the reviewers were picked from
+	Candidates []ReviewerCandidate
+	Requested  int
+	// Seed of the random generator used for the pick
+	Seed int64
+	// RequiredSkills are skills the reviewers should cover, MissingSkills are those none of them has
+	RequiredSkills []string
+	MissingSkills  []string
+	// SeniorsNeeded reviewers of SeniorLevel or above had to be picked, SeniorShortfall of them were not found
+	SeniorLevel     string
+	SeniorsNeeded   int
+	SeniorShortfall int
+}
+
+// AssignmentTrace records the input and output of a reviewer pick, so that it can be replayed later
+type AssignmentTrace struct {
+	Strategy       string              `json:"strategy"`
+	Candidates     []ReviewerCandidate `json:"candidates"`
+	Reviewers      []string            `json:"reviewers"`
+	RequiredSkills []string            `json:"required_skills,omitempty"`
+	SeniorLevel    string              `json:"senior_level,omitempty"`
+	Seed           int64               `json:"seed"`
+	Count          int                 `json:"count"`
+	SeniorsNeeded  int                 `json:"seniors_needed,omitempty"`
+}
+
+// Trace returns the trace of the assignment
+func (a *Assignment) Trace() *AssignmentTrace {
+	return &AssignmentTrace{
+		Strategy:       a.Strategy,
+		Candidates:     a.Candidates,
+		Reviewers:      a.Reviewers,
+		RequiredSkills: a.RequiredSkills,
+		SeniorLevel:    a.SeniorLevel,
+		Seed:           a.Seed,
+		Count:          a.Requested,
+		SeniorsNeeded:  a.SeniorsNeeded,
+	}
+}
+
+// AssignmentExplanation compares a stored assignment with its replay
+type AssignmentExplanation struct {
+	Trace         *AssignmentTrace
+	PullRequestID string
+	Replayed      []string
+	// Reassignments are the later replacements picked by a strategy, oldest first
+	Reassignments []ReassignmentExplanation
+	Reproducible  bool
+}
+
+// ReassignmentExplanation compares a recorded replacement pick with its replay
+type ReassignmentExplanation struct {
+	Event        PREvent
+	Replayed     []string
+	Reproducible bool
+}
+
+// CapacityExhausted reports that fewer reviewers than requested were picked
+// because the remaining candidates are at their limits
+func (a *Assignment) CapacityExhausted() bool {
+	return len(a.Reviewers) < a.Requested && len(a.AtCapacity) > 0
+}
+
+// AssignmentReport explains the reviewer selection of a PR. It is returned by the API and not stored
+type AssignmentReport struct {
+	AtCapacity         []string `json:"at_capacity"`
+	MissingSkills      []string `json:"missing_skills"`
+	RequestedReviewers int      `json:"requested_reviewers"`
+	AssignedReviewers  int      `json:"assigned_reviewers"`
+	Seed               int64    `json:"seed"`
+	SeniorShortfall    int      `json:"senior_shortfall"`
+	CapacityExhausted  bool     `json:"capacity_exhausted"`
+}
+
+// Report builds the API report of the assignment
+func (a *Assignment) Report() *AssignmentReport {
+	atCapacity := a.AtCapacity
+	if atCapacity == nil {
+		atCapacity = []string{}
+	}
+	missingSkills := a.MissingSkills
+	if missingSkills == nil {
+		missingSkills = []string{}
+	}
+	return &AssignmentReport{
+		AtCapacity:         atCapacity,
+		MissingSkills:      missingSkills,
+		RequestedReviewers: a.Requested,
+		AssignedReviewers:  len(a.Reviewers),
+		Seed:               a.Seed,
+		SeniorShortfall:    a.SeniorShortfall,
+		CapacityExhausted:  a.CapacityExhausted(),
+	}
+}
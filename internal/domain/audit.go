@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+
+	DefaultAuditListLimit = 50
+	MaxAuditListLimit     = 500
+)
+
+// Types of objects changed by audited requests
+const (
+	AuditTargetTeam        = "team"
+	AuditTargetUser        = "user"
+	AuditTargetAbsence     = "absence"
+	AuditTargetPR          = "pull_request"
+	AuditTargetCodeOwners  = "codeowners"
+	AuditTargetDeactivated = "deactivated_users"
+
+	AuditTargetWebhook         = "webhook"
+	AuditTargetWebhookDelivery = "webhook_delivery"
+	AuditTargetIntegration     = "integration_account"
+)
+
+// AuditChange is the value of one field before and after a request, nil means the field was absent
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEntry records one mutating API call
+type AuditEntry struct {
+	CreatedAt time.Time `json:"created_at"`
+	ActorID   string    `json:"actor_id"`
+	RequestID string    `json:"request_id"`
+	// Action is the HTTP method and path of the call
+	Action     string `json:"action"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	// Changes holds only the fields that differ, keyed by their JSON names
+	Changes    map[string]AuditChange `json:"changes"`
+	Outcome    string                 `json:"outcome"`
+	Error      string                 `json:"error,omitempty"`
+	StatusCode int                    `json:"status_code"`
+	ID         int64                  `json:"id"`
+}
+
+// AuditFilter selects audit entries. Empty fields do not filter, the range includes From and excludes To
+type AuditFilter struct {
+	From       *time.Time
+	To         *time.Time
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Outcome    string
+	// Cursor is the ID of the last entry of the previous page, 0 starts from the newest entry
+	Cursor int64
+	Limit  int
+}
+
+// AuditPage is one page of the audit log, newest first. NextCursor is 0 on the last page
+type AuditPage struct {
+	Entries    []AuditEntry
+	NextCursor int64
+}
+
+// AuditDiff compares the JSON representations of before and after field by field.
+// Values that are not JSON objects are compared as a whole under the "value" key
+func AuditDiff(before, after any) (map[string]AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]AuditChange)
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			changes[name] = AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+func auditFields(value any) (map[string]any, error) {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Pointer && reflect.ValueOf(value).IsNil()) {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audited value: %w", err)
+	}
+
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audited value: %w", err)
+	}
+	if fields, ok := decoded.(map[string]any); ok {
+		return fields, nil
+	}
+	return map[string]any{"value": decoded}, nil
+}
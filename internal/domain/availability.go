@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Absence is a period when the user is away and must not be picked as a reviewer
+type Absence struct {
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	HandedOverAt *time.Time `json:"handed_over_at,omitempty"`
+	UserID       string     `json:"user_id"`
+	Reason       string     `json:"reason"`
+	ID           int64      `json:"id"`
+	// HandOverReviews requests open reviews of the user to be reassigned when the absence starts
+	HandOverReviews bool `json:"hand_over_reviews"`
+}
+
+// HandoverResult is the result of handing over open reviews of absent users
+type HandoverResult struct {
+	HandedOverUsers []string
+	ReassignedPRs   []PRReassignment
+	UnderstaffedPRs []string
+	// CapacityExhaustedPRs could not be fully restaffed because candidates are at their limits
+	CapacityExhaustedPRs []string
+	// SeniorMissingPRs are left with fewer senior reviewers than their team requires
+	SeniorMissingPRs []string
+	// Seed of the random generator used to pick replacements
+	Seed int64
+}
@@ -6,7 +6,14 @@ type BatchDeactivateResult struct {
 	DeactivatedUsers []string
 	ReassignedPRs    []PRReassignment
 	SkippedUsers     []string
+	UnderstaffedPRs  []string
+	// CapacityExhaustedPRs could not be fully restaffed because candidates are at their limits
+	CapacityExhaustedPRs []string
+	// SeniorMissingPRs are left with fewer senior reviewers than their team requires
+	SeniorMissingPRs []string
 	ProcessingTime   time.Duration
+	// Seed of the random generator used to pick replacements
+	Seed int64
 }
 
 type ReassignmentTask struct {
@@ -15,4 +22,6 @@ type ReassignmentTask struct {
 	DeactivatedRevs  []string
 	TeamName         string
 	CurrentReviewers []string
+	ChangedFiles     []string
+	RequiredSkills   []string
 }
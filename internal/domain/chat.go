@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// Default chat templates, they are text/template templates executed with ChatMessage
+const (
+	DefaultChatAssignedTemplate   = `{{mentions .Reviewers}} you are assigned to review "{{.PullRequest.Name}}" ({{.PullRequest.ID}}) by {{.Author.Username}}`
+	DefaultChatReassignedTemplate = `{{mentions .Reviewers}} you are assigned to review "{{.PullRequest.Name}}" ({{.PullRequest.ID}}) by {{.Author.Username}} instead of {{.PreviousReviewer.Username}}`
+	DefaultChatMergedTemplate     = `"{{.PullRequest.Name}}" ({{.PullRequest.ID}}) by {{mention .Author}} is merged, reviewed by {{mentions .Reviewers}}`
+)
+
+// TeamChatSettings is where and how the team's chat is notified about its members' PRs
+type TeamChatSettings struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	TeamName  string    `json:"team_name"`
+	// WebhookURL is the incoming webhook of the chat channel. It holds the channel's secret, so it is not audited
+	WebhookURL string        `json:"-"`
+	Templates  ChatTemplates `json:"templates"`
+}
+
+// ChatTemplates are the message templates per event, an empty template means the default one
+type ChatTemplates struct {
+	Assigned   string `json:"assigned"`
+	Reassigned string `json:"reassigned"`
+	Merged     string `json:"merged"`
+}
+
+// ForEvent returns the template of the outbox event type, ok is false for events that are not sent to chats.
+// The initial reviewers are announced once per PR when it is opened for review, by pr.created or pr.ready,
+// reviewers added later get the same template through reviewer.assigned
+func (t ChatTemplates) ForEvent(eventType string) (text string, ok bool) {
+	switch eventType {
+	case WebhookPRCreated, WebhookPRReady, WebhookReviewerAssigned:
+		return withDefault(t.Assigned, DefaultChatAssignedTemplate), true
+	case WebhookReviewerReassigned:
+		return withDefault(t.Reassigned, DefaultChatReassignedTemplate), true
+	case WebhookPRMerged:
+		return withDefault(t.Merged, DefaultChatMergedTemplate), true
+	default:
+		return "", false
+	}
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// ChatUser is a user mentioned in a chat message
+type ChatUser struct {
+	UserID   string
+	Username string
+}
+
+type ChatPullRequest struct {
+	ID   string
+	Name string
+}
+
+// ChatMessage is the data of chat templates. Reviewers holds the initial reviewers for assignments,
+// the added reviewer for later additions, the new reviewer for reassignments and all reviewers for merges. PreviousReviewer is set for reassignments
+type ChatMessage struct {
+	PreviousReviewer *ChatUser
+	Event            string
+	PullRequest      ChatPullRequest
+	Author           ChatUser
+	Reviewers        []ChatUser
+}
+
+// ChatPost is a rendered message queued for the team's chat
+type ChatPost struct {
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	TeamName      string
+	EventType     string
+	Text          string
+	// WebhookURL of the team's chat, it is loaded only to send the post
+	WebhookURL string
+	// Status is one of the delivery statuses, posts are retried like webhook deliveries
+	Status    string
+	LastError string
+	ID        int64
+	// OutboxID is the event the post was created from, an event published twice queues it once
+	OutboxID int64
+	Attempts int
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Code owners modes define how owners of changed files are treated during reviewer selection
+const (
+	CodeOwnersOff     = "off"
+	CodeOwnersPrefer  = "prefer"
+	CodeOwnersRequire = "require"
+
+	DefaultCodeOwnersMode = CodeOwnersPrefer
+)
+
+// CodeOwnerRule maps a path pattern to its owners. Later rules take precedence
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Users   []string `json:"users"`
+	Teams   []string `json:"teams"`
+	Line    int      `json:"line"`
+}
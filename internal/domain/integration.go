@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Providers of inbound webhooks
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+func ValidProvider(provider string) bool {
+	return provider == ProviderGitHub || provider == ProviderGitLab
+}
+
+// GitHub pull_request actions applied to PRs, other actions are ignored
+const (
+	GitHubActionOpened         = "opened"
+	GitHubActionReopened       = "reopened"
+	GitHubActionClosed         = "closed"
+	GitHubActionReadyForReview = "ready_for_review"
+)
+
+// GitLab merge request actions applied to PRs, update is applied only when it toggles the draft flag
+const (
+	GitLabActionOpen   = "open"
+	GitLabActionReopen = "reopen"
+	GitLabActionClose  = "close"
+	GitLabActionMerge  = "merge"
+	GitLabActionUpdate = "update"
+)
+
+// Outcomes of an inbound webhook
+const (
+	IngestApplied = "applied"
+	// IngestIgnored means the event or action does not change PRs
+	IngestIgnored = "ignored"
+	// IngestDuplicate means the delivery was already applied
+	IngestDuplicate = "duplicate"
+)
+
+// IntegrationAccount links a login of an external system to a user
+type IntegrationAccount struct {
+	CreatedAt time.Time `json:"created_at"`
+	Provider  string    `json:"provider"`
+	Login     string    `json:"login"`
+	UserID    string    `json:"user_id"`
+}
+
+// InboundDelivery is a webhook received from an external system
+type InboundDelivery struct {
+	Provider   string
+	DeliveryID string
+	Event      string
+	Action     string
+	// PullRequestID is set for PR events
+	PullRequestID string
+}
+
+// GitHubPullRequestEvent is the part of a GitHub pull_request webhook the service uses
+type GitHubPullRequestEvent struct {
+	Action string
+	// Repository is the full name of the repository, e.g. octo-org/api
+	Repository  string
+	Title       string
+	AuthorLogin string
+	Number      int
+	Draft       bool
+	Merged      bool
+}
+
+// PullRequestID identifies the GitHub PR in the service
+func (e GitHubPullRequestEvent) PullRequestID() string {
+	return fmt.Sprintf("%s#%d", e.Repository, e.Number)
+}
+
+// GitLabMergeRequestEvent is the part of a GitLab Merge Request Hook the service uses
+type GitLabMergeRequestEvent struct {
+	Action string
+	// Project is the path of the project, e.g. group/api
+	Project string
+	Title   string
+	// Username is the user who triggered the event, the author for open
+	Username  string
+	ProjectID int64
+	IID       int
+	Draft     bool
+	// DraftChanged is set when an update toggled the draft flag
+	DraftChanged bool
+}
+
+// PullRequestID identifies the GitLab merge request in the service
+func (e GitLabMergeRequestEvent) PullRequestID() string {
+	return fmt.Sprintf("%s!%d", e.Project, e.IID)
+}
+
+// GitLabReviewerSync is a queued post-back of the reviewers assigned to a merge request to GitLab
+type GitLabReviewerSync struct {
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	PullRequestID string
+	// ReviewerIDs are mapped to GitLab usernames when the sync is sent
+	ReviewerIDs []string
+	// Status is one of the delivery statuses, syncs are retried like webhook deliveries
+	Status    string
+	LastError string
+	ID        int64
+	ProjectID int64
+	IID       int
+	Attempts  int
+}
+
+// IngestResult tells what an inbound webhook changed
+type IngestResult struct {
+	Outcome       string
+	Action        string
+	PullRequestID string
+	// PullRequest is the state after an applied event
+	PullRequest *PullRequest
+}
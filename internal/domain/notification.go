@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// DigestItem is a notification kept for the daily digest of a user with the digest preference
+type DigestItem struct {
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	UserID        string     `json:"user_id"`
+	EventType     string     `json:"event_type"`
+	PullRequestID string     `json:"pull_request_id"`
+	Summary       string     `json:"summary"`
+	ID            int64      `json:"id"`
+	// OutboxID is the event the item was created from, an event published twice queues it once
+	OutboxID int64 `json:"-"`
+}
+
+// OutgoingEmail is a rendered email of a user with the immediate preference, queued for sending
+type OutgoingEmail struct {
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	UserID        string
+	EventType     string
+	Recipient     string
+	Subject       string
+	HTML          string
+	// Status is one of the delivery statuses, emails are retried like webhook deliveries
+	Status    string
+	LastError string
+	ID        int64
+	// OutboxID is the event the email was created from, an event published twice queues it once
+	OutboxID int64
+	Attempts int
+}
+
+// Digest is the daily summary of a user: the open PRs waiting for their review, the longest waiting first,
+// and the notifications queued since the previous digest
+type Digest struct {
+	GeneratedAt   time.Time      `json:"generated_at"`
+	UserID        string         `json:"user_id"`
+	Username      string         `json:"username"`
+	Email         string         `json:"email,omitempty"`
+	Timezone      string         `json:"timezone"`
+	LocalDate     string         `json:"local_date"`
+	Reviews       []DigestReview `json:"reviews"`
+	Notifications []DigestItem   `json:"notifications"`
+}
+
+// Empty reports whether there is nothing to tell the user
+func (d *Digest) Empty() bool {
+	return len(d.Reviews) == 0 && len(d.Notifications) == 0
+}
+
+type DigestReview struct {
+	AssignedAt      time.Time     `json:"assigned_at"`
+	PullRequestID   string        `json:"pull_request_id"`
+	PullRequestName string        `json:"pull_request_name"`
+	AuthorID        string        `json:"author_id"`
+	Author          string        `json:"author"`
+	Waiting         time.Duration `json:"waiting"`
+}
+
+// DigestPreview is the digest as it would be emailed, WillSend is false when the user gets no digest
+type DigestPreview struct {
+	Digest   *Digest `json:"digest"`
+	Subject  string  `json:"subject"`
+	HTML     string  `json:"html"`
+	WillSend bool    `json:"will_send"`
+	// Reason explains why the digest would not be sent
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage is a domain event stored in the same transaction as the change that produced it
+type OutboxMessage struct {
+	CreatedAt time.Time
+	// EventType is one of the webhook event types
+	EventType string
+	Payload   json.RawMessage
+	// ID orders the messages, it also identifies the event for receivers dropping duplicates
+	ID int64
+}
@@ -1,22 +1,53 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 const (
 	StatusOpen   = "OPEN"
 	StatusMerged = "MERGED"
+	StatusClosed = "CLOSED"
+	StatusDraft  = "DRAFT"
 
 	TeamAdmins = "admins"
 )
 
+// statusTransitions lists the statuses a PR can move to. MERGED is final
+var statusTransitions = map[string][]string{
+	StatusDraft:  {StatusOpen, StatusClosed},
+	StatusOpen:   {StatusMerged, StatusClosed},
+	StatusClosed: {StatusOpen, StatusDraft},
+}
+
+// CanTransition reports whether a PR can move from one status to another
+func CanTransition(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
+
 type PullRequest struct {
-	CreatedAt         *time.Time `json:"created_at,omitempty"`
-	MergedAt          *time.Time `json:"merged_at,omitempty"`
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt          *time.Time `json:"created_at,omitempty"`
+	MergedAt           *time.Time `json:"merged_at,omitempty"`
+	ClosedAt           *time.Time `json:"closed_at,omitempty"`
+	PullRequestID      string     `json:"pull_request_id"`
+	PullRequestName    string     `json:"pull_request_name"`
+	AuthorID           string     `json:"author_id"`
+	Status             string     `json:"status"`
+	AssignmentStrategy string     `json:"assignment_strategy"`
+	AssignedReviewers  []string   `json:"assigned_reviewers"`
+	FallbackReviewers  []string   `json:"fallback_reviewers"`
+	ChangedFiles       []string   `json:"changed_files"`
+	// RequiredSkills are skills the reviewers of the PR should cover
+	RequiredSkills []string `json:"required_skills"`
+	// Reviews hold the review state of every assigned reviewer
+	Reviews []Review `json:"reviews"`
+	// AssignmentSeed is the seed used to pick the initial reviewers
+	AssignmentSeed *int64 `json:"assignment_seed,omitempty"`
+	// AssignmentTrace is stored on creation and read only when explaining the assignment
+	AssignmentTrace *AssignmentTrace `json:"-"`
+	// Assignment is set by operations that pick reviewers
+	Assignment *AssignmentReport `json:"assignment,omitempty"`
 }
 
 type PullRequestShort struct {
@@ -24,10 +55,21 @@ type PullRequestShort struct {
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
+	// AssignedAt is set in the PRs of a reviewer, it is when the reviewer was assigned
+	AssignedAt *time.Time `json:"assigned_at,omitempty"`
 }
 
 type PRReassignment struct {
-	PullRequestID string
-	OldReviewers  []string
-	NewReviewers  []string
+	PullRequestID     string
+	OldReviewers      []string
+	NewReviewers      []string
+	FallbackReviewers []string
+}
+
+// ReviewerReplacement is a new reviewer taking the place of an old one
+type ReviewerReplacement struct {
+	// Trace is the recorded pick of the new reviewer, nil when the reviewer was chosen manually
+	Trace      *AssignmentTrace
+	UserID     string
+	IsFallback bool
 }
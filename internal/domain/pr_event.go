@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// PR event types, pr_events is append-only and keeps the full history of a PR
+const (
+	EventCreated       = "created"
+	EventAssigned      = "assigned"
+	EventUnassigned    = "unassigned"
+	EventReassigned    = "reassigned"
+	EventReviewed      = "reviewed"
+	EventMerged        = "merged"
+	EventStatusChanged = "status_changed"
+)
+
+// Reasons of reviewer changes
+const (
+	// EventReasonAssignment means the reviewer was picked by the team's strategy
+	EventReasonAssignment = "assignment"
+	// EventReasonManual means the reviewer was chosen by the caller
+	EventReasonManual       = "manual"
+	EventReasonDeactivation = "deactivation"
+	EventReasonAbsence      = "absence"
+)
+
+// EventSource tells who made a change and why, it is stored with every event the change produces
+type EventSource struct {
+	// ActorID is empty for changes made by background jobs
+	ActorID string
+	Reason  string
+}
+
+// PREvent is one entry of the PR timeline
+type PREvent struct {
+	CreatedAt     time.Time `json:"created_at"`
+	PullRequestID string    `json:"pull_request_id"`
+	Type          string    `json:"type"`
+	ActorID       string    `json:"actor_id,omitempty"`
+	ReviewerID    string    `json:"reviewer_id,omitempty"`
+	// PreviousReviewerID is set for reassignments
+	PreviousReviewerID string `json:"previous_reviewer_id,omitempty"`
+	FromStatus         string `json:"from_status,omitempty"`
+	ToStatus           string `json:"to_status,omitempty"`
+	ReviewState        string `json:"review_state,omitempty"`
+	// Reason is set for reviewer changes
+	Reason string `json:"reason,omitempty"`
+	// AssignmentTrace is the recorded pick of a reassignment made by a strategy
+	AssignmentTrace *AssignmentTrace `json:"-"`
+	ID              int64            `json:"id"`
+}
+
+// Event returns an event of the PR made by the source
+func (s EventSource) Event(prID, eventType string) PREvent {
+	return PREvent{PullRequestID: prID, Type: eventType, ActorID: s.ActorID}
+}
+
+// ReviewerEvent returns a reviewer change of the PR made by the source
+func (s EventSource) ReviewerEvent(prID, eventType, reviewerID string) PREvent {
+	event := s.Event(prID, eventType)
+	event.ReviewerID = reviewerID
+	event.Reason = s.Reason
+	return event
+}
+
+// StatusEvent returns a status change of the PR made by the source
+func (s EventSource) StatusEvent(prID, eventType, from, to string) PREvent {
+	event := s.Event(prID, eventType)
+	event.FromStatus = from
+	event.ToStatus = to
+	return event
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPRListLimit = 50
+	MaxPRListLimit     = 200
+)
+
+// PRFilter selects PRs for listing. Empty fields do not filter, ranges include From and exclude To
+type PRFilter struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	// Cursor points at the last PR of the previous page
+	Cursor     *PRCursor
+	AuthorID   string
+	ReviewerID string
+	// TeamName is the team of the author
+	TeamName string
+	Status   string
+	Limit    int
+}
+
+// PRCursor is the position in the list ordered by created_at and pull_request_id, both descending
+type PRCursor struct {
+	CreatedAt     time.Time
+	PullRequestID string
+}
+
+// Encode returns an opaque string representation of the cursor
+func (c PRCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.PullRequestID))
+}
+
+// ParsePRCursor decodes a cursor returned by Encode
+func ParsePRCursor(value string) (*PRCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+	createdAt, prID, ok := strings.Cut(string(data), "|")
+	if !ok || prID == "" {
+		return nil, errors.New("malformed cursor")
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+	return &PRCursor{CreatedAt: parsed, PullRequestID: prID}, nil
+}
+
+// PRPage is one page of the PR list. NextCursor is empty on the last page
+type PRPage struct {
+	PullRequests []PullRequest
+	NextCursor   string
+}
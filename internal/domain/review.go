@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Review states of an assigned reviewer
+const (
+	ReviewPending          = "pending"
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+	ReviewCommented        = "commented"
+)
+
+func ValidReviewState(state string) bool {
+	switch state {
+	case ReviewPending, ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	}
+	return false
+}
+
+// Review is the current state of one reviewer's review of a PR
+type Review struct {
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	UserID     string     `json:"user_id"`
+	// TeamName of the reviewer, used by the merge policy
+	TeamName string `json:"team_name"`
+	State    string `json:"state"`
+}
+
+// MergePolicy lists the conditions a PR of the team has to meet to be merged.
+// The zero value allows merging unconditionally
+type MergePolicy struct {
+	MinApprovals int `json:"min_approvals"`
+	// BlockOnChangesRequested forbids merging while any reviewer requests changes
+	BlockOnChangesRequested bool `json:"block_on_changes_requested"`
+	// RequireTeamApproval needs at least one approval from a member of the author's team
+	RequireTeamApproval bool `json:"require_team_approval"`
+}
+
+// Violations returns the rules of the policy the reviews do not satisfy
+func (p MergePolicy) Violations(authorTeam string, reviews []Review) []string {
+	approvals := 0
+	teamApproval := false
+	changesRequested := 0
+	for _, review := range reviews {
+		switch review.State {
+		case ReviewApproved:
+			approvals++
+			if review.TeamName == authorTeam {
+				teamApproval = true
+			}
+		case ReviewChangesRequested:
+			changesRequested++
+		}
+	}
+
+	var violations []string
+	if approvals < p.MinApprovals {
+		violations = append(violations, fmt.Sprintf("%d approvals required, got %d", p.MinApprovals, approvals))
+	}
+	if p.BlockOnChangesRequested && changesRequested > 0 {
+		violations = append(violations, fmt.Sprintf("%d reviewers requested changes", changesRequested))
+	}
+	if p.RequireTeamApproval && !teamApproval {
+		violations = append(violations, fmt.Sprintf("approval from team %s required", authorTeam))
+	}
+	return violations
+}
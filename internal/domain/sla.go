@@ -0,0 +1,113 @@
+package domain
+
+import "time"
+
+// Review SLAs are counted in working hours: 09:00-18:00 from Monday to Friday of the reviewer's timezone
+const (
+	WorkdayStartHour = 9
+	WorkdayEndHour   = 18
+
+	MaxSLAHours               = 1000
+	DefaultSLAEscalationHours = 8
+
+	DefaultSLABreachListLimit = 50
+	MaxSLABreachListLimit     = 200
+)
+
+// SLA breach statuses, a breach is resolved once the review is submitted, the reviewer is removed or the PR is no longer open
+const (
+	SLABreachOpen     = "open"
+	SLABreachResolved = "resolved"
+)
+
+// AddWorkingHours returns the moment the given number of working hours have passed since start
+func AddWorkingHours(start time.Time, hours int, location *time.Location) time.Time {
+	if hours <= 0 {
+		return start
+	}
+	remaining := time.Duration(hours) * time.Hour
+	current := start.In(location)
+	for {
+		year, month, day := current.Date()
+		dayStart := time.Date(year, month, day, WorkdayStartHour, 0, 0, 0, location)
+		dayEnd := time.Date(year, month, day, WorkdayEndHour, 0, 0, 0, location)
+		nextDay := time.Date(year, month, day+1, WorkdayStartHour, 0, 0, 0, location)
+
+		if weekday := current.Weekday(); weekday == time.Saturday || weekday == time.Sunday || !current.Before(dayEnd) {
+			current = nextDay
+			continue
+		}
+		if current.Before(dayStart) {
+			current = dayStart
+		}
+		if available := dayEnd.Sub(current); remaining > available {
+			remaining -= available
+			current = nextDay
+			continue
+		}
+		return current.Add(remaining)
+	}
+}
+
+// SLAAssignment is a pending review of a reviewer whose team has a review SLA,
+// with the breach of the assignment when it is already recorded
+type SLAAssignment struct {
+	AssignedAt      time.Time
+	PullRequestID   string
+	ReviewerID      string
+	TeamName        string
+	Timezone        string
+	SLAHours        int
+	EscalationHours int
+	// LeadID is the active lead of the reviewer's team, empty when the team has none
+	LeadID      string
+	BreachID    int64
+	DueAt       *time.Time
+	EscalatedAt *time.Time
+}
+
+type SLABreach struct {
+	AssignedAt  time.Time  `json:"assigned_at"`
+	DueAt       time.Time  `json:"due_at"`
+	BreachedAt  time.Time  `json:"breached_at"`
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	// EscalatedTo is the team lead the breach was escalated to
+	EscalatedTo   string `json:"escalated_to,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	TeamName      string `json:"team_name"`
+	ID            int64  `json:"id"`
+	SLAHours      int    `json:"sla_hours"`
+}
+
+// Status is open until the breach is resolved
+func (b *SLABreach) Status() string {
+	if b.ResolvedAt != nil {
+		return SLABreachResolved
+	}
+	return SLABreachOpen
+}
+
+// SLABreachFilter selects breaches for the list. Empty fields do not filter
+type SLABreachFilter struct {
+	TeamName   string
+	ReviewerID string
+	Status     string
+	// Cursor is the ID of the last breach of the previous page
+	Cursor int64
+	Limit  int
+}
+
+// SLABreachPage is one page of breaches, newest first. NextCursor is 0 on the last page
+type SLABreachPage struct {
+	Breaches   []SLABreach
+	NextCursor int64
+}
+
+// SLACheckResult counts what a run of the SLA checker changed
+type SLACheckResult struct {
+	Breached  int
+	Escalated int
+	Resolved  int
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddWorkingHours(t *testing.T) {
+	// 2024-01-08 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name  string
+		start time.Time
+		hours int
+		due   time.Time
+	}{
+		{"within the day", at(8, 10, 0), 4, at(8, 14, 0)},
+		{"whole day", at(8, 9, 0), 9, at(8, 18, 0)},
+		{"spans the night", at(8, 16, 30), 4, at(9, 11, 30)},
+		{"spans the weekend", at(12, 16, 0), 4, at(15, 11, 0)},
+		{"starts on the weekend", at(13, 12, 0), 1, at(15, 10, 0)},
+		{"starts before the day", at(8, 7, 0), 1, at(8, 10, 0)},
+		{"starts after the day", at(8, 19, 0), 1, at(9, 10, 0)},
+		{"several days", at(8, 9, 0), 27, at(10, 18, 0)},
+		{"no hours", at(13, 12, 0), 0, at(13, 12, 0)},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.due, AddWorkingHours(tc.start, tc.hours, time.UTC).UTC(), tc.name)
+	}
+
+	// working hours are those of the given timezone, 09:00 in Tokyo is 00:00 UTC
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, at(8, 1, 0), AddWorkingHours(at(7, 23, 0), 1, tokyo).UTC())
+}
+
+func TestSLABreach_Status(t *testing.T) {
+	breach := SLABreach{}
+	assert.Equal(t, SLABreachOpen, breach.Status())
+
+	resolvedAt := time.Now()
+	breach.ResolvedAt = &resolvedAt
+	assert.Equal(t, SLABreachResolved, breach.Status())
+}
@@ -1,10 +1,14 @@
 package domain
 
+import "time"
+
 type Statistics struct {
 	UserAssignments []UserAssignmentStat `json:"user_assignments"`
 	TotalPRs        int                  `json:"total_prs"`
 	OpenPRs         int                  `json:"open_prs"`
 	MergedPRs       int                  `json:"merged_prs"`
+	ClosedPRs       int                  `json:"closed_prs"`
+	DraftPRs        int                  `json:"draft_prs"`
 	TotalUsers      int                  `json:"total_users"`
 	ActiveUsers     int                  `json:"active_users"`
 	TotalTeams      int                  `json:"total_teams"`
@@ -18,3 +22,20 @@ type UserAssignmentStat struct {
 	OpenAssignments   int    `json:"open_assignments"`
 	MergedAssignments int    `json:"merged_assignments"`
 }
+
+// PairingDiversity shows how often authors of a team get the same reviewers
+type PairingDiversity struct {
+	Since    time.Time
+	TeamName string
+	Overall  PairingDiversityPoint
+	Points   []PairingDiversityPoint
+}
+
+// PairingDiversityPoint is the ratio of distinct author/reviewer pairs to review assignments
+// of PRs created in a period. 1 means that no pair repeated
+type PairingDiversityPoint struct {
+	PeriodStart   *time.Time
+	Assignments   int
+	DistinctPairs int
+	Diversity     float64
+}
@@ -3,13 +3,61 @@ package domain
 import "time"
 
 type Team struct {
-	CreatedAt time.Time    `json:"created_at"`
-	TeamName  string       `json:"team_name"`
-	Members   []TeamMember `json:"members"`
+	CreatedAt        time.Time    `json:"created_at"`
+	TeamName         string       `json:"team_name"`
+	ReviewerStrategy string       `json:"reviewer_strategy"`
+	Members          []TeamMember `json:"members"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+	Skills    []string `json:"skills"`
+	Seniority string   `json:"seniority"`
+	IsActive  bool     `json:"is_active"`
+	// IsTeamLead marks the member SLA breaches of the team are escalated to
+	IsTeamLead bool `json:"is_team_lead"`
+}
+
+const (
+	DefaultReviewerCount = 2
+	MaxReviewerCount     = 10
+
+	DefaultPairingLookback = 10
+	MaxPairingLookback     = 100
+)
+
+// TeamSettings holds per-team reviewer assignment knobs
+type TeamSettings struct {
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	MaxOpenReviews   *int       `json:"max_open_reviews,omitempty"`
+	TeamName         string     `json:"team_name"`
+	ReviewerStrategy string     `json:"reviewer_strategy"`
+	CodeOwnersMode   string     `json:"code_owners_mode"`
+	// SeniorLevel is the lowest seniority counted by MinSeniorReviewers
+	SeniorLevel        string `json:"senior_level"`
+	ReviewerCount      int    `json:"reviewer_count"`
+	MinReviewers       int    `json:"min_reviewers"`
+	MinSeniorReviewers int    `json:"min_senior_reviewers"`
+	// PairingLookback is the number of the author's latest PRs checked to avoid repeating the same reviewers, 0 disables it
+	PairingLookback int `json:"pairing_lookback"`
+	// MergePolicy is checked before merging PRs authored by the team's members
+	MergePolicy MergePolicy `json:"merge_policy"`
+	// ReviewSLAHours is the working hours the team's members have to review a PR, 0 disables the SLA.
+	// A breach is escalated to the team lead SLAEscalationHours working hours later
+	ReviewSLAHours     int `json:"review_sla_hours"`
+	SLAEscalationHours int `json:"sla_escalation_hours"`
+}
+
+// DefaultTeamSettings returns settings used for teams that have not been configured
+func DefaultTeamSettings(teamName string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:           teamName,
+		ReviewerStrategy:   DefaultReviewerStrategy,
+		CodeOwnersMode:     DefaultCodeOwnersMode,
+		SeniorLevel:        SenioritySenior,
+		ReviewerCount:      DefaultReviewerCount,
+		PairingLookback:    DefaultPairingLookback,
+		SLAEscalationHours: DefaultSLAEscalationHours,
+	}
 }
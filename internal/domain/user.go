@@ -1,6 +1,56 @@
 package domain
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Seniority levels, from the lowest to the highest
+const (
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
+	SeniorityLead   = "lead"
+
+	DefaultSeniority = SeniorityMiddle
+)
+
+var seniorityRanks = map[string]int{
+	SeniorityJunior: 1,
+	SeniorityMiddle: 2,
+	SenioritySenior: 3,
+	SeniorityLead:   4,
+}
+
+// ValidSeniority reports whether level is a known seniority level
+func ValidSeniority(level string) bool {
+	_, ok := seniorityRanks[level]
+	return ok
+}
+
+// SeniorityAtLeast reports whether level is minLevel or above. Unknown levels are never enough
+func SeniorityAtLeast(level, minLevel string) bool {
+	rank, ok := seniorityRanks[level]
+	return ok && rank >= seniorityRanks[minLevel]
+}
+
+// Notification preferences: immediate emails every notification, digest collects them for the daily digest
+const (
+	NotifyImmediate = "immediate"
+	NotifyDigest    = "digest"
+	NotifyOff       = "off"
+)
+
+func ValidNotificationPreference(preference string) bool {
+	return preference == NotifyImmediate || preference == NotifyDigest || preference == NotifyOff
+}
+
+// Daily digest schedule of new users
+const (
+	DefaultTimezone   = "UTC"
+	DefaultDigestHour = 9
+)
 
 type User struct {
 	CreatedAt time.Time `json:"created_at"`
@@ -9,4 +59,32 @@ type User struct {
 	Username  string    `json:"username"`
 	TeamName  string    `json:"team_name"`
 	IsActive  bool      `json:"is_active"`
+	// MaxOpenReviews is the personal cap of open reviews, nil means no cap
+	MaxOpenReviews *int    `json:"max_open_reviews,omitempty"`
+	ReviewWeight   float64 `json:"review_weight"`
+	// Skills are expertise tags used to match reviewers with PRs, nil means they are not set
+	Skills    []string `json:"skills"`
+	Seniority string   `json:"seniority"`
+	// Email is empty when the user has no address, such users get no emails
+	Email                  string `json:"email,omitempty"`
+	NotificationPreference string `json:"notification_preference"`
+	// Timezone is an IANA zone name, the daily digest is sent at DigestHour of the user's local time
+	Timezone   string `json:"timezone"`
+	DigestHour int    `json:"digest_hour"`
+}
+
+// NormalizeSkills lowercases and trims the skill tags, drops blanks and duplicates and sorts the result
+func NormalizeSkills(skills []string) []string {
+	seen := make(map[string]bool, len(skills))
+	result := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		result = append(result, skill)
+	}
+	sort.Strings(result)
+	return result
 }
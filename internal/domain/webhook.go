@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Event types webhooks can subscribe to
+const (
+	WebhookPRCreated          = "pr.created"
+	WebhookPRReady            = "pr.ready"
+	WebhookReviewerAssigned   = "reviewer.assigned"
+	WebhookReviewerReassigned = "reviewer.reassigned"
+	WebhookPRMerged           = "pr.merged"
+	WebhookUserDeactivated    = "user.deactivated"
+	WebhookSLABreached        = "sla.breached"
+	WebhookSLAEscalated       = "sla.escalated"
+)
+
+var webhookEventTypes = []string{
+	WebhookPRCreated,
+	WebhookPRReady,
+	WebhookReviewerAssigned,
+	WebhookReviewerReassigned,
+	WebhookPRMerged,
+	WebhookUserDeactivated,
+	WebhookSLABreached,
+	WebhookSLAEscalated,
+}
+
+// ValidWebhookEventType reports whether webhooks can subscribe to the event type
+func ValidWebhookEventType(eventType string) bool {
+	return slices.Contains(webhookEventTypes, eventType)
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	// MaxDeliveryAttempts is the number of attempts after which a delivery is failed
+	MaxDeliveryAttempts = 8
+	// deliveryBackoffBase is the delay after the first failed attempt, it doubles with every attempt
+	deliveryBackoffBase = 30 * time.Second
+	deliveryBackoffMax  = 6 * time.Hour
+	// deliveryLeaseMargin is added to the requests of a claimed batch to cover saving their results
+	deliveryLeaseMargin = time.Minute
+	// unboundedRequestLease is assumed for every request of a client without a timeout
+	unboundedRequestLease = time.Minute
+
+	DefaultDeliveryListLimit = 50
+	MaxDeliveryListLimit     = 200
+)
+
+// DeliveryBackoff returns the delay before the next attempt after the given number of failed attempts
+func DeliveryBackoff(attempts int) time.Duration {
+	delay := deliveryBackoffBase
+	for i := 1; i < attempts && delay < deliveryBackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, deliveryBackoffMax)
+}
+
+// DeliveryLease returns how long a batch of limit claimed deliveries stays hidden from other senders.
+// It outlasts limit requests that each take up to timeout, so no delivery is claimed twice
+func DeliveryLease(limit int, timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		timeout = unboundedRequestLease
+	}
+	return time.Duration(limit)*timeout + deliveryLeaseMargin
+}
+
+type WebhookSubscription struct {
+	CreatedAt  time.Time `json:"created_at"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	// Secret signs the payloads, it is returned only when the subscription is created
+	Secret    string `json:"-"`
+	CreatedBy string `json:"created_by"`
+	ID        int64  `json:"id"`
+	IsActive  bool   `json:"is_active"`
+}
+
+// WebhookEvent is the body of a webhook request
+type WebhookEvent struct {
+	OccurredAt time.Time `json:"occurred_at"`
+	// ID is shared by the deliveries of the event to all subscriptions, receivers use it to drop duplicates
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type WebhookDelivery struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	RedeliveryOf   *int64     `json:"redelivery_of,omitempty"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	// URL and Secret of the subscription, they are loaded only to send the delivery
+	URL       string          `json:"-"`
+	Secret    string          `json:"-"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    string          `json:"status"`
+	LastError string          `json:"last_error,omitempty"`
+	ID        int64           `json:"id"`
+	// SubscriptionID of the receiver
+	SubscriptionID int64 `json:"subscription_id"`
+	// OutboxID is the event the delivery was created from, an event published twice is queued once
+	// per subscription. It is 0 for redeliveries
+	OutboxID int64 `json:"-"`
+	Attempts int   `json:"attempts"`
+}
+
+// DeliveryFilter selects deliveries for the log. Empty fields do not filter
+type DeliveryFilter struct {
+	SubscriptionID int64
+	Status         string
+	EventType      string
+	// Cursor is the ID of the last delivery of the previous page
+	Cursor int64
+	Limit  int
+}
+
+// Webhook payloads
+
+// WebhookPRData describes a PR in pr.created, pr.ready and pr.merged events
+type WebhookPRData struct {
+	PullRequest PullRequestShort `json:"pull_request"`
+	Reviewers   []string         `json:"reviewers"`
+}
+
+// WebhookReviewerData describes a reviewer change in reviewer.assigned and reviewer.reassigned events
+type WebhookReviewerData struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	// PreviousReviewerID is set for reassignments
+	PreviousReviewerID string `json:"previous_reviewer_id,omitempty"`
+	Reason             string `json:"reason"`
+}
+
+// WebhookUserData describes a user in user.deactivated events
+type WebhookUserData struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+// WebhookSLAData describes a breached review in sla.breached and sla.escalated events
+type WebhookSLAData struct {
+	DueAt         time.Time `json:"due_at"`
+	BreachID      int64     `json:"breach_id"`
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	TeamName      string    `json:"team_name"`
+	SLAHours      int       `json:"sla_hours"`
+	// LeadID is the team lead the breach is escalated to, set in sla.escalated events
+	LeadID string `json:"lead_id,omitempty"`
+}
+
+// DeliveryPage is one page of the delivery log, newest first. NextCursor is 0 on the last page
+type DeliveryPage struct {
+	Deliveries []WebhookDelivery
+	NextCursor int64
+}
+
+// WebhookSignature returns the value of the X-Webhook-Signature-256 header for the body
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
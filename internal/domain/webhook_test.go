@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryLease(t *testing.T) {
+	// a batch of 50 requests of up to 5s each plus the margin for saving the results
+	assert.Equal(t, 50*5*time.Second+time.Minute, DeliveryLease(50, 5*time.Second))
+	// requests without a timeout count as a minute each
+	assert.Equal(t, 3*time.Minute, DeliveryLease(2, 0))
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, DeliveryBackoff(1))
+	assert.Equal(t, time.Minute, DeliveryBackoff(2))
+	assert.Equal(t, 6*time.Hour, DeliveryBackoff(MaxDeliveryAttempts+20))
+}
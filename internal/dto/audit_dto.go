@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type AuditChangeDTO struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditEntryDTO struct {
+	CreatedAt  time.Time                 `json:"created_at"`
+	ID         int64                     `json:"id"`
+	ActorID    string                    `json:"actor_id"`
+	RequestID  string                    `json:"request_id"`
+	Action     string                    `json:"action"`
+	TargetType string                    `json:"target_type"`
+	TargetID   string                    `json:"target_id"`
+	Changes    map[string]AuditChangeDTO `json:"changes"`
+	Outcome    string                    `json:"outcome"`
+	StatusCode int                       `json:"status_code"`
+	Error      string                    `json:"error,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type AbsenceDTO struct {
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	HandedOverAt    *time.Time `json:"handed_over_at,omitempty"`
+	UserID          string     `json:"user_id"`
+	Reason          string     `json:"reason"`
+	ID              int64      `json:"id"`
+	HandOverReviews bool       `json:"hand_over_reviews"`
+}
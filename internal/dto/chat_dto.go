@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type TeamChatSettingsDTO struct {
+	UpdatedAt  time.Time        `json:"updated_at"`
+	TeamName   string           `json:"team_name"`
+	WebhookURL string           `json:"webhook_url"`
+	Templates  ChatTemplatesDTO `json:"templates"`
+}
+
+// ChatTemplatesDTO holds the text/template message templates, an empty template means the default one
+type ChatTemplatesDTO struct {
+	Assigned   string `json:"assigned"`
+	Reassigned string `json:"reassigned"`
+	Merged     string `json:"merged"`
+}
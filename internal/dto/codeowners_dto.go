@@ -0,0 +1,8 @@
+package dto
+
+type CodeOwnerRuleDTO struct {
+	Pattern string   `json:"pattern"`
+	Users   []string `json:"users"`
+	Teams   []string `json:"teams"`
+	Line    int      `json:"line"`
+}
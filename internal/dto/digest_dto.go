@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type DigestDTO struct {
+	GeneratedAt   time.Time         `json:"generated_at"`
+	UserID        string            `json:"user_id"`
+	Username      string            `json:"username"`
+	Email         string            `json:"email,omitempty"`
+	Timezone      string            `json:"timezone"`
+	LocalDate     string            `json:"local_date"`
+	Reviews       []DigestReviewDTO `json:"reviews"`
+	Notifications []DigestItemDTO   `json:"notifications"`
+}
+
+type DigestReviewDTO struct {
+	AssignedAt      time.Time `json:"assigned_at"`
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	Author          string    `json:"author"`
+	WaitingSeconds  int64     `json:"waiting_seconds"`
+}
+
+type DigestItemDTO struct {
+	CreatedAt     time.Time `json:"created_at"`
+	EventType     string    `json:"event_type"`
+	PullRequestID string    `json:"pull_request_id"`
+	Summary       string    `json:"summary"`
+}
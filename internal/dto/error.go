@@ -13,7 +13,16 @@ const (
 	ErrCodeTeamExists  = "TEAM_EXISTS"
 	ErrCodePRExists    = "PR_EXISTS"
 	ErrCodePRMerged    = "PR_MERGED"
+	ErrCodeMergeBlock  = "MERGE_BLOCKED"
+	ErrCodePRNotOpen   = "PR_NOT_OPEN"
+	ErrCodeTransition  = "INVALID_STATUS_TRANSITION"
 	ErrCodeNotAssigned = "NOT_ASSIGNED"
+	ErrCodeAssigned    = "ALREADY_ASSIGNED"
+	ErrCodeIsAuthor    = "REVIEWER_IS_AUTHOR"
+	ErrCodeInactive    = "USER_INACTIVE"
 	ErrCodeNoCandidate = "NO_CANDIDATE"
+	ErrCodeNotEnough   = "NOT_ENOUGH_REVIEWERS"
+	ErrCodeAtCapacity  = "REVIEWERS_AT_CAPACITY"
+	ErrCodeNeedSenior  = "SENIOR_REVIEWER_REQUIRED"
 	ErrCodeNotFound    = "NOT_FOUND"
 )
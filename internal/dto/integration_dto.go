@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type IntegrationAccountDTO struct {
+	CreatedAt time.Time `json:"created_at"`
+	Provider  string    `json:"provider"`
+	Login     string    `json:"login"`
+	UserID    string    `json:"user_id"`
+}
@@ -3,13 +3,82 @@ package dto
 import "time"
 
 type PullRequestDTO struct {
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt          *time.Time  `json:"createdAt,omitempty"`
+	MergedAt           *time.Time  `json:"mergedAt,omitempty"`
+	ClosedAt           *time.Time  `json:"closedAt,omitempty"`
+	PullRequestID      string      `json:"pull_request_id"`
+	PullRequestName    string      `json:"pull_request_name"`
+	AuthorID           string      `json:"author_id"`
+	Status             string      `json:"status"`
+	AssignmentStrategy string      `json:"assignment_strategy"`
+	AssignedReviewers  []string    `json:"assigned_reviewers"`
+	FallbackReviewers  []string    `json:"fallback_reviewers"`
+	ChangedFiles       []string    `json:"changed_files"`
+	RequiredSkills     []string    `json:"required_skills"`
+	AssignmentSeed     *int64      `json:"assignment_seed,omitempty"`
+	Reviews            []ReviewDTO `json:"reviews"`
+	// Assignment explains the reviewer selection, it is returned only by operations that pick reviewers
+	Assignment *AssignmentReportDTO `json:"assignment,omitempty"`
+}
+
+type ReviewDTO struct {
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	UserID     string     `json:"user_id"`
+	State      string     `json:"state"`
+}
+
+type PREventDTO struct {
+	CreatedAt          time.Time `json:"created_at"`
+	ID                 int64     `json:"id"`
+	Type               string    `json:"type"`
+	ActorID            string    `json:"actor_id,omitempty"`
+	ReviewerID         string    `json:"reviewer_id,omitempty"`
+	PreviousReviewerID string    `json:"previous_reviewer_id,omitempty"`
+	FromStatus         string    `json:"from_status,omitempty"`
+	ToStatus           string    `json:"to_status,omitempty"`
+	ReviewState        string    `json:"review_state,omitempty"`
+	Reason             string    `json:"reason,omitempty"`
+}
+
+type AssignmentReportDTO struct {
+	AtCapacity         []string `json:"at_capacity"`
+	MissingSkills      []string `json:"missing_skills"`
+	RequestedReviewers int      `json:"requested_reviewers"`
+	AssignedReviewers  int      `json:"assigned_reviewers"`
+	Seed               int64    `json:"seed"`
+	SeniorShortfall    int      `json:"senior_shortfall"`
+	CapacityExhausted  bool     `json:"capacity_exhausted"`
+}
+
+type ReviewerCandidateDTO struct {
+	LastAssignedAt *time.Time `json:"last_assigned_at,omitempty"`
+	MaxOpenReviews *int       `json:"max_open_reviews,omitempty"`
+	UserID         string     `json:"user_id"`
+	OpenReviews    int        `json:"open_reviews"`
+	Tier           int        `json:"tier"`
+	Weight         float64    `json:"weight"`
+	Skills         []string   `json:"skills"`
+	Seniority      string     `json:"seniority,omitempty"`
+	RecentPairings int        `json:"recent_pairings"`
+}
+
+// ReassignmentExplanationDTO is a replacement picked by a strategy, replayed from its seed and candidates
+type ReassignmentExplanationDTO struct {
+	CreatedAt          time.Time              `json:"created_at"`
+	PreviousReviewerID string                 `json:"previous_reviewer_id"`
+	ReviewerID         string                 `json:"reviewer_id"`
+	Reason             string                 `json:"reason"`
+	Strategy           string                 `json:"strategy"`
+	Candidates         []ReviewerCandidateDTO `json:"candidates"`
+	RecordedReviewers  []string               `json:"recorded_reviewers"`
+	ReplayedReviewers  []string               `json:"replayed_reviewers"`
+	RequiredSkills     []string               `json:"required_skills"`
+	SeniorLevel        string                 `json:"senior_level,omitempty"`
+	SeniorsNeeded      int                    `json:"seniors_needed"`
+	Seed               int64                  `json:"seed"`
+	RequestedCount     int                    `json:"requested_count"`
+	EventID            int64                  `json:"event_id"`
+	Reproducible       bool                   `json:"reproducible"`
 }
 
 type PullRequestShortDTO struct {
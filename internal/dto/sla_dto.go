@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type SLABreachDTO struct {
+	AssignedAt    time.Time  `json:"assigned_at"`
+	DueAt         time.Time  `json:"due_at"`
+	BreachedAt    time.Time  `json:"breached_at"`
+	EscalatedAt   *time.Time `json:"escalated_at,omitempty"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+	EscalatedTo   string     `json:"escalated_to,omitempty"`
+	PullRequestID string     `json:"pull_request_id"`
+	ReviewerID    string     `json:"reviewer_id"`
+	TeamName      string     `json:"team_name"`
+	Status        string     `json:"status"`
+	ID            int64      `json:"id"`
+	SLAHours      int        `json:"sla_hours"`
+}
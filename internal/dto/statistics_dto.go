@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type PRReviewerDistributionDTO struct {
 	PullRequestID   string   `json:"pull_request_id"`
 	PullRequestName string   `json:"pull_request_name"`
@@ -8,3 +10,10 @@ type PRReviewerDistributionDTO struct {
 	ReviewerIDs     []string `json:"reviewer_ids"`
 	ReviewerCount   int      `json:"reviewer_count"`
 }
+
+type PairingDiversityPointDTO struct {
+	PeriodStart   *time.Time `json:"period_start,omitempty"`
+	Assignments   int        `json:"assignments"`
+	DistinctPairs int        `json:"distinct_pairs"`
+	Diversity     float64    `json:"diversity"`
+}
@@ -1,12 +1,39 @@
 package dto
 
+import "time"
+
 type TeamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+	Skills    []string `json:"skills"`
+	Seniority string   `json:"seniority"`
+	IsActive  bool     `json:"is_active"`
+	// IsTeamLead marks the member SLA breaches are escalated to
+	IsTeamLead bool `json:"is_team_lead"`
 }
 
 type TeamDTO struct {
-	TeamName string          `json:"team_name"`
-	Members  []TeamMemberDTO `json:"members"`
+	TeamName         string          `json:"team_name"`
+	ReviewerStrategy string          `json:"reviewer_strategy"`
+	Members          []TeamMemberDTO `json:"members"`
+}
+
+type TeamSettingsDTO struct {
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	MaxOpenReviews     *int       `json:"max_open_reviews,omitempty"`
+	TeamName           string     `json:"team_name"`
+	ReviewerStrategy   string     `json:"reviewer_strategy"`
+	CodeOwnersMode     string     `json:"code_owners_mode"`
+	SeniorLevel        string     `json:"senior_level"`
+	ReviewerCount      int        `json:"reviewer_count"`
+	MinReviewers       int        `json:"min_reviewers"`
+	MinSeniorReviewers int        `json:"min_senior_reviewers"`
+	PairingLookback    int        `json:"pairing_lookback"`
+	// Merge policy
+	MergeMinApprovals            int  `json:"merge_min_approvals"`
+	MergeBlockOnChangesRequested bool `json:"merge_block_on_changes_requested"`
+	MergeRequireTeamApproval     bool `json:"merge_require_team_approval"`
+	// Review SLA in working hours
+	ReviewSLAHours     int `json:"review_sla_hours"`
+	SLAEscalationHours int `json:"sla_escalation_hours"`
 }
@@ -1,10 +1,19 @@
 package dto
 
 type UserDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	ReviewWeight   float64  `json:"review_weight"`
+	Skills         []string `json:"skills"`
+	Seniority      string   `json:"seniority"`
+	// Email is omitted when the user has no address
+	Email                  string `json:"email,omitempty"`
+	NotificationPreference string `json:"notification_preference"`
+	Timezone               string `json:"timezone"`
+	DigestHour             int    `json:"digest_hour"`
 }
 
 type UserAssignmentStatDTO struct {
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookSubscriptionDTO struct {
+	CreatedAt  time.Time `json:"created_at"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedBy  string    `json:"created_by"`
+	ID         int64     `json:"id"`
+	IsActive   bool      `json:"is_active"`
+}
+
+type WebhookDeliveryDTO struct {
+	CreatedAt      time.Time       `json:"created_at"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	RedeliveryOf   *int64          `json:"redelivery_of,omitempty"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	LastError      string          `json:"last_error,omitempty"`
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	Attempts       int             `json:"attempts"`
+}
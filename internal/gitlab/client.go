@@ -0,0 +1,104 @@
+// Package gitlab is a minimal client of the GitLab REST API
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBodySize limits the part of an error response kept in the error
+const maxErrorBodySize = 512
+
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient returns a client of the GitLab instance at baseURL, e.g. https://gitlab.com, authenticated with a personal or project access token
+func NewClient(baseURL, token string, client *http.Client) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  client,
+	}
+}
+
+// SetReviewers replaces the reviewers of the merge request with the users of the given usernames.
+// Usernames unknown to GitLab are skipped
+func (c *Client) SetReviewers(ctx context.Context, projectID int64, iid int, usernames []string) error {
+	reviewerIDs := make([]int64, 0, len(usernames))
+	for _, username := range usernames {
+		userID, found, err := c.userID(ctx, username)
+		if err != nil {
+			return err
+		}
+		if found {
+			reviewerIDs = append(reviewerIDs, userID)
+		}
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	body := map[string][]int64{"reviewer_ids": reviewerIDs}
+	path := fmt.Sprintf("/api/v4/projects/%d/merge_requests/%d", projectID, iid)
+	return c.do(ctx, http.MethodPut, path, body, nil)
+}
+
+func (c *Client) userID(ctx context.Context, username string) (int64, bool, error) {
+	var users []struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/v4/users?username="+url.QueryEscape(username), nil, &users); err != nil {
+		return 0, false, err
+	}
+	if len(users) == 0 {
+		return 0, false, nil
+	}
+	return users[0].ID, true, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gitlab request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("gitlab %s %s responded with %d: %s", method, path, resp.StatusCode, bytes.TrimSpace(errBody))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode gitlab response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type AuditService interface {
+	ListEntries(ctx context.Context, filter domain.AuditFilter) (*domain.AuditPage, error)
+	ExportEntries(ctx context.Context, filter domain.AuditFilter, write func(domain.AuditEntry) error) error
+}
+
+type AuditHandler struct {
+	service AuditService
+}
+
+func NewAuditHandler(service AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// GetAuditLog godoc
+// @Summary Get the audit log (Admin only)
+// @Description List mutating API calls newest first with their actor, request ID, target, changed fields and outcome.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Description With format=jsonl every matching entry is exported as JSON lines, limit and cursor are ignored
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param actor_id query string false "User who made the call"
+// @Param action query string false "HTTP method and path, e.g. POST /users/setIsActive"
+// @Param target_type query string false "Type of the changed object: team, user, absence, pull_request, codeowners, deactivated_users"
+// @Param target_id query string false "ID of the changed object"
+// @Param outcome query string false "success or failure"
+// @Param from query string false "Lower bound of the call time, RFC 3339"
+// @Param to query string false "Upper bound of the call time, RFC 3339, exclusive"
+// @Param limit query int false "Page size, 1-500, 50 by default"
+// @Param cursor query int false "next_cursor of the previous page"
+// @Param format query string false "json (default) or jsonl"
+// @Success 200 {object} response.AuditLogResponse "Audit log retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/audit [get]
+func (h *AuditHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+	case "jsonl":
+		h.exportAuditLog(w, r, filter)
+		return
+	default:
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "format must be json or jsonl")
+		return
+	}
+
+	page, err := h.service.ListEntries(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapAuditPageToResponse(page))
+}
+
+// exportAuditLog writes one JSON object per line. Once the first line is sent errors can only be logged
+func (h *AuditHandler) exportAuditLog(w http.ResponseWriter, r *http.Request, filter domain.AuditFilter) {
+	// the export may outlive the server's write timeout, it is limited by the route's deadline instead
+	if deadline, ok := r.Context().Deadline(); ok {
+		if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			slog.Warn("failed to extend audit export write deadline", "error", err)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	started := false
+	err := h.service.ExportEntries(r.Context(), filter, func(entry domain.AuditEntry) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		return encoder.Encode(mapper.MapAuditEntryToDTO(&entry))
+	})
+
+	switch {
+	case err == nil && !started:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	case err == nil:
+	case started:
+		slog.Error("failed to export audit log", "error", err)
+	case errors.Is(err, my_errors.ErrInvalidInput):
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+	}
+}
+
+func parseAuditFilter(r *http.Request) (domain.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := domain.AuditFilter{
+		ActorID:    query.Get("actor_id"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+		Outcome:    query.Get("outcome"),
+	}
+
+	var err error
+	if filter.From, err = queryTime(r, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = queryTime(r, "to"); err != nil {
+		return filter, err
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, errors.New("limit must be an integer")
+		}
+	}
+	if value := query.Get("cursor"); value != "" {
+		if filter.Cursor, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return filter, errors.New("cursor must be an integer")
+		}
+	}
+
+	return filter, nil
+}
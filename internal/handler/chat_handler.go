@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+	"pr-reviewer-service/internal/request"
+
+	"github.com/go-playground/validator/v10"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type ChatService interface {
+	GetSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error)
+	SetSettings(ctx context.Context, settings *domain.TeamChatSettings) (*domain.TeamChatSettings, error)
+	DeleteSettings(ctx context.Context, teamName string) error
+}
+
+type ChatHandler struct {
+	service   ChatService
+	validator *validator.Validate
+}
+
+func NewChatHandler(service ChatService, validator *validator.Validate) *ChatHandler {
+	return &ChatHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetSettings godoc
+// @Summary Get team chat notifications (Admin only)
+// @Description Get the incoming webhook and the message templates of the team's chat
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string true "Team name"
+// @Success 200 {object} response.TeamChatSettingsResponse "Chat settings retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team chat is not configured"
+// @Router /team/chat [get]
+func (h *ChatHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), teamName)
+	if err != nil {
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrChatNotConfigured.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapTeamChatSettingsToResponse(settings))
+}
+
+// SetSettings godoc
+// @Summary Set team chat notifications (Admin only)
+// @Description Post assignments, reassignments and merges of PRs authored by the team's members to a Slack or Mattermost compatible incoming webhook.
+// @Description Templates use Go text/template syntax with .Event, .PullRequest.ID, .PullRequest.Name, .Author, .Reviewers and .PreviousReviewer,
+// @Description users have .UserID and .Username, {{mention .Author}} renders @username and {{mentions .Reviewers}} joins several mentions.
+// @Description Omitted templates use the defaults. Failed notifications are logged and never fail the PR operation
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetTeamChatRequest true "Chat settings"
+// @Success 200 {object} response.TeamChatSettingsResponse "Chat settings saved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or template"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/chat [put]
+func (h *ChatHandler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	var req request.SetTeamChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	settings, err := h.service.SetSettings(r.Context(), mapper.MapSetTeamChatRequestToDomain(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrTeamNotFound.Error())
+		case errors.Is(err, my_errors.ErrInvalidInput) || errors.Is(err, my_errors.ErrEmptyField):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapTeamChatSettingsToResponse(settings))
+}
+
+// DeleteSettings godoc
+// @Summary Stop team chat notifications (Admin only)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string true "Team name"
+// @Success 204 "Chat notifications stopped"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team chat is not configured"
+// @Router /team/chat [delete]
+func (h *ChatHandler) DeleteSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	if err := h.service.DeleteSettings(r.Context(), teamName); err != nil {
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrChatNotConfigured.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
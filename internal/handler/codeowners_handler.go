@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+	"pr-reviewer-service/internal/request"
+
+	"github.com/go-playground/validator/v10"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type CodeOwnersService interface {
+	UploadRules(ctx context.Context, content string) ([]domain.CodeOwnerRule, error)
+	GetRules(ctx context.Context) ([]domain.CodeOwnerRule, error)
+}
+
+type CodeOwnersHandler struct {
+	service   CodeOwnersService
+	validator *validator.Validate
+}
+
+func NewCodeOwnersHandler(service CodeOwnersService, validator *validator.Validate) *CodeOwnersHandler {
+	return &CodeOwnersHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetRules godoc
+// @Summary Get code owner rules
+// @Description Get path ownership rules used for reviewer assignment, in file order
+// @Tags CodeOwners
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.CodeOwnersResponse "Rules retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /codeowners [get]
+func (h *CodeOwnersHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetRules(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapCodeOwnerRulesToDTO(rules))
+}
+
+// UploadRules godoc
+// @Summary Upload code owner rules (Admin only)
+// @Description Replace path ownership rules with the content of a file in GitHub CODEOWNERS syntax. Owners @org/team are teams, @user are users
+// @Tags CodeOwners
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.UploadCodeOwnersRequest true "CODEOWNERS file content"
+// @Success 200 {object} response.CodeOwnersResponse "Rules uploaded successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or CODEOWNERS syntax"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /codeowners [put]
+func (h *CodeOwnersHandler) UploadRules(w http.ResponseWriter, r *http.Request) {
+	var req request.UploadCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	rules, err := h.service.UploadRules(r.Context(), req.Content)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidCodeOwners) || errors.Is(err, my_errors.ErrEmptyField) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapCodeOwnerRulesToDTO(rules))
+}
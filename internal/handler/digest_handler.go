@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type DigestService interface {
+	PreviewDigest(ctx context.Context, userID string) (*domain.DigestPreview, error)
+}
+
+type DigestHandler struct {
+	service DigestService
+}
+
+func NewDigestHandler(service DigestService) *DigestHandler {
+	return &DigestHandler{service: service}
+}
+
+// PreviewDigest godoc
+// @Summary Preview user daily digest (Admin only)
+// @Description Build the daily digest of the user as it would be emailed now: their OPEN reviews with how long each has been waiting,
+// @Description the longest waiting first, and the notifications queued for the digest. Nothing is sent or marked as sent.
+// @Description Email is the only digest channel, team chats do not get digests. will_send is false with a reason when the user would get no digest
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string true "User ID"
+// @Success 200 {object} response.DigestPreviewResponse "Digest preview"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/users/digest [get]
+func (h *DigestHandler) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "user_id query parameter is required")
+		return
+	}
+
+	preview, err := h.service.PreviewDigest(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapDigestPreviewToResponse(preview))
+}
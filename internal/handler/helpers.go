@@ -2,10 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/middleware"
 )
 
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -33,3 +36,22 @@ func respondWithError(w http.ResponseWriter, status int, errResp *dto.ErrorRespo
 		slog.Warn("failed to encode error response", "error", err)
 	}
 }
+
+// currentUserID returns the authenticated user set by AuthMiddleware
+func currentUserID(r *http.Request) string {
+	userID, _ := r.Context().Value(middleware.UserIDKey).(string)
+	return userID
+}
+
+// queryTime parses an optional RFC 3339 query parameter
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+	}
+	return &parsed, nil
+}
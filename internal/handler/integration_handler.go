@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+	"pr-reviewer-service/internal/request"
+	"pr-reviewer-service/internal/response"
+
+	"github.com/go-playground/validator/v10"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// maxWebhookBodySize is the largest payload GitHub and GitLab send
+const maxWebhookBodySize = 25 << 20
+
+type IntegrationService interface {
+	SetAccount(ctx context.Context, account *domain.IntegrationAccount) (*domain.IntegrationAccount, error)
+	ListAccounts(ctx context.Context, provider string) ([]domain.IntegrationAccount, error)
+	VerifyGitHubSignature(body []byte, signature string) error
+	HandleGitHubPullRequest(ctx context.Context, deliveryID string, event domain.GitHubPullRequestEvent) (*domain.IngestResult, error)
+	VerifyGitLabToken(token string) error
+	HandleGitLabMergeRequest(ctx context.Context, deliveryID string, event domain.GitLabMergeRequestEvent) (*domain.IngestResult, error)
+}
+
+type IntegrationHandler struct {
+	service   IntegrationService
+	validator *validator.Validate
+}
+
+func NewIntegrationHandler(service IntegrationService, validator *validator.Validate) *IntegrationHandler {
+	return &IntegrationHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GitHubWebhook godoc
+// @Summary Receive a GitHub webhook
+// @Description Apply pull_request events of GitHub: opened creates the PR (as a draft for draft PRs), ready_for_review marks it ready,
+// @Description closed merges or closes it and reopened reopens it. Other events and actions are ignored.
+// @Description The PR ID is "<owner>/<repo>#<number>", the author is resolved through the github integration accounts.
+// @Description The body must be signed with the configured secret in X-Hub-Signature-256. A delivery is applied once per X-GitHub-Delivery
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Param X-GitHub-Event header string true "Event name"
+// @Param X-GitHub-Delivery header string true "Delivery ID"
+// @Param X-Hub-Signature-256 header string true "sha256= followed by the hex HMAC-SHA256 of the body"
+// @Param request body request.GitHubPullRequestPayload true "GitHub webhook payload"
+// @Success 200 {object} response.IngestResponse "Event applied, ignored or already applied"
+// @Failure 400 {object} dto.ErrorResponse "Invalid payload"
+// @Failure 401 {object} dto.ErrorResponse "Invalid signature"
+// @Failure 404 {object} dto.ErrorResponse "PR or author not found"
+// @Failure 409 {object} dto.ErrorResponse "PR cannot change its status"
+// @Failure 422 {object} dto.ErrorResponse "GitHub login is not mapped to a user"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 503 {object} dto.ErrorResponse "GitHub integration is not configured"
+// @Router /integrations/github/webhook [post]
+func (h *IntegrationHandler) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.service.VerifyGitHubSignature(body, r.Header.Get("X-Hub-Signature-256")); err != nil {
+		if errors.Is(err, my_errors.ErrIntegrationNotSet) {
+			respondError(w, http.StatusServiceUnavailable, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusUnauthorized, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	if event != "pull_request" {
+		respondJSON(w, http.StatusOK, response.IngestResponse{Outcome: domain.IngestIgnored, Event: event})
+		return
+	}
+
+	var payload request.GitHubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	result, err := h.service.HandleGitHubPullRequest(
+		r.Context(),
+		r.Header.Get("X-GitHub-Delivery"),
+		mapper.MapGitHubPullRequestPayloadToDomain(&payload),
+	)
+	if err != nil {
+		respondIngestError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapIngestResultToResponse(event, result))
+}
+
+// GitLabWebhook godoc
+// @Summary Receive a GitLab webhook
+// @Description Apply Merge Request Hook events of GitLab: open creates the PR (as a draft for draft merge requests),
+// @Description an update that removes the draft flag marks it ready, close closes, merge merges and reopen reopens it. Other events and actions are ignored.
+// @Description The PR ID is "<project path>!<iid>", the author is resolved through the gitlab integration accounts.
+// @Description X-Gitlab-Token must be the configured secret token. A delivery is applied once per X-Gitlab-Event-UUID.
+// @Description When a GitLab API token is configured, the assigned reviewers are set as reviewers of the merge request
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Param X-Gitlab-Event header string true "Event name"
+// @Param X-Gitlab-Event-UUID header string true "Delivery ID"
+// @Param X-Gitlab-Token header string true "Secret token of the webhook"
+// @Param request body request.GitLabMergeRequestPayload true "GitLab webhook payload"
+// @Success 200 {object} response.IngestResponse "Event applied, ignored or already applied"
+// @Failure 400 {object} dto.ErrorResponse "Invalid payload"
+// @Failure 401 {object} dto.ErrorResponse "Invalid token"
+// @Failure 404 {object} dto.ErrorResponse "PR or author not found"
+// @Failure 409 {object} dto.ErrorResponse "PR cannot change its status"
+// @Failure 422 {object} dto.ErrorResponse "GitLab username is not mapped to a user"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Failure 503 {object} dto.ErrorResponse "GitLab integration is not configured"
+// @Router /integrations/gitlab/webhook [post]
+func (h *IntegrationHandler) GitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.VerifyGitLabToken(r.Header.Get("X-Gitlab-Token")); err != nil {
+		if errors.Is(err, my_errors.ErrIntegrationNotSet) {
+			respondError(w, http.StatusServiceUnavailable, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusUnauthorized, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	event := r.Header.Get("X-Gitlab-Event")
+	if event != "Merge Request Hook" {
+		respondJSON(w, http.StatusOK, response.IngestResponse{Outcome: domain.IngestIgnored, Event: event})
+		return
+	}
+
+	var payload request.GitLabMergeRequestPayload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBodySize)).Decode(&payload); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	// older GitLab versions send only Idempotency-Key
+	deliveryID := r.Header.Get("X-Gitlab-Event-UUID")
+	if deliveryID == "" {
+		deliveryID = r.Header.Get("Idempotency-Key")
+	}
+
+	result, err := h.service.HandleGitLabMergeRequest(r.Context(), deliveryID, mapper.MapGitLabMergeRequestPayloadToDomain(&payload))
+	if err != nil {
+		respondIngestError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapIngestResultToResponse(event, result))
+}
+
+func respondIngestError(w http.ResponseWriter, err error) {
+	if respondStatusChangeError(w, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, my_errors.ErrAccountNotMapped):
+		respondError(w, http.StatusUnprocessableEntity, dto.ErrCodeNotFound, err.Error())
+	case errors.Is(err, my_errors.ErrMergeBlocked):
+		respondError(w, http.StatusConflict, dto.ErrCodeMergeBlock, err.Error())
+	case errors.Is(err, my_errors.ErrInvalidInput) || errors.Is(err, my_errors.ErrEmptyField):
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+	}
+}
+
+// SetAccount godoc
+// @Summary Map an external login to a user (Admin only)
+// @Description Link a GitHub login or a GitLab username to a user, webhooks of the login's PRs are attributed to the user. Logins are case-insensitive
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetIntegrationAccountRequest true "Login mapping"
+// @Success 200 {object} response.IntegrationAccountResponse "Login mapped successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/integrations/accounts [put]
+func (h *IntegrationHandler) SetAccount(w http.ResponseWriter, r *http.Request) {
+	var req request.SetIntegrationAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	account, err := h.service.SetAccount(r.Context(), mapper.MapSetIntegrationAccountRequestToDomain(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+		case errors.Is(err, my_errors.ErrInvalidInput) || errors.Is(err, my_errors.ErrEmptyField):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response.IntegrationAccountResponse{
+		Account: mapper.MapIntegrationAccountToDTO(account),
+	})
+}
+
+// ListAccounts godoc
+// @Summary List external login mappings (Admin only)
+// @Tags Integrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider query string false "Provider, github or gitlab"
+// @Success 200 {object} response.IntegrationAccountsResponse "Mappings retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/integrations/accounts [get]
+func (h *IntegrationHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.ListAccounts(r.Context(), r.URL.Query().Get("provider"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapIntegrationAccountsToResponse(accounts))
+}
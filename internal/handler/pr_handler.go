@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"pr-reviewer-service/internal/dto"
 	"pr-reviewer-service/internal/mapper"
@@ -20,7 +21,17 @@ import (
 type PRService interface {
 	CreatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) (string, *domain.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*domain.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, userID, state string) (*domain.PullRequest, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (string, *domain.PullRequest, error)
+	AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error)
+	ExplainAssignment(ctx context.Context, prID string) (*domain.AssignmentExplanation, error)
+	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ListPRs(ctx context.Context, filter domain.PRFilter) (*domain.PRPage, error)
+	GetTimeline(ctx context.Context, prID string) ([]domain.PREvent, error)
 }
 
 type PRHandler struct {
@@ -37,7 +48,9 @@ func NewPRHandler(service PRService, validator *validator.Validate) *PRHandler {
 
 // CreatePR godoc
 // @Summary Create a new pull request
-// @Description Create a PR and automatically assign up to 2 reviewers from author's team
+// @Description Create a PR and automatically assign reviewers from author's team according to the team settings.
+// @Description Reviewers covering required_skills are preferred, skills nobody covers are listed in assignment.missing_skills.
+// @Description Drafts are created without reviewers, they are assigned when the PR is marked ready
 // @Tags PullRequests
 // @Accept json
 // @Produce json
@@ -47,7 +60,7 @@ func NewPRHandler(service PRService, validator *validator.Validate) *PRHandler {
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "Author not found"
-// @Failure 409 {object} dto.ErrorResponse "PR already exists"
+// @Failure 409 {object} dto.ErrorResponse "PR already exists, not enough reviewers, reviewers at capacity or no senior reviewer"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /pullRequest/create [post]
 func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
@@ -83,6 +96,30 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 				},
 			})
 			return
+		case errors.Is(err, my_errors.ErrReviewersAtCapacity):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeAtCapacity,
+					Message: err.Error(),
+				},
+			})
+			return
+		case errors.Is(err, my_errors.ErrNotEnoughReviewers):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNotEnough,
+					Message: err.Error(),
+				},
+			})
+			return
+		case errors.Is(err, my_errors.ErrSeniorReviewerRequired):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNeedSenior,
+					Message: err.Error(),
+				},
+			})
+			return
 		default:
 			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
 			return
@@ -98,7 +135,7 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 
 // MergePR godoc
 // @Summary Merge a pull request
-// @Description Mark PR as MERGED (idempotent operation)
+// @Description Mark PR as MERGED (idempotent operation). The merge policy of the author's team has to be satisfied
 // @Tags PullRequests
 // @Accept json
 // @Produce json
@@ -108,6 +145,7 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "Merge policy is not satisfied or PR is closed or a draft"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /pullRequest/merge [post]
 func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
@@ -133,6 +171,18 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+		if respondStatusChangeError(w, err) {
+			return
+		}
+		if errors.Is(err, my_errors.ErrMergeBlocked) {
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeMergeBlock,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
 		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
 		return
 	}
@@ -144,9 +194,189 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, resp)
 }
 
+// ClosePR godoc
+// @Summary Close a pull request
+// @Description Close an open PR or a draft without merging it (idempotent operation). Reviewers stay assigned but the PR no longer counts towards their load
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ClosePRRequest true "Close PR request"
+// @Success 200 {object} response.PRResponse "PR closed successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "PR already merged"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/close [post]
+func (h *PRHandler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	var req request.ClosePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.ClosePR(r.Context(), req.PullRequestID)
+	if err != nil {
+		if !respondStatusChangeError(w, err) {
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReopenPR godoc
+// @Summary Reopen a pull request
+// @Description Return a closed PR to OPEN (idempotent operation). A PR closed before it got reviewers returns to DRAFT
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ReopenPRRequest true "Reopen PR request"
+// @Success 200 {object} response.PRResponse "PR reopened successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "PR already merged"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/reopen [post]
+func (h *PRHandler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	var req request.ReopenPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.ReopenPR(r.Context(), req.PullRequestID)
+	if err != nil {
+		if !respondStatusChangeError(w, err) {
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// MarkReady godoc
+// @Summary Mark a draft ready for review
+// @Description Move a draft to OPEN and assign reviewers according to the team settings (idempotent operation)
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.MarkReadyRequest true "Mark ready request"
+// @Success 200 {object} response.PRResponse "PR marked ready successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR or author not found"
+// @Failure 409 {object} dto.ErrorResponse "PR is closed or merged, not enough reviewers, reviewers at capacity or no senior reviewer"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/markReady [post]
+func (h *PRHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	var req request.MarkReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.MarkReady(r.Context(), req.PullRequestID)
+	if err != nil {
+		if !respondStatusChangeError(w, err) {
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SubmitReview godoc
+// @Summary Submit a review
+// @Description Set the review state of the current user on the PR: pending, approved, changes_requested or commented
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SubmitReviewRequest true "Submit review request"
+// @Success 200 {object} response.PRResponse "Review submitted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "PR is not open or user is not assigned"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/review [post]
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req request.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.SubmitReview(r.Context(), req.PullRequestID, currentUserID(r), req.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrPRNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+		case errors.Is(err, my_errors.ErrPRAlreadyMerged):
+			respondError(w, http.StatusConflict, dto.ErrCodePRMerged, my_errors.ErrPRAlreadyMerged.Error())
+		case errors.Is(err, my_errors.ErrPRNotOpen):
+			respondError(w, http.StatusConflict, dto.ErrCodePRNotOpen, err.Error())
+		case errors.Is(err, my_errors.ErrReviewerIsNotAssigned):
+			respondError(w, http.StatusConflict, dto.ErrCodeNotAssigned, my_errors.ErrReviewerIsNotAssigned.Error())
+		case errors.Is(err, my_errors.ErrInvalidInput), errors.Is(err, my_errors.ErrEmptyField):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
 // ReassignReviewer godoc
 // @Summary Reassign a reviewer on PR
-// @Description Replace one reviewer with another from the same team
+// @Description Replace one reviewer with new_user_id or, when it is omitted, with another member of the same team picked by the team's strategy.
+// @Description The chosen user must be active, must not be the author and must not be assigned already
 // @Tags PullRequests
 // @Accept json
 // @Produce json
@@ -156,7 +386,7 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 404 {object} dto.ErrorResponse "PR or user not found"
-// @Failure 409 {object} dto.ErrorResponse "Cannot reassign (PR merged, user not assigned, or no candidates)"
+// @Failure 409 {object} dto.ErrorResponse "Cannot reassign (PR merged, user not assigned, chosen user is the author, inactive or already assigned, no candidates, candidates at capacity or no senior to replace a senior)"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /pullRequest/reassign [post]
 func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
@@ -171,38 +401,33 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newReviewerID, updatedPR, err := h.service.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	newReviewerID, updatedPR, err := h.service.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
-		switch {
-		case errors.Is(err, my_errors.ErrPRNotFound):
-			respondWithError(w, http.StatusNotFound, &dto.ErrorResponse{
-				Error: dto.ErrorDetail{
-					Code:    dto.ErrCodeNotFound,
-					Message: my_errors.ErrPRNotFound.Error(),
-				},
-			})
+		if respondManualReviewerError(w, err) {
 			return
-		case errors.Is(err, my_errors.ErrUserNotFound):
-			respondWithError(w, http.StatusNotFound, &dto.ErrorResponse{
+		}
+		switch {
+		case errors.Is(err, my_errors.ErrReviewerIsNotAssigned):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
 				Error: dto.ErrorDetail{
-					Code:    dto.ErrCodeNotFound,
-					Message: my_errors.ErrUserNotFound.Error(),
+					Code:    dto.ErrCodeNotAssigned,
+					Message: my_errors.ErrReviewerIsNotAssigned.Error(),
 				},
 			})
 			return
-		case errors.Is(err, my_errors.ErrPRAlreadyMerged):
+		case errors.Is(err, my_errors.ErrReviewersAtCapacity):
 			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
 				Error: dto.ErrorDetail{
-					Code:    dto.ErrCodePRMerged,
-					Message: my_errors.ErrPRAlreadyMerged.Error(),
+					Code:    dto.ErrCodeAtCapacity,
+					Message: err.Error(),
 				},
 			})
 			return
-		case errors.Is(err, my_errors.ErrReviewerIsNotAssigned):
+		case errors.Is(err, my_errors.ErrSeniorReviewerRequired):
 			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
 				Error: dto.ErrorDetail{
-					Code:    dto.ErrCodeNotAssigned,
-					Message: my_errors.ErrReviewerIsNotAssigned.Error(),
+					Code:    dto.ErrCodeNeedSenior,
+					Message: err.Error(),
 				},
 			})
 			return
@@ -227,3 +452,352 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, resp)
 }
+
+// AddReviewer godoc
+// @Summary Add a reviewer to PR
+// @Description Assign one more reviewer chosen by the caller.
+// @Description The user must be active, must not be the author and must not be assigned already
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.AddReviewerRequest true "Add reviewer request"
+// @Success 200 {object} response.PRResponse "Reviewer added successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR or user not found"
+// @Failure 409 {object} dto.ErrorResponse "Cannot add (PR merged, user is the author, inactive or already assigned)"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/addReviewer [post]
+func (h *PRHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req request.AddReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		if !respondManualReviewerError(w, err) {
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RemoveReviewer godoc
+// @Summary Remove a reviewer from PR
+// @Description Unassign the reviewer without a replacement. The PR keeps at least min_reviewers and min_senior_reviewers of the author's team
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.RemoveReviewerRequest true "Remove reviewer request"
+// @Success 200 {object} response.PRResponse "Reviewer removed successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 409 {object} dto.ErrorResponse "Cannot remove (PR merged, user not assigned, not enough reviewers or seniors left)"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/removeReviewer [post]
+func (h *PRHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req request.RemoveReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	updatedPR, err := h.service.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrReviewerIsNotAssigned):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNotAssigned,
+					Message: my_errors.ErrReviewerIsNotAssigned.Error(),
+				},
+			})
+		case errors.Is(err, my_errors.ErrNotEnoughReviewers):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNotEnough,
+					Message: err.Error(),
+				},
+			})
+		case errors.Is(err, my_errors.ErrSeniorReviewerRequired):
+			respondWithError(w, http.StatusConflict, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNeedSenior,
+					Message: err.Error(),
+				},
+			})
+		case respondManualReviewerError(w, err):
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		}
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(updatedPR),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// respondManualReviewerError writes the response for errors of a reviewer chosen by the caller.
+// It reports whether the error was handled
+func respondManualReviewerError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, my_errors.ErrPRNotFound):
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+	case errors.Is(err, my_errors.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+	case errors.Is(err, my_errors.ErrPRAlreadyMerged):
+		respondError(w, http.StatusConflict, dto.ErrCodePRMerged, my_errors.ErrPRAlreadyMerged.Error())
+	case errors.Is(err, my_errors.ErrPRNotOpen):
+		respondError(w, http.StatusConflict, dto.ErrCodePRNotOpen, err.Error())
+	case errors.Is(err, my_errors.ErrReviewerIsAuthor):
+		respondError(w, http.StatusConflict, dto.ErrCodeIsAuthor, my_errors.ErrReviewerIsAuthor.Error())
+	case errors.Is(err, my_errors.ErrUserIsNotActive):
+		respondError(w, http.StatusConflict, dto.ErrCodeInactive, err.Error())
+	case errors.Is(err, my_errors.ErrReviewerAlreadyAssigned):
+		respondError(w, http.StatusConflict, dto.ErrCodeAssigned, my_errors.ErrReviewerAlreadyAssigned.Error())
+	default:
+		return false
+	}
+	return true
+}
+
+// GetPR godoc
+// @Summary Get a pull request
+// @Description Get the PR with its reviewers and their review states
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} response.PRResponse "PR retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/get [get]
+func (h *PRHandler) GetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "pull_request_id query parameter is required")
+		return
+	}
+
+	pr, err := h.service.GetPR(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrPRNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	resp := response.PRResponse{
+		PR: mapper.MapDomainPRToDTO(pr),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetTimeline godoc
+// @Summary Get the history of a pull request
+// @Description Get every change of the PR oldest first: creation, reviewer assignments, removals and reassignments
+// @Description with their reason, reviews, merge and status changes. actor_id is omitted for changes made by background jobs
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} response.PRTimelineResponse "PR timeline retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "PR not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/timeline [get]
+func (h *PRHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "pull_request_id query parameter is required")
+		return
+	}
+
+	events, err := h.service.GetTimeline(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrPRNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapPRTimelineToResponse(prID, events))
+}
+
+// ListPRs godoc
+// @Summary List pull requests
+// @Description List PRs newest first with optional filters. Time ranges include the lower bound and exclude the upper one.
+// @Description Pass next_cursor of the response as cursor to get the next page
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param author_id query string false "Author ID"
+// @Param reviewer_id query string false "Assigned reviewer ID"
+// @Param team_name query string false "Team of the author"
+// @Param status query string false "Status" Enums(OPEN, MERGED, CLOSED, DRAFT)
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param merged_from query string false "Merged at or after (RFC 3339)"
+// @Param merged_to query string false "Merged before (RFC 3339)"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Success 200 {object} response.PRListResponse "PRs retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /pullRequest/list [get]
+func (h *PRHandler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	filter, err := parsePRFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	page, err := h.service.ListPRs(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapPRPageToResponse(page))
+}
+
+func parsePRFilter(r *http.Request) (domain.PRFilter, error) {
+	query := r.URL.Query()
+	filter := domain.PRFilter{
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		TeamName:   query.Get("team_name"),
+		Status:     query.Get("status"),
+	}
+
+	var err error
+	if filter.CreatedFrom, err = queryTime(r, "created_from"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = queryTime(r, "created_to"); err != nil {
+		return filter, err
+	}
+	if filter.MergedFrom, err = queryTime(r, "merged_from"); err != nil {
+		return filter, err
+	}
+	if filter.MergedTo, err = queryTime(r, "merged_to"); err != nil {
+		return filter, err
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, errors.New("limit must be an integer")
+		}
+	}
+	if value := query.Get("cursor"); value != "" {
+		if filter.Cursor, err = domain.ParsePRCursor(value); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
+
+// ExplainAssignment godoc
+// @Summary Explain reviewer assignment (Admin only)
+// @Description Show the recorded seed, strategy and candidates of the initial reviewer pick and of later replacements picked by a strategy, and replay them
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pull_request_id query string true "Pull request ID"
+// @Success 200 {object} response.AssignmentExplanationResponse "Assignment explained successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "PR not found or assignment was not recorded"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/pullRequest/explain [get]
+func (h *PRHandler) ExplainAssignment(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "pull_request_id query parameter is required")
+		return
+	}
+
+	explanation, err := h.service.ExplainAssignment(r.Context(), prID)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrPRNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrAssignmentTraceNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrAssignmentTraceNotFound.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapAssignmentExplanationToDTO(explanation))
+}
+
+// respondStatusChangeError writes the response for errors of PR lifecycle operations.
+// It reports whether the error was handled
+func respondStatusChangeError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, my_errors.ErrPRNotFound):
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrPRNotFound.Error())
+	case errors.Is(err, my_errors.ErrAuthorNotFound):
+		respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrAuthorNotFound.Error())
+	case errors.Is(err, my_errors.ErrPRAlreadyMerged):
+		respondError(w, http.StatusConflict, dto.ErrCodePRMerged, my_errors.ErrPRAlreadyMerged.Error())
+	case errors.Is(err, my_errors.ErrInvalidStatusTransition):
+		respondError(w, http.StatusConflict, dto.ErrCodeTransition, err.Error())
+	case errors.Is(err, my_errors.ErrReviewersAtCapacity):
+		respondError(w, http.StatusConflict, dto.ErrCodeAtCapacity, err.Error())
+	case errors.Is(err, my_errors.ErrNotEnoughReviewers):
+		respondError(w, http.StatusConflict, dto.ErrCodeNotEnough, err.Error())
+	case errors.Is(err, my_errors.ErrSeniorReviewerRequired):
+		respondError(w, http.StatusConflict, dto.ErrCodeNeedSenior, err.Error())
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type SLAService interface {
+	ListBreaches(ctx context.Context, filter domain.SLABreachFilter) (*domain.SLABreachPage, error)
+}
+
+type SLAHandler struct {
+	service SLAService
+}
+
+func NewSLAHandler(service SLAService) *SLAHandler {
+	return &SLAHandler{service: service}
+}
+
+// ListBreaches godoc
+// @Summary Get review SLA breaches (Admin only)
+// @Description List breaches of the team review SLAs newest first. A breach is open while the review is pending and
+// @Description resolved once the review is submitted, the reviewer is removed or the PR is no longer open.
+// @Description Pass next_cursor of the response as cursor to get the next page
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string false "Reviewer's team"
+// @Param reviewer_id query string false "Reviewer ID"
+// @Param status query string false "open or resolved"
+// @Param limit query int false "Page size, 1-200, 50 by default"
+// @Param cursor query int false "next_cursor of the previous page"
+// @Success 200 {object} response.SLABreachesResponse "Breaches retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/sla/breaches [get]
+func (h *SLAHandler) ListBreaches(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseSLABreachFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	page, err := h.service.ListBreaches(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapSLABreachPageToResponse(page))
+}
+
+func parseSLABreachFilter(r *http.Request) (domain.SLABreachFilter, error) {
+	query := r.URL.Query()
+	filter := domain.SLABreachFilter{
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		Status:     query.Get("status"),
+	}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, errors.New("limit must be an integer")
+		}
+	}
+	if value := query.Get("cursor"); value != "" {
+		if filter.Cursor, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return filter, errors.New("cursor must be an integer")
+		}
+	}
+
+	return filter, nil
+}
@@ -2,16 +2,21 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"pr-reviewer-service/internal/dto"
 	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+	"pr-reviewer-service/internal/service"
 
 	"pr-reviewer-service/internal/domain"
 )
 
 type StatisticsService interface {
 	GetStatistics(ctx context.Context) (*domain.Statistics, error)
+	GetPairingDiversity(ctx context.Context, teamName string, weeks int) (*domain.PairingDiversity, error)
 }
 
 type StatisticsHandler struct {
@@ -45,3 +50,55 @@ func (h *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request
 	resp := mapper.MapDomainStatisticsToDTO(stats)
 	respondJSON(w, http.StatusOK, resp)
 }
+
+// GetPairingDiversity godoc
+// @Summary Get pairing diversity of a team (Admin only)
+// @Description Weekly ratio of distinct author/reviewer pairs to review assignments of PRs created by the team's members.
+// @Description 1 means that no author got the same reviewer twice in a week
+// @Tags Statistics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string true "Team name"
+// @Param weeks query int false "Number of weeks to look back (default 12, max 104)"
+// @Success 200 {object} response.PairingDiversityResponse "Pairing diversity retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /statistics/pairingDiversity [get]
+func (h *StatisticsHandler) GetPairingDiversity(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	weeks := service.DefaultDiversityWeeks
+	if value := r.URL.Query().Get("weeks"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "weeks must be an integer")
+			return
+		}
+		weeks = parsed
+	}
+
+	diversity, err := h.service.GetPairingDiversity(r.Context(), teamName, weeks)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrTeamNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapPairingDiversityToDTO(diversity))
+}
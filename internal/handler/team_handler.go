@@ -21,6 +21,12 @@ type TeamService interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
 	GetAllTeams(ctx context.Context) ([]domain.Team, error)
+	SetReviewerStrategy(ctx context.Context, teamName, strategy string) (*domain.Team, error)
+	GetSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	UpdateSettings(ctx context.Context, settings *domain.TeamSettings) (*domain.TeamSettings, error)
+	GetFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+	SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) ([]string, error)
+	SetTeamLead(ctx context.Context, teamName, userID string) (*domain.Team, error)
 }
 
 type TeamHandler struct {
@@ -150,3 +156,287 @@ func (h *TeamHandler) ListAllTeams(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, resp)
 }
+
+// SetReviewerStrategy godoc
+// @Summary Set team reviewer selection strategy (deprecated, use PUT /team/settings)
+// @Description Kept for compatibility: sets reviewer_strategy in the team settings, like PUT /team/settings
+// @Tags Teams
+// @Deprecated
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetReviewerStrategyRequest true "Set reviewer strategy request"
+// @Success 200 {object} response.TeamResponse "Strategy updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/setReviewerStrategy [post]
+func (h *TeamHandler) SetReviewerStrategy(w http.ResponseWriter, r *http.Request) {
+	var req request.SetReviewerStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	team, err := h.service.SetReviewerStrategy(r.Context(), req.TeamName, req.ReviewerStrategy)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound):
+			respondWithError(w, http.StatusNotFound, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNotFound,
+					Message: my_errors.ErrTeamNotFound.Error(),
+				},
+			})
+			return
+		case errors.Is(err, my_errors.ErrInvalidReviewerStrategy):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.TeamResponse{
+		Team: mapper.MapDomainTeamToDTO(team),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetSettings godoc
+// @Summary Get team settings (Admin only)
+// @Description Get reviewer assignment settings of the team: reviewer count, minimum reviewers, strategy and limits
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string true "Team name"
+// @Success 200 {object} response.TeamSettingsResponse "Settings retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Router /team/settings [get]
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), teamName)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, &dto.ErrorResponse{
+			Error: dto.ErrorDetail{
+				Code:    dto.ErrCodeNotFound,
+				Message: my_errors.ErrTeamNotFound.Error(),
+			},
+		})
+		return
+	}
+
+	resp := response.TeamSettingsResponse{
+		Settings: mapper.MapDomainTeamSettingsToDTO(settings),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// UpdateSettings godoc
+// @Summary Update team settings (Admin only)
+// @Description Replace reviewer assignment settings of the team
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.UpdateTeamSettingsRequest true "Team settings"
+// @Success 200 {object} response.TeamSettingsResponse "Settings updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/settings [put]
+func (h *TeamHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req request.UpdateTeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), mapper.MapUpdateTeamSettingsRequestToDomain(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound):
+			respondWithError(w, http.StatusNotFound, &dto.ErrorResponse{
+				Error: dto.ErrorDetail{
+					Code:    dto.ErrCodeNotFound,
+					Message: my_errors.ErrTeamNotFound.Error(),
+				},
+			})
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput), errors.Is(err, my_errors.ErrInvalidReviewerStrategy):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.TeamSettingsResponse{
+		Settings: mapper.MapDomainTeamSettingsToDTO(settings),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetFallbackTeams godoc
+// @Summary Get team fallback pools (Admin only)
+// @Description Get teams whose members top up reviewers when the team cannot provide enough of them, in priority order
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param team_name query string true "Team name"
+// @Success 200 {object} response.FallbackTeamsResponse "Fallback teams retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/fallbacks [get]
+func (h *TeamHandler) GetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "team_name query parameter is required")
+		return
+	}
+
+	fallbackTeams, err := h.service.GetFallbackTeams(r.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrTeamNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	resp := response.FallbackTeamsResponse{
+		TeamName:      teamName,
+		FallbackTeams: fallbackTeams,
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetFallbackTeams godoc
+// @Summary Set team fallback pools (Admin only)
+// @Description Replace the fallback pools of the team. The first team in the list is used first
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetFallbackTeamsRequest true "Fallback teams"
+// @Success 200 {object} response.FallbackTeamsResponse "Fallback teams updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/fallbacks [put]
+func (h *TeamHandler) SetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	var req request.SetFallbackTeamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	fallbackTeams, err := h.service.SetFallbackTeams(r.Context(), req.TeamName, req.FallbackTeams)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, err.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.FallbackTeamsResponse{
+		TeamName:      req.TeamName,
+		FallbackTeams: fallbackTeams,
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetLead godoc
+// @Summary Set team lead (Admin only)
+// @Description Make the member the team's lead, review SLA breaches of the team are escalated to the lead. An empty user_id removes the lead
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetTeamLeadRequest true "Team lead"
+// @Success 200 {object} response.TeamResponse "Team lead updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Team not found or user is not a member"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /team/setLead [post]
+func (h *TeamHandler) SetLead(w http.ResponseWriter, r *http.Request) {
+	var req request.SetTeamLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	team, err := h.service.SetTeamLead(r.Context(), req.TeamName, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrTeamNotFound), errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.TeamResponse{
+		Team: mapper.MapDomainTeamToDTO(team),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"pr-reviewer-service/internal/dto"
 	"pr-reviewer-service/internal/mapper"
@@ -19,9 +20,18 @@ import (
 
 type UserService interface {
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetUserCapacity(ctx context.Context, userID string, maxOpenReviews *int, reviewWeight float64) (*domain.User, error)
+	SetUserSkills(ctx context.Context, userID string, skills []string) (*domain.User, error)
+	SetUserSeniority(ctx context.Context, userID, seniority string) (*domain.User, error)
+	SetUserNotifications(ctx context.Context, userID, email, preference string) (*domain.User, error)
+	SetUserDigestSchedule(ctx context.Context, userID, timezone string, digestHour int) (*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
 	GetAllUsers(ctx context.Context) ([]domain.User, error)
 	BatchDeactivateUsers(ctx context.Context, userIDs []string) (*domain.BatchDeactivateResult, error)
 	BatchDeactivateTeam(ctx context.Context, teamName string) (*domain.BatchDeactivateResult, error)
+	AddAbsence(ctx context.Context, absence *domain.Absence) (*domain.Absence, error)
+	GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
 }
 
 type PRServiceForUser interface {
@@ -95,6 +105,281 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, resp)
 }
 
+// SetCapacity godoc
+// @Summary Set user review capacity (Admin only)
+// @Description Set the personal limit of open reviews (omit max_open_reviews to remove it) and the review weight used by reviewer selection
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetUserCapacityRequest true "Capacity request"
+// @Success 200 {object} response.UserResponse "User capacity updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/setCapacity [post]
+func (h *UserHandler) SetCapacity(w http.ResponseWriter, r *http.Request) {
+	var req request.SetUserCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.SetUserCapacity(r.Context(), req.UserID, req.MaxOpenReviews, req.ReviewWeight)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.UserResponse{
+		User: mapper.MapDomainUserToDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetSkills godoc
+// @Summary Set user skills (Admin only)
+// @Description Replace the skill tags of the user (go, postgres, frontend, security...). Tags are lowercased and deduplicated
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetUserSkillsRequest true "Skills request"
+// @Success 200 {object} response.UserResponse "User skills updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/setSkills [post]
+func (h *UserHandler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	var req request.SetUserSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.SetUserSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	resp := response.UserResponse{
+		User: mapper.MapDomainUserToDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetSeniority godoc
+// @Summary Set user seniority (Admin only)
+// @Description Set the seniority level (junior, middle, senior, lead) used by the team's senior reviewer policy
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetUserSeniorityRequest true "Seniority request"
+// @Success 200 {object} response.UserResponse "User seniority updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/setSeniority [post]
+func (h *UserHandler) SetSeniority(w http.ResponseWriter, r *http.Request) {
+	var req request.SetUserSeniorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.SetUserSeniority(r.Context(), req.UserID, req.Seniority)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.UserResponse{
+		User: mapper.MapDomainUserToDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetNotifications godoc
+// @Summary Set user email notifications (Admin only)
+// @Description Set the email address and how the user is notified about assignments: immediate sends every email,
+// @Description digest collects notifications for the daily digest and off sends nothing. An empty email removes the address
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetUserNotificationsRequest true "Notifications request"
+// @Success 200 {object} response.UserResponse "User notifications updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/setNotifications [post]
+func (h *UserHandler) SetNotifications(w http.ResponseWriter, r *http.Request) {
+	var req request.SetUserNotificationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.SetUserNotifications(r.Context(), req.UserID, req.Email, req.NotificationPreference)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.UserResponse{
+		User: mapper.MapDomainUserToDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetDigestSchedule godoc
+// @Summary Set user daily digest schedule (Admin only)
+// @Description Set the IANA timezone of the user and the hour of their local time the daily digest of pending reviews is sent at
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.SetUserDigestScheduleRequest true "Digest schedule request"
+// @Success 200 {object} response.UserResponse "User digest schedule updated successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/setDigestSchedule [post]
+func (h *UserHandler) SetDigestSchedule(w http.ResponseWriter, r *http.Request) {
+	var req request.SetUserDigestScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	user, err := h.userService.SetUserDigestSchedule(r.Context(), req.UserID, req.Timezone, *req.DigestHour)
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput) || errors.Is(err, my_errors.ErrEmptyField):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.UserResponse{
+		User: mapper.MapDomainUserToDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetSkills godoc
+// @Summary Get user skills
+// @Description Get the skill tags used to match the user with pull requests
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string true "User ID"
+// @Success 200 {object} response.UserSkillsResponse "User skills retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/skills [get]
+func (h *UserHandler) GetSkills(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "user_id query parameter is required")
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapDomainUserToSkillsResponse(user))
+}
+
 // GetReview godoc
 // @Summary Get PRs assigned to user
 // @Description Get list of pull requests where user is assigned as reviewer
@@ -231,3 +516,111 @@ func (h *UserHandler) BatchDeactivateUsers(w http.ResponseWriter, r *http.Reques
 	resp := mapper.MapBatchDeactivateResultToDTO(result)
 	respondJSON(w, http.StatusOK, resp)
 }
+
+// AddAbsence godoc
+// @Summary Add own absence
+// @Description Register a period when the current user is away. Away users are not picked as reviewers. With hand_over_reviews their open reviews are reassigned when the absence starts
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.AddAbsenceRequest true "Absence period"
+// @Success 201 {object} response.AbsenceResponse "Absence added successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "User not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/availability [post]
+func (h *UserHandler) AddAbsence(w http.ResponseWriter, r *http.Request) {
+	var req request.AddAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	absence, err := h.userService.AddAbsence(r.Context(), mapper.MapAddAbsenceRequestToDomain(currentUserID(r), &req))
+	if err != nil {
+		switch {
+		case errors.Is(err, my_errors.ErrUserNotFound):
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrUserNotFound.Error())
+			return
+		case errors.Is(err, my_errors.ErrInvalidInput), errors.Is(err, my_errors.ErrEmptyField):
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		default:
+			respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+	}
+
+	resp := response.AbsenceResponse{
+		Absence: mapper.MapDomainAbsenceToDTO(absence),
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// GetAbsences godoc
+// @Summary Get own absences
+// @Description Get current and upcoming absences of the current user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.AbsencesResponse "Absences retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /users/availability [get]
+func (h *UserHandler) GetAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+
+	absences, err := h.userService.GetAbsences(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	resp := response.AbsencesResponse{
+		UserID:   userID,
+		Absences: mapper.MapDomainAbsencesToDTO(absences),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DeleteAbsence godoc
+// @Summary Delete own absence
+// @Description Cancel an absence of the current user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id query int true "Absence ID"
+// @Success 204 "Absence deleted successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Absence not found"
+// @Router /users/availability [delete]
+func (h *UserHandler) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	absenceID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "id query parameter must be an integer")
+		return
+	}
+
+	if err := h.userService.DeleteAbsence(r.Context(), currentUserID(r), absenceID); err != nil {
+		if errors.Is(err, my_errors.ErrAbsenceNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrAbsenceNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-service/internal/dto"
+	"pr-reviewer-service/internal/mapper"
+	"pr-reviewer-service/internal/my_errors"
+	"pr-reviewer-service/internal/request"
+	"pr-reviewer-service/internal/response"
+
+	"github.com/go-playground/validator/v10"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DisableSubscription(ctx context.Context, subscriptionID int64) error
+	ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) (*domain.DeliveryPage, error)
+	Redeliver(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	service   WebhookService
+	validator *validator.Validate
+}
+
+func NewWebhookHandler(service WebhookService, validator *validator.Validate) *WebhookHandler {
+	return &WebhookHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// CreateSubscription godoc
+// @Summary Register a webhook (Admin only)
+// @Description Subscribe a URL to events: pr.created, pr.ready, reviewer.assigned, reviewer.reassigned, pr.merged, user.deactivated, sla.breached, sla.escalated.
+// @Description Every request carries the X-Webhook-Signature-256 header, "sha256=" followed by the hex HMAC-SHA256 of the body with the secret.
+// @Description The secret is returned only in this response
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.CreateWebhookRequest true "Webhook to register"
+// @Success 201 {object} response.WebhookCreatedResponse "Webhook registered successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req request.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(r.Context(), mapper.MapCreateWebhookRequestToDomain(&req))
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidInput) || errors.Is(err, my_errors.ErrEmptyField) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, response.WebhookCreatedResponse{
+		Webhook: mapper.MapWebhookSubscriptionToDTO(subscription),
+		Secret:  subscription.Secret,
+	})
+}
+
+// ListSubscriptions godoc
+// @Summary List webhooks (Admin only)
+// @Description Get all registered webhooks including disabled ones, secrets are not returned
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.WebhooksResponse "Webhooks retrieved successfully"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.service.ListSubscriptions(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapWebhookSubscriptionsToResponse(subscriptions))
+}
+
+// DisableSubscription godoc
+// @Summary Disable a webhook (Admin only)
+// @Description Stop sending events to the webhook. Its delivery log is kept, pending deliveries are not sent
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id query int true "Webhook ID"
+// @Success 204 "Webhook disabled successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Webhook not found"
+// @Router /admin/webhooks [delete]
+func (h *WebhookHandler) DisableSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "id query parameter must be an integer")
+		return
+	}
+
+	if err := h.service.DisableSubscription(r.Context(), subscriptionID); err != nil {
+		if errors.Is(err, my_errors.ErrWebhookNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrWebhookNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary Get the webhook delivery log (Admin only)
+// @Description List deliveries newest first with their status, attempts and the last response of the receiver.
+// @Description Pass next_cursor of the response as cursor to get the next page
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription_id query int false "Webhook ID"
+// @Param status query string false "pending, delivered or failed"
+// @Param event_type query string false "Event type, e.g. pr.created"
+// @Param limit query int false "Page size, 1-200, 50 by default"
+// @Param cursor query int false "next_cursor of the previous page"
+// @Success 200 {object} response.WebhookDeliveriesResponse "Deliveries retrieved successfully"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDeliveryFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	page, err := h.service.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrInvalidInput) {
+			respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, mapper.MapDeliveryPageToResponse(page))
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook event (Admin only)
+// @Description Queue a new delivery of the same event to the same webhook, the payload and the event ID are unchanged
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.RedeliverRequest true "Delivery to repeat"
+// @Success 202 {object} response.WebhookDeliveryResponse "Redelivery queued"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} dto.ErrorResponse "Delivery not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /admin/webhooks/deliveries/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	var req request.RedeliverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		respondError(w, http.StatusBadRequest, dto.ErrCodeNotFound, "validation error: "+err.Error())
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), req.DeliveryID)
+	if err != nil {
+		if errors.Is(err, my_errors.ErrDeliveryNotFound) {
+			respondError(w, http.StatusNotFound, dto.ErrCodeNotFound, my_errors.ErrDeliveryNotFound.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, dto.ErrCodeNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, response.WebhookDeliveryResponse{
+		Delivery: mapper.MapWebhookDeliveryToDTO(delivery),
+	})
+}
+
+func parseDeliveryFilter(r *http.Request) (domain.DeliveryFilter, error) {
+	query := r.URL.Query()
+	filter := domain.DeliveryFilter{
+		Status:    query.Get("status"),
+		EventType: query.Get("event_type"),
+	}
+
+	var err error
+	if value := query.Get("subscription_id"); value != "" {
+		if filter.SubscriptionID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return filter, errors.New("subscription_id must be an integer")
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, errors.New("limit must be an integer")
+		}
+	}
+	if value := query.Get("cursor"); value != "" {
+		if filter.Cursor, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return filter, errors.New("cursor must be an integer")
+		}
+	}
+
+	return filter, nil
+}
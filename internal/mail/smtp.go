@@ -0,0 +1,127 @@
+// Package mail sends HTML emails through SMTP and renders their templates
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message is an HTML email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+type SMTPMailer struct {
+	host string
+	addr string
+	from string
+	// auth is nil when the server does not require authentication
+	auth smtp.Auth
+}
+
+// NewSMTPMailer returns a mailer sending through host:port as from.
+// STARTTLS is used when the server offers it, PLAIN authentication only when username is set
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	mailer := &SMTPMailer{
+		host: host,
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send delivers the message, the context limits the whole SMTP session
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if m.auth != nil {
+		if err := client.Auth(m.auth); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(m.from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+
+	data, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	body, err := m.compose(message)
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := data.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected the message: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose builds the RFC 5322 message, the DATA writer converts line endings and escapes leading dots
+func (m *SMTPMailer) compose(message Message) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	domain := m.host
+	if at := strings.LastIndex(m.from, "@"); at >= 0 {
+		domain = m.from[at+1:]
+	}
+
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", m.from},
+		{"To", message.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + hex.EncodeToString(id) + "@" + domain + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/html; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+	for _, header := range headers {
+		buf.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	buf.WriteString("\r\n")
+	buf.WriteString(message.HTML)
+	return buf.Bytes(), nil
+}
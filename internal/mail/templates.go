@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+)
+
+// Email templates
+const (
+	TemplateReviewerAssigned = "reviewer_assigned.html"
+	TemplateReviewersReady   = "reviewers_ready.html"
+	TemplateDigest           = "digest.html"
+	TemplateSLABreached      = "sla_breached.html"
+	TemplateSLAEscalated     = "sla_escalated.html"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.html"))
+
+// ReviewerAssigned is the data of the email to a reviewer, PreviousReviewer is set when the review is taken over
+type ReviewerAssigned struct {
+	Recipient        string
+	PullRequestID    string
+	PullRequestName  string
+	Author           string
+	PreviousReviewer string
+}
+
+// ReviewersReady is the data of the email to the author once the PR has its reviewers
+type ReviewersReady struct {
+	Recipient       string
+	PullRequestID   string
+	PullRequestName string
+	Reviewers       []string
+}
+
+// Digest is the data of the daily digest, Reviews are the open PRs waiting for the recipient's review
+type Digest struct {
+	Recipient     string
+	Date          string
+	Reviews       []DigestReview
+	Notifications []string
+}
+
+type DigestReview struct {
+	PullRequestID   string
+	PullRequestName string
+	Author          string
+	// Waiting is how long the review has been waiting, for example "2d 5h"
+	Waiting string
+}
+
+// SLABreached is the data of the email to a reviewer whose review exceeded the team review SLA.
+// DueAt is formatted in the recipient's timezone
+type SLABreached struct {
+	Recipient       string
+	PullRequestID   string
+	PullRequestName string
+	Author          string
+	DueAt           string
+	SLAHours        int
+}
+
+// SLAEscalated is the data of the email to the team lead about a breach still open after the escalation delay
+type SLAEscalated struct {
+	Recipient       string
+	PullRequestID   string
+	PullRequestName string
+	Reviewer        string
+	DueAt           string
+	SLAHours        int
+}
+
+// Render executes the named template, values are HTML-escaped
+func Render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
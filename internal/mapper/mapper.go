@@ -12,14 +12,18 @@ func MapDomainTeamToDTO(team *domain.Team) dto.TeamDTO {
 	members := make([]dto.TeamMemberDTO, len(team.Members))
 	for i, m := range team.Members {
 		members[i] = dto.TeamMemberDTO{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:     m.UserID,
+			Username:   m.Username,
+			Skills:     nonNilStrings(m.Skills),
+			Seniority:  m.Seniority,
+			IsActive:   m.IsActive,
+			IsTeamLead: m.IsTeamLead,
 		}
 	}
 	return dto.TeamDTO{
-		TeamName: team.TeamName,
-		Members:  members,
+		TeamName:         team.TeamName,
+		ReviewerStrategy: team.ReviewerStrategy,
+		Members:          members,
 	}
 }
 
@@ -27,24 +31,91 @@ func MapCreateTeamRequestToDomain(req *request.CreateTeamRequest) *domain.Team {
 	members := make([]domain.TeamMember, len(req.Members))
 	for i, m := range req.Members {
 		members[i] = domain.TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:    m.UserID,
+			Username:  m.Username,
+			Skills:    m.Skills,
+			Seniority: m.Seniority,
+			IsActive:  m.IsActive,
 		}
 	}
 	return &domain.Team{
-		TeamName: req.TeamName,
-		Members:  members,
+		TeamName:         req.TeamName,
+		ReviewerStrategy: req.ReviewerStrategy,
+		Members:          members,
+	}
+}
+
+func MapDomainTeamSettingsToDTO(settings *domain.TeamSettings) dto.TeamSettingsDTO {
+	return dto.TeamSettingsDTO{
+		UpdatedAt:                    settings.UpdatedAt,
+		MaxOpenReviews:               settings.MaxOpenReviews,
+		TeamName:                     settings.TeamName,
+		ReviewerStrategy:             settings.ReviewerStrategy,
+		CodeOwnersMode:               settings.CodeOwnersMode,
+		SeniorLevel:                  settings.SeniorLevel,
+		ReviewerCount:                settings.ReviewerCount,
+		MinReviewers:                 settings.MinReviewers,
+		MinSeniorReviewers:           settings.MinSeniorReviewers,
+		PairingLookback:              settings.PairingLookback,
+		MergeMinApprovals:            settings.MergePolicy.MinApprovals,
+		MergeBlockOnChangesRequested: settings.MergePolicy.BlockOnChangesRequested,
+		MergeRequireTeamApproval:     settings.MergePolicy.RequireTeamApproval,
+		ReviewSLAHours:               settings.ReviewSLAHours,
+		SLAEscalationHours:           settings.SLAEscalationHours,
+	}
+}
+
+func MapUpdateTeamSettingsRequestToDomain(req *request.UpdateTeamSettingsRequest) *domain.TeamSettings {
+	pairingLookback := domain.DefaultPairingLookback
+	if req.PairingLookback != nil {
+		pairingLookback = *req.PairingLookback
+	}
+	slaEscalationHours := domain.DefaultSLAEscalationHours
+	if req.SLAEscalationHours != nil {
+		slaEscalationHours = *req.SLAEscalationHours
+	}
+	return &domain.TeamSettings{
+		MaxOpenReviews:     req.MaxOpenReviews,
+		TeamName:           req.TeamName,
+		ReviewerStrategy:   req.ReviewerStrategy,
+		CodeOwnersMode:     req.CodeOwnersMode,
+		SeniorLevel:        req.SeniorLevel,
+		ReviewerCount:      req.ReviewerCount,
+		MinReviewers:       req.MinReviewers,
+		MinSeniorReviewers: req.MinSeniorReviewers,
+		PairingLookback:    pairingLookback,
+		MergePolicy: domain.MergePolicy{
+			MinApprovals:            req.MergeMinApprovals,
+			BlockOnChangesRequested: req.MergeBlockOnChangesRequested,
+			RequireTeamApproval:     req.MergeRequireTeamApproval,
+		},
+		ReviewSLAHours:     req.ReviewSLAHours,
+		SLAEscalationHours: slaEscalationHours,
 	}
 }
 
 // User mappers
 func MapDomainUserToDTO(user *domain.User) dto.UserDTO {
 	return dto.UserDTO{
-		UserID:   user.UserID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		MaxOpenReviews:         user.MaxOpenReviews,
+		UserID:                 user.UserID,
+		Username:               user.Username,
+		TeamName:               user.TeamName,
+		IsActive:               user.IsActive,
+		ReviewWeight:           user.ReviewWeight,
+		Skills:                 nonNilStrings(user.Skills),
+		Seniority:              user.Seniority,
+		Email:                  user.Email,
+		NotificationPreference: user.NotificationPreference,
+		Timezone:               user.Timezone,
+		DigestHour:             user.DigestHour,
+	}
+}
+
+func MapDomainUserToSkillsResponse(user *domain.User) response.UserSkillsResponse {
+	return response.UserSkillsResponse{
+		UserID: user.UserID,
+		Skills: nonNilStrings(user.Skills),
 	}
 }
 
@@ -56,17 +127,173 @@ func MapDomainUsersToDTO(users []domain.User) []dto.UserDTO {
 	return result
 }
 
+// Availability mappers
+func MapDomainAbsenceToDTO(absence *domain.Absence) dto.AbsenceDTO {
+	return dto.AbsenceDTO{
+		StartsAt:        absence.StartsAt,
+		EndsAt:          absence.EndsAt,
+		HandedOverAt:    absence.HandedOverAt,
+		UserID:          absence.UserID,
+		Reason:          absence.Reason,
+		ID:              absence.ID,
+		HandOverReviews: absence.HandOverReviews,
+	}
+}
+
+func MapDomainAbsencesToDTO(absences []domain.Absence) []dto.AbsenceDTO {
+	result := make([]dto.AbsenceDTO, len(absences))
+	for i, absence := range absences {
+		result[i] = MapDomainAbsenceToDTO(&absence)
+	}
+	return result
+}
+
+func MapAddAbsenceRequestToDomain(userID string, req *request.AddAbsenceRequest) *domain.Absence {
+	return &domain.Absence{
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		UserID:          userID,
+		Reason:          req.Reason,
+		HandOverReviews: req.HandOverReviews,
+	}
+}
+
 // PR mappers
 func MapDomainPRToDTO(pr *domain.PullRequest) dto.PullRequestDTO {
 	return dto.PullRequestDTO{
-		PullRequestID:     pr.PullRequestID,
-		PullRequestName:   pr.PullRequestName,
-		AuthorID:          pr.AuthorID,
-		Status:            pr.Status,
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:         pr.CreatedAt,
-		MergedAt:          pr.MergedAt,
+		PullRequestID:      pr.PullRequestID,
+		PullRequestName:    pr.PullRequestName,
+		AuthorID:           pr.AuthorID,
+		Status:             pr.Status,
+		AssignmentStrategy: pr.AssignmentStrategy,
+		AssignedReviewers:  pr.AssignedReviewers,
+		FallbackReviewers:  pr.FallbackReviewers,
+		ChangedFiles:       pr.ChangedFiles,
+		RequiredSkills:     nonNilStrings(pr.RequiredSkills),
+		CreatedAt:          pr.CreatedAt,
+		MergedAt:           pr.MergedAt,
+		ClosedAt:           pr.ClosedAt,
+		AssignmentSeed:     pr.AssignmentSeed,
+		Reviews:            mapReviews(pr.Reviews),
+		Assignment:         MapAssignmentReportToDTO(pr.Assignment),
+	}
+}
+
+func MapPRPageToResponse(page *domain.PRPage) response.PRListResponse {
+	prs := make([]dto.PullRequestDTO, len(page.PullRequests))
+	for i := range page.PullRequests {
+		prs[i] = MapDomainPRToDTO(&page.PullRequests[i])
+	}
+	return response.PRListResponse{
+		PullRequests: prs,
+		NextCursor:   page.NextCursor,
+	}
+}
+
+func MapPRTimelineToResponse(prID string, events []domain.PREvent) response.PRTimelineResponse {
+	result := make([]dto.PREventDTO, len(events))
+	for i, event := range events {
+		result[i] = dto.PREventDTO{
+			CreatedAt:          event.CreatedAt,
+			ID:                 event.ID,
+			Type:               event.Type,
+			ActorID:            event.ActorID,
+			ReviewerID:         event.ReviewerID,
+			PreviousReviewerID: event.PreviousReviewerID,
+			FromStatus:         event.FromStatus,
+			ToStatus:           event.ToStatus,
+			ReviewState:        event.ReviewState,
+			Reason:             event.Reason,
+		}
+	}
+	return response.PRTimelineResponse{
+		PullRequestID: prID,
+		Events:        result,
+	}
+}
+
+func mapReviews(reviews []domain.Review) []dto.ReviewDTO {
+	result := make([]dto.ReviewDTO, len(reviews))
+	for i, review := range reviews {
+		result[i] = dto.ReviewDTO{
+			ReviewedAt: review.ReviewedAt,
+			UserID:     review.UserID,
+			State:      review.State,
+		}
 	}
+	return result
+}
+
+func MapAssignmentReportToDTO(report *domain.AssignmentReport) *dto.AssignmentReportDTO {
+	if report == nil {
+		return nil
+	}
+	return &dto.AssignmentReportDTO{
+		AtCapacity:         report.AtCapacity,
+		MissingSkills:      report.MissingSkills,
+		RequestedReviewers: report.RequestedReviewers,
+		AssignedReviewers:  report.AssignedReviewers,
+		Seed:               report.Seed,
+		SeniorShortfall:    report.SeniorShortfall,
+		CapacityExhausted:  report.CapacityExhausted,
+	}
+}
+
+func MapAssignmentExplanationToDTO(explanation *domain.AssignmentExplanation) response.AssignmentExplanationResponse {
+	reassignments := make([]dto.ReassignmentExplanationDTO, len(explanation.Reassignments))
+	for i, r := range explanation.Reassignments {
+		trace := r.Event.AssignmentTrace
+		reassignments[i] = dto.ReassignmentExplanationDTO{
+			CreatedAt:          r.Event.CreatedAt,
+			PreviousReviewerID: r.Event.PreviousReviewerID,
+			ReviewerID:         r.Event.ReviewerID,
+			Reason:             r.Event.Reason,
+			Strategy:           trace.Strategy,
+			Candidates:         mapReviewerCandidates(trace.Candidates),
+			RecordedReviewers:  trace.Reviewers,
+			ReplayedReviewers:  r.Replayed,
+			RequiredSkills:     nonNilStrings(trace.RequiredSkills),
+			SeniorLevel:        trace.SeniorLevel,
+			SeniorsNeeded:      trace.SeniorsNeeded,
+			Seed:               trace.Seed,
+			RequestedCount:     trace.Count,
+			EventID:            r.Event.ID,
+			Reproducible:       r.Reproducible,
+		}
+	}
+
+	return response.AssignmentExplanationResponse{
+		PullRequestID:     explanation.PullRequestID,
+		Strategy:          explanation.Trace.Strategy,
+		Candidates:        mapReviewerCandidates(explanation.Trace.Candidates),
+		RecordedReviewers: explanation.Trace.Reviewers,
+		ReplayedReviewers: explanation.Replayed,
+		RequiredSkills:    nonNilStrings(explanation.Trace.RequiredSkills),
+		SeniorLevel:       explanation.Trace.SeniorLevel,
+		SeniorsNeeded:     explanation.Trace.SeniorsNeeded,
+		Seed:              explanation.Trace.Seed,
+		RequestedCount:    explanation.Trace.Count,
+		Reproducible:      explanation.Reproducible,
+		Reassignments:     reassignments,
+	}
+}
+
+func mapReviewerCandidates(traceCandidates []domain.ReviewerCandidate) []dto.ReviewerCandidateDTO {
+	candidates := make([]dto.ReviewerCandidateDTO, len(traceCandidates))
+	for i, c := range traceCandidates {
+		candidates[i] = dto.ReviewerCandidateDTO{
+			LastAssignedAt: c.LastAssignedAt,
+			MaxOpenReviews: c.MaxOpenReviews,
+			UserID:         c.UserID,
+			OpenReviews:    c.OpenReviews,
+			Tier:           c.Tier,
+			Weight:         c.Weight,
+			Skills:         nonNilStrings(c.Skills),
+			Seniority:      c.Seniority,
+			RecentPairings: c.RecentPairings,
+		}
+	}
+	return candidates
 }
 
 func MapDomainPRShortToDTO(pr *domain.PullRequestShort) dto.PullRequestShortDTO {
@@ -87,12 +314,19 @@ func MapDomainPRsShortToDTO(prs []domain.PullRequestShort) []dto.PullRequestShor
 }
 
 func MapCreatePRRequestToDomain(req *request.CreatePRRequest) *domain.PullRequest {
+	status := domain.StatusOpen
+	if req.Draft {
+		status = domain.StatusDraft
+	}
 	return &domain.PullRequest{
 		PullRequestID:     req.PullRequestID,
 		PullRequestName:   req.PullRequestName,
 		AuthorID:          req.AuthorID,
-		Status:            domain.StatusOpen,
+		Status:            status,
 		AssignedReviewers: []string{},
+		FallbackReviewers: []string{},
+		ChangedFiles:      req.ChangedFiles,
+		RequiredSkills:    req.RequiredSkills,
 	}
 }
 
@@ -114,6 +348,8 @@ func MapDomainStatisticsToDTO(stats *domain.Statistics) response.StatisticsRespo
 		TotalPRs:        stats.TotalPRs,
 		OpenPRs:         stats.OpenPRs,
 		MergedPRs:       stats.MergedPRs,
+		ClosedPRs:       stats.ClosedPRs,
+		DraftPRs:        stats.DraftPRs,
 		TotalUsers:      stats.TotalUsers,
 		ActiveUsers:     stats.ActiveUsers,
 		TotalTeams:      stats.TotalTeams,
@@ -121,23 +357,336 @@ func MapDomainStatisticsToDTO(stats *domain.Statistics) response.StatisticsRespo
 	}
 }
 
+func MapPairingDiversityToDTO(diversity *domain.PairingDiversity) response.PairingDiversityResponse {
+	points := make([]dto.PairingDiversityPointDTO, len(diversity.Points))
+	for i, p := range diversity.Points {
+		points[i] = mapPairingDiversityPoint(p)
+	}
+	return response.PairingDiversityResponse{
+		Since:    diversity.Since,
+		TeamName: diversity.TeamName,
+		Overall:  mapPairingDiversityPoint(diversity.Overall),
+		Points:   points,
+	}
+}
+
+func mapPairingDiversityPoint(point domain.PairingDiversityPoint) dto.PairingDiversityPointDTO {
+	return dto.PairingDiversityPointDTO{
+		PeriodStart:   point.PeriodStart,
+		Assignments:   point.Assignments,
+		DistinctPairs: point.DistinctPairs,
+		Diversity:     point.Diversity,
+	}
+}
+
+// Code owners mapper
+func MapCodeOwnerRulesToDTO(rules []domain.CodeOwnerRule) response.CodeOwnersResponse {
+	result := make([]dto.CodeOwnerRuleDTO, len(rules))
+	for i, rule := range rules {
+		result[i] = dto.CodeOwnerRuleDTO{
+			Pattern: rule.Pattern,
+			Users:   rule.Users,
+			Teams:   rule.Teams,
+			Line:    rule.Line,
+		}
+	}
+
+	return response.CodeOwnersResponse{
+		Rules: result,
+		Count: len(result),
+	}
+}
+
 // Batch mapper
 func MapBatchDeactivateResultToDTO(result *domain.BatchDeactivateResult) response.BatchDeactivateResponse {
 	reassignedPRs := make([]response.PRReassignmentInfo, len(result.ReassignedPRs))
 	for i, pr := range result.ReassignedPRs {
 		reassignedPRs[i] = response.PRReassignmentInfo{
-			PullRequestID: pr.PullRequestID,
-			OldReviewers:  pr.OldReviewers,
-			NewReviewers:  pr.NewReviewers,
+			PullRequestID:     pr.PullRequestID,
+			OldReviewers:      pr.OldReviewers,
+			NewReviewers:      pr.NewReviewers,
+			FallbackReviewers: pr.FallbackReviewers,
 		}
 	}
 
 	return response.BatchDeactivateResponse{
-		DeactivatedUsers:   result.DeactivatedUsers,
-		ReassignedPRs:      reassignedPRs,
-		SkippedUsers:       result.SkippedUsers,
-		TotalDeactivated:   len(result.DeactivatedUsers),
-		TotalPRsReassigned: len(result.ReassignedPRs),
-		ProcessingTimeMs:   result.ProcessingTime.Milliseconds(),
+		DeactivatedUsers:     result.DeactivatedUsers,
+		ReassignedPRs:        reassignedPRs,
+		SkippedUsers:         result.SkippedUsers,
+		UnderstaffedPRs:      result.UnderstaffedPRs,
+		CapacityExhaustedPRs: result.CapacityExhaustedPRs,
+		SeniorMissingPRs:     result.SeniorMissingPRs,
+		TotalDeactivated:     len(result.DeactivatedUsers),
+		TotalPRsReassigned:   len(result.ReassignedPRs),
+		ProcessingTimeMs:     result.ProcessingTime.Milliseconds(),
+		Seed:                 result.Seed,
+	}
+}
+
+// nonNilStrings makes empty lists encode as [] instead of null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func MapAuditEntryToDTO(entry *domain.AuditEntry) dto.AuditEntryDTO {
+	changes := make(map[string]dto.AuditChangeDTO, len(entry.Changes))
+	for field, change := range entry.Changes {
+		changes[field] = dto.AuditChangeDTO{Before: change.Before, After: change.After}
+	}
+	return dto.AuditEntryDTO{
+		CreatedAt:  entry.CreatedAt,
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		RequestID:  entry.RequestID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Changes:    changes,
+		Outcome:    entry.Outcome,
+		StatusCode: entry.StatusCode,
+		Error:      entry.Error,
+	}
+}
+
+func MapAuditPageToResponse(page *domain.AuditPage) response.AuditLogResponse {
+	entries := make([]dto.AuditEntryDTO, len(page.Entries))
+	for i := range page.Entries {
+		entries[i] = MapAuditEntryToDTO(&page.Entries[i])
+	}
+	return response.AuditLogResponse{
+		Entries:    entries,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// Webhook mappers
+func MapCreateWebhookRequestToDomain(req *request.CreateWebhookRequest) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+}
+
+func MapWebhookSubscriptionToDTO(subscription *domain.WebhookSubscription) dto.WebhookSubscriptionDTO {
+	return dto.WebhookSubscriptionDTO{
+		CreatedAt:  subscription.CreatedAt,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedBy:  subscription.CreatedBy,
+		ID:         subscription.ID,
+		IsActive:   subscription.IsActive,
+	}
+}
+
+func MapWebhookSubscriptionsToResponse(subscriptions []domain.WebhookSubscription) response.WebhooksResponse {
+	webhooks := make([]dto.WebhookSubscriptionDTO, len(subscriptions))
+	for i := range subscriptions {
+		webhooks[i] = MapWebhookSubscriptionToDTO(&subscriptions[i])
+	}
+	return response.WebhooksResponse{Webhooks: webhooks}
+}
+
+func MapWebhookDeliveryToDTO(delivery *domain.WebhookDelivery) dto.WebhookDeliveryDTO {
+	return dto.WebhookDeliveryDTO{
+		CreatedAt:      delivery.CreatedAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		LastStatusCode: delivery.LastStatusCode,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		LastError:      delivery.LastError,
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Attempts:       delivery.Attempts,
+	}
+}
+
+func MapDeliveryPageToResponse(page *domain.DeliveryPage) response.WebhookDeliveriesResponse {
+	deliveries := make([]dto.WebhookDeliveryDTO, len(page.Deliveries))
+	for i := range page.Deliveries {
+		deliveries[i] = MapWebhookDeliveryToDTO(&page.Deliveries[i])
+	}
+	return response.WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// Integration mappers
+func MapSetIntegrationAccountRequestToDomain(req *request.SetIntegrationAccountRequest) *domain.IntegrationAccount {
+	return &domain.IntegrationAccount{
+		Provider: req.Provider,
+		Login:    req.Login,
+		UserID:   req.UserID,
+	}
+}
+
+func MapIntegrationAccountToDTO(account *domain.IntegrationAccount) dto.IntegrationAccountDTO {
+	return dto.IntegrationAccountDTO{
+		CreatedAt: account.CreatedAt,
+		Provider:  account.Provider,
+		Login:     account.Login,
+		UserID:    account.UserID,
+	}
+}
+
+func MapIntegrationAccountsToResponse(accounts []domain.IntegrationAccount) response.IntegrationAccountsResponse {
+	result := make([]dto.IntegrationAccountDTO, len(accounts))
+	for i := range accounts {
+		result[i] = MapIntegrationAccountToDTO(&accounts[i])
+	}
+	return response.IntegrationAccountsResponse{Accounts: result}
+}
+
+func MapGitHubPullRequestPayloadToDomain(payload *request.GitHubPullRequestPayload) domain.GitHubPullRequestEvent {
+	number := payload.Number
+	if number == 0 {
+		number = payload.PullRequest.Number
+	}
+	return domain.GitHubPullRequestEvent{
+		Action:      payload.Action,
+		Repository:  payload.Repository.FullName,
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+		Number:      number,
+		Draft:       payload.PullRequest.Draft,
+		Merged:      payload.PullRequest.Merged,
+	}
+}
+
+func MapGitLabMergeRequestPayloadToDomain(payload *request.GitLabMergeRequestPayload) domain.GitLabMergeRequestEvent {
+	attributes := payload.ObjectAttributes
+	change := payload.Changes.Draft
+	if change == nil {
+		change = payload.Changes.WorkInProgress
+	}
+	return domain.GitLabMergeRequestEvent{
+		Action:       attributes.Action,
+		Project:      payload.Project.PathWithNamespace,
+		Title:        attributes.Title,
+		Username:     payload.User.Username,
+		ProjectID:    payload.Project.ID,
+		IID:          attributes.IID,
+		Draft:        attributes.Draft || attributes.WorkInProgress,
+		DraftChanged: change != nil && change.Previous != change.Current,
+	}
+}
+
+func MapIngestResultToResponse(event string, result *domain.IngestResult) response.IngestResponse {
+	resp := response.IngestResponse{
+		Outcome:       result.Outcome,
+		Event:         event,
+		Action:        result.Action,
+		PullRequestID: result.PullRequestID,
+	}
+	if result.PullRequest != nil {
+		pr := MapDomainPRToDTO(result.PullRequest)
+		resp.PullRequest = &pr
+	}
+	return resp
+}
+
+// Chat mappers
+func MapSetTeamChatRequestToDomain(req *request.SetTeamChatRequest) *domain.TeamChatSettings {
+	return &domain.TeamChatSettings{
+		TeamName:   req.TeamName,
+		WebhookURL: req.WebhookURL,
+		Templates: domain.ChatTemplates{
+			Assigned:   req.Templates.Assigned,
+			Reassigned: req.Templates.Reassigned,
+			Merged:     req.Templates.Merged,
+		},
+	}
+}
+
+func MapTeamChatSettingsToResponse(settings *domain.TeamChatSettings) response.TeamChatSettingsResponse {
+	return response.TeamChatSettingsResponse{
+		Chat: dto.TeamChatSettingsDTO{
+			UpdatedAt:  settings.UpdatedAt,
+			TeamName:   settings.TeamName,
+			WebhookURL: settings.WebhookURL,
+			Templates: dto.ChatTemplatesDTO{
+				Assigned:   settings.Templates.Assigned,
+				Reassigned: settings.Templates.Reassigned,
+				Merged:     settings.Templates.Merged,
+			},
+		},
+	}
+}
+
+// Digest mappers
+func MapDigestPreviewToResponse(preview *domain.DigestPreview) response.DigestPreviewResponse {
+	digest := preview.Digest
+	result := response.DigestPreviewResponse{
+		Digest: dto.DigestDTO{
+			GeneratedAt:   digest.GeneratedAt,
+			UserID:        digest.UserID,
+			Username:      digest.Username,
+			Email:         digest.Email,
+			Timezone:      digest.Timezone,
+			LocalDate:     digest.LocalDate,
+			Reviews:       make([]dto.DigestReviewDTO, len(digest.Reviews)),
+			Notifications: make([]dto.DigestItemDTO, len(digest.Notifications)),
+		},
+		Subject:  preview.Subject,
+		HTML:     preview.HTML,
+		WillSend: preview.WillSend,
+		Reason:   preview.Reason,
+	}
+	for i, review := range digest.Reviews {
+		result.Digest.Reviews[i] = dto.DigestReviewDTO{
+			AssignedAt:      review.AssignedAt,
+			PullRequestID:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			AuthorID:        review.AuthorID,
+			Author:          review.Author,
+			WaitingSeconds:  int64(review.Waiting.Seconds()),
+		}
+	}
+	for i, item := range digest.Notifications {
+		result.Digest.Notifications[i] = dto.DigestItemDTO{
+			CreatedAt:     item.CreatedAt,
+			EventType:     item.EventType,
+			PullRequestID: item.PullRequestID,
+			Summary:       item.Summary,
+		}
+	}
+	return result
+}
+
+// SLA mappers
+func MapSLABreachToDTO(breach *domain.SLABreach) dto.SLABreachDTO {
+	return dto.SLABreachDTO{
+		AssignedAt:    breach.AssignedAt,
+		DueAt:         breach.DueAt,
+		BreachedAt:    breach.BreachedAt,
+		EscalatedAt:   breach.EscalatedAt,
+		ResolvedAt:    breach.ResolvedAt,
+		EscalatedTo:   breach.EscalatedTo,
+		PullRequestID: breach.PullRequestID,
+		ReviewerID:    breach.ReviewerID,
+		TeamName:      breach.TeamName,
+		Status:        breach.Status(),
+		ID:            breach.ID,
+		SLAHours:      breach.SLAHours,
+	}
+}
+
+func MapSLABreachPageToResponse(page *domain.SLABreachPage) response.SLABreachesResponse {
+	breaches := make([]dto.SLABreachDTO, len(page.Breaches))
+	for i := range page.Breaches {
+		breaches[i] = MapSLABreachToDTO(&page.Breaches[i])
+	}
+	return response.SLABreachesResponse{
+		Breaches:   breaches,
+		NextCursor: page.NextCursor,
 	}
 }
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/dto"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+// auditResponseWriter keeps the status and the error body of the response
+type auditResponseWriter struct {
+	http.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.status >= http.StatusBadRequest {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// AuditMiddleware records every mutating call with its actor, request ID, target, changes and outcome.
+// It has to run after AuthMiddleware, read-only methods are not recorded
+func AuditMiddleware(recorder AuditRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, record := audit.NewContext(r.Context())
+			rw := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			actorID, _ := ctx.Value(UserIDKey).(string)
+			entry := &domain.AuditEntry{
+				ActorID:    actorID,
+				RequestID:  chimiddleware.GetReqID(ctx),
+				Action:     r.Method + " " + r.URL.Path,
+				TargetType: record.TargetType,
+				TargetID:   record.TargetID,
+				Outcome:    domain.AuditOutcomeSuccess,
+				StatusCode: rw.status,
+			}
+			if rw.status >= http.StatusBadRequest {
+				entry.Outcome = domain.AuditOutcomeFailure
+				var errResp dto.ErrorResponse
+				if err := json.Unmarshal(rw.body.Bytes(), &errResp); err == nil {
+					entry.Error = errResp.Error.Message
+				}
+			} else {
+				changes, err := domain.AuditDiff(record.Before, record.After)
+				if err != nil {
+					slog.Warn("failed to diff audited change", "action", entry.Action, "error", err)
+				}
+				entry.Changes = changes
+			}
+
+			// the request may have hit its timeout, the entry is stored anyway
+			if err := recorder.Record(context.WithoutCancel(ctx), entry); err != nil {
+				slog.Error("failed to record audit entry", "action", entry.Action, "actor_id", actorID, "error", err)
+			}
+		})
+	}
+}
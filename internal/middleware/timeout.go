@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// ExportTimeout limits the request to timeout, or to exportTimeout when it asks for
+// a streamed export (format=jsonl) that cannot fit into the regular deadline
+func ExportTimeout(timeout, exportTimeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		regular := chimiddleware.Timeout(timeout)(next)
+		export := chimiddleware.Timeout(exportTimeout)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") == "jsonl" {
+				export.ServeHTTP(w, r)
+				return
+			}
+			regular.ServeHTTP(w, r)
+		})
+	}
+}
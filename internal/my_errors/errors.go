@@ -9,6 +9,7 @@ var (
 	ErrCannotDeactivateAdmin     = errors.New("cannot deactivate admin users")
 	ErrCannotDeactivateAdminTeam = errors.New("cannot deactivate admin team")
 	ErrUserIsNotActive           = errors.New("user is not active")
+	ErrAbsenceNotFound           = errors.New("absence not found")
 
 	// Team my_errors
 	ErrTeamAlreadyExists = errors.New("team already exists")
@@ -19,10 +20,37 @@ var (
 	ErrPRAlreadyMerged = errors.New("pull request already merged")
 	ErrPRAlreadyExists = errors.New("pull request already exists")
 	ErrAuthorNotFound  = errors.New("author not found")
+	ErrMergeBlocked    = errors.New("merge policy of the team is not satisfied")
+	ErrPRNotOpen       = errors.New("pull request is not open")
+
+	ErrInvalidStatusTransition = errors.New("pull request cannot move to this status")
+
+	ErrAssignmentTraceNotFound = errors.New("assignment of this pull request was not recorded")
 
 	// Reviewer my_errors
-	ErrNoActiveReviewerWasFound = errors.New("no active replacement candidate in team")
+	ErrNoActiveReviewerWasFound = errors.New("no active replacement candidate in team or its fallback pools")
 	ErrReviewerIsNotAssigned    = errors.New("reviewer is not assigned to this PR")
+	ErrReviewerAlreadyAssigned  = errors.New("reviewer is already assigned to this PR")
+	ErrReviewerIsAuthor         = errors.New("author cannot review own PR")
+	ErrInvalidReviewerStrategy  = errors.New("unknown reviewer selection strategy")
+	ErrNotEnoughReviewers       = errors.New("not enough active reviewers to satisfy team minimum")
+	ErrReviewersAtCapacity      = errors.New("all candidate reviewers are at their open reviews limit")
+	ErrSeniorReviewerRequired   = errors.New("not enough senior reviewers available to satisfy team policy")
+
+	// Code owners my_errors
+	ErrInvalidCodeOwners = errors.New("invalid code owners")
+
+	// Webhook my_errors
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// Integration my_errors
+	ErrInvalidSignature  = errors.New("invalid webhook signature")
+	ErrAccountNotMapped  = errors.New("external account is not mapped to a user")
+	ErrIntegrationNotSet = errors.New("integration is not configured")
+
+	// Chat my_errors
+	ErrChatNotConfigured = errors.New("team chat is not configured")
 
 	// Auth my_errors
 	ErrInvalidToken  = errors.New("invalid token")
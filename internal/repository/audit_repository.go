@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pool: pool}
+}
+
+func (r *AuditRepository) CreateEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+	if entry.Changes == nil {
+		changes = []byte("{}")
+	}
+
+	query := `
+        INSERT INTO audit_log (
+            actor_id, request_id, action, target_type, target_id, changes, outcome, status_code, error
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at
+    `
+	err = r.pool.QueryRow(ctx, query,
+		entry.ActorID,
+		entry.RequestID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		changes,
+		entry.Outcome,
+		entry.StatusCode,
+		entry.Error,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListEntries returns entries matching the filter, newest first
+func (r *AuditRepository) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorID != "" {
+		addCondition("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		addCondition("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		addCondition("target_id = $%d", filter.TargetID)
+	}
+	if filter.Outcome != "" {
+		addCondition("outcome = $%d", filter.Outcome)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+	if filter.Cursor > 0 {
+		addCondition("id < $%d", filter.Cursor)
+	}
+
+	query := `
+        SELECT id, actor_id, request_id, action, target_type, target_id, changes, outcome, status_code, error, created_at
+        FROM audit_log
+    `
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.AuditEntry{}
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var changes []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.RequestID,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&changes,
+			&entry.Outcome,
+			&entry.StatusCode,
+			&entry.Error,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
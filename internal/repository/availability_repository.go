@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AvailabilityRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAvailabilityRepository(pool *pgxpool.Pool) *AvailabilityRepository {
+	return &AvailabilityRepository{pool: pool}
+}
+
+func (r *AvailabilityRepository) CreateAbsence(ctx context.Context, absence *domain.Absence) error {
+	query := `
+        INSERT INTO user_availability (user_id, starts_at, ends_at, reason, hand_over_reviews)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		absence.UserID,
+		absence.StartsAt,
+		absence.EndsAt,
+		absence.Reason,
+		absence.HandOverReviews,
+	).Scan(&absence.ID, &absence.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create absence: %w", err)
+	}
+	return nil
+}
+
+// GetUserAbsences returns current and upcoming absences of the user
+func (r *AvailabilityRepository) GetUserAbsences(ctx context.Context, userID string, at time.Time) ([]domain.Absence, error) {
+	query := `
+        SELECT id, user_id, starts_at, ends_at, reason, hand_over_reviews, handed_over_at, created_at
+        FROM user_availability
+        WHERE user_id = $1 AND ends_at > $2
+        ORDER BY starts_at
+    `
+	rows, err := r.pool.Query(ctx, query, userID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *AvailabilityRepository) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	query := `DELETE FROM user_availability WHERE id = $1 AND user_id = $2`
+	result, err := r.pool.Exec(ctx, query, absenceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete absence: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("absence not found")
+	}
+	return nil
+}
+
+// GetAwayUsers returns which of the users are absent at the given moment
+func (r *AvailabilityRepository) GetAwayUsers(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+        SELECT DISTINCT user_id
+        FROM user_availability
+        WHERE user_id = ANY($1) AND starts_at <= $2 AND ends_at > $2
+    `
+	rows, err := r.pool.Query(ctx, query, userIDs, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get away users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan away user: %w", err)
+		}
+		result[userID] = true
+	}
+	return result, rows.Err()
+}
+
+// GetPendingHandovers returns started absences whose open reviews were not handed over yet
+func (r *AvailabilityRepository) GetPendingHandovers(ctx context.Context, at time.Time) ([]domain.Absence, error) {
+	query := `
+        SELECT id, user_id, starts_at, ends_at, reason, hand_over_reviews, handed_over_at, created_at
+        FROM user_availability
+        WHERE hand_over_reviews AND handed_over_at IS NULL
+          AND starts_at <= $1 AND ends_at > $1
+        ORDER BY starts_at
+    `
+	rows, err := r.pool.Query(ctx, query, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending handovers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *AvailabilityRepository) MarkHandedOver(ctx context.Context, absenceIDs []int64, at time.Time) error {
+	if len(absenceIDs) == 0 {
+		return nil
+	}
+
+	query := `UPDATE user_availability SET handed_over_at = $1 WHERE id = ANY($2)`
+	if _, err := r.pool.Exec(ctx, query, at, absenceIDs); err != nil {
+		return fmt.Errorf("failed to mark absences handed over: %w", err)
+	}
+	return nil
+}
+
+func scanAbsences(rows pgx.Rows) ([]domain.Absence, error) {
+	absences := []domain.Absence{}
+	for rows.Next() {
+		var absence domain.Absence
+		err := rows.Scan(
+			&absence.ID,
+			&absence.UserID,
+			&absence.StartsAt,
+			&absence.EndsAt,
+			&absence.Reason,
+			&absence.HandOverReviews,
+			&absence.HandedOverAt,
+			&absence.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan absence: %w", err)
+		}
+		absences = append(absences, absence)
+	}
+	return absences, rows.Err()
+}
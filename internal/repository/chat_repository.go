@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChatRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewChatRepository(pool *pgxpool.Pool) *ChatRepository {
+	return &ChatRepository{pool: pool}
+}
+
+func (r *ChatRepository) GetSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error) {
+	settings, err := r.FindSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, fmt.Errorf("chat settings not found")
+	}
+	return settings, nil
+}
+
+// FindSettings returns the team's chat settings or nil when the team's chat is not configured
+func (r *ChatRepository) FindSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error) {
+	query := `
+        SELECT team_name, webhook_url, assigned_template, reassigned_template, merged_template, updated_at
+        FROM team_chat_settings
+        WHERE team_name = $1
+    `
+	var settings domain.TeamChatSettings
+	err := r.pool.QueryRow(ctx, query, teamName).Scan(
+		&settings.TeamName,
+		&settings.WebhookURL,
+		&settings.Templates.Assigned,
+		&settings.Templates.Reassigned,
+		&settings.Templates.Merged,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chat settings: %w", err)
+	}
+	return &settings, nil
+}
+
+func (r *ChatRepository) UpsertSettings(ctx context.Context, settings *domain.TeamChatSettings) error {
+	query := `
+        INSERT INTO team_chat_settings (team_name, webhook_url, assigned_template, reassigned_template, merged_template)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (team_name)
+        DO UPDATE SET
+            webhook_url = EXCLUDED.webhook_url,
+            assigned_template = EXCLUDED.assigned_template,
+            reassigned_template = EXCLUDED.reassigned_template,
+            merged_template = EXCLUDED.merged_template,
+            updated_at = NOW()
+        RETURNING updated_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		settings.TeamName,
+		settings.WebhookURL,
+		settings.Templates.Assigned,
+		settings.Templates.Reassigned,
+		settings.Templates.Merged,
+	).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save chat settings: %w", err)
+	}
+	return nil
+}
+
+func (r *ChatRepository) DeleteSettings(ctx context.Context, teamName string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM team_chat_settings WHERE team_name = $1`, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete chat settings: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("chat settings not found")
+	}
+	return nil
+}
+
+// CreatePost queues the post for the team's chat. Nothing is queued when the team's chat has been disabled meanwhile
+// or the post of the outbox event is already queued, the post's ID stays 0 then
+func (r *ChatRepository) CreatePost(ctx context.Context, post *domain.ChatPost) error {
+	query := `
+        INSERT INTO chat_posts (team_name, event_type, text, outbox_id)
+        SELECT team_name, $2, $3, NULLIF($4::BIGINT, 0)
+        FROM team_chat_settings
+        WHERE team_name = $1
+        ON CONFLICT (outbox_id) DO NOTHING
+        RETURNING id, status, next_attempt_at, created_at
+    `
+	err := r.pool.QueryRow(ctx, query, post.TeamName, post.EventType, post.Text, post.OutboxID).
+		Scan(&post.ID, &post.Status, &post.NextAttemptAt, &post.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to create chat post: %w", err)
+	}
+	return nil
+}
+
+// ClaimDuePosts locks up to limit pending posts that are due at now by moving their next attempt to leaseUntil.
+// Posts claimed by another replica are skipped, a post whose sender died is picked up again after the lease
+func (r *ChatRepository) ClaimDuePosts(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.ChatPost, error) {
+	query := `
+        UPDATE chat_posts p
+        SET next_attempt_at = $1
+        FROM team_chat_settings s
+        WHERE s.team_name = p.team_name AND p.id IN (
+            SELECT due.id
+            FROM chat_posts due
+            WHERE due.status = 'pending' AND due.next_attempt_at <= $2
+            ORDER BY due.next_attempt_at, due.id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING p.id, p.team_name, p.event_type, p.text, p.status, p.attempts, p.next_attempt_at,
+                  p.last_attempt_at, p.last_error, p.delivered_at, p.created_at, s.webhook_url
+    `
+	rows, err := r.pool.Query(ctx, query, leaseUntil, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim chat posts: %w", err)
+	}
+	defer rows.Close()
+
+	posts := []domain.ChatPost{}
+	for rows.Next() {
+		var post domain.ChatPost
+		err := rows.Scan(
+			&post.ID,
+			&post.TeamName,
+			&post.EventType,
+			&post.Text,
+			&post.Status,
+			&post.Attempts,
+			&post.NextAttemptAt,
+			&post.LastAttemptAt,
+			&post.LastError,
+			&post.DeliveredAt,
+			&post.CreatedAt,
+			&post.WebhookURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// SavePostAttempt stores the result of a post attempt
+func (r *ChatRepository) SavePostAttempt(ctx context.Context, post *domain.ChatPost) error {
+	query := `
+        UPDATE chat_posts
+        SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, last_error = $5, delivered_at = $6
+        WHERE id = $7
+    `
+	_, err := r.pool.Exec(ctx, query,
+		post.Status,
+		post.Attempts,
+		post.NextAttemptAt,
+		post.LastAttemptAt,
+		post.LastError,
+		post.DeliveredAt,
+		post.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save chat post attempt: %w", err)
+	}
+	return nil
+}
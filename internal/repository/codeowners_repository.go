@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CodeOwnersRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCodeOwnersRepository(pool *pgxpool.Pool) *CodeOwnersRepository {
+	return &CodeOwnersRepository{pool: pool}
+}
+
+// ReplaceRules atomically replaces the whole rule set
+func (r *CodeOwnersRepository) ReplaceRules(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM code_owner_rules`); err != nil {
+		return fmt.Errorf("failed to delete code owner rules: %w", err)
+	}
+
+	query := `
+        INSERT INTO code_owner_rules (position, pattern, owner_users, owner_teams)
+        VALUES ($1, $2, $3, $4)
+    `
+	for _, rule := range rules {
+		_, err := tx.Exec(ctx, query, rule.Line, rule.Pattern, rule.Users, rule.Teams)
+		if err != nil {
+			return fmt.Errorf("failed to insert code owner rule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetRules returns rules in file order
+func (r *CodeOwnersRepository) GetRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	query := `
+        SELECT position, pattern, owner_users, owner_teams
+        FROM code_owner_rules
+        ORDER BY position
+    `
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code owner rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []domain.CodeOwnerRule{}
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Line, &rule.Pattern, &rule.Users, &rule.Teams); err != nil {
+			return nil, fmt.Errorf("failed to scan code owner rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IntegrationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewIntegrationRepository(pool *pgxpool.Pool) *IntegrationRepository {
+	return &IntegrationRepository{pool: pool}
+}
+
+func (r *IntegrationRepository) UpsertAccount(ctx context.Context, account *domain.IntegrationAccount) error {
+	query := `
+        INSERT INTO integration_accounts (provider, login, user_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (provider, login)
+        DO UPDATE SET user_id = EXCLUDED.user_id
+        RETURNING created_at
+    `
+	err := r.pool.QueryRow(ctx, query, account.Provider, account.Login, account.UserID).Scan(&account.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save integration account: %w", err)
+	}
+	return nil
+}
+
+func (r *IntegrationRepository) ListAccounts(ctx context.Context, provider string) ([]domain.IntegrationAccount, error) {
+	query := `
+        SELECT provider, login, user_id, created_at
+        FROM integration_accounts
+        WHERE $1 = '' OR provider = $1
+        ORDER BY provider, login
+    `
+	rows, err := r.pool.Query(ctx, query, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integration accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []domain.IntegrationAccount{}
+	for rows.Next() {
+		var account domain.IntegrationAccount
+		if err := rows.Scan(&account.Provider, &account.Login, &account.UserID, &account.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan integration account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func (r *IntegrationRepository) GetUserIDByLogin(ctx context.Context, provider, login string) (string, error) {
+	query := `SELECT user_id FROM integration_accounts WHERE provider = $1 AND login = $2`
+	var userID string
+	if err := r.pool.QueryRow(ctx, query, provider, login).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("integration account not found")
+		}
+		return "", fmt.Errorf("failed to get integration account: %w", err)
+	}
+	return userID, nil
+}
+
+// GetLoginsByUserIDs returns one login of the provider per user in the order of userIDs, users without a login are skipped
+func (r *IntegrationRepository) GetLoginsByUserIDs(ctx context.Context, provider string, userIDs []string) ([]string, error) {
+	query := `
+        SELECT login
+        FROM (
+            SELECT DISTINCT ON (a.user_id) a.login, u.position
+            FROM integration_accounts a
+            INNER JOIN unnest($2::text[]) WITH ORDINALITY AS u(user_id, position) ON u.user_id = a.user_id
+            WHERE a.provider = $1
+            ORDER BY a.user_id, a.login
+        ) logins
+        ORDER BY position
+    `
+	rows, err := r.pool.Query(ctx, query, provider, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration logins: %w", err)
+	}
+	defer rows.Close()
+
+	logins := []string{}
+	for rows.Next() {
+		var login string
+		if err := rows.Scan(&login); err != nil {
+			return nil, fmt.Errorf("failed to scan integration login: %w", err)
+		}
+		logins = append(logins, login)
+	}
+	return logins, rows.Err()
+}
+
+// ClaimDelivery records the delivery and reports whether it is new
+func (r *IntegrationRepository) ClaimDelivery(ctx context.Context, delivery *domain.InboundDelivery) (bool, error) {
+	query := `
+        INSERT INTO integration_deliveries (provider, delivery_id, event, action, pull_request_id)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (provider, delivery_id) DO NOTHING
+    `
+	result, err := r.pool.Exec(ctx, query,
+		delivery.Provider,
+		delivery.DeliveryID,
+		delivery.Event,
+		delivery.Action,
+		delivery.PullRequestID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record inbound delivery: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseDelivery forgets a delivery that failed, so that its redelivery is applied
+func (r *IntegrationRepository) ReleaseDelivery(ctx context.Context, provider, deliveryID string) error {
+	query := `DELETE FROM integration_deliveries WHERE provider = $1 AND delivery_id = $2`
+	if _, err := r.pool.Exec(ctx, query, provider, deliveryID); err != nil {
+		return fmt.Errorf("failed to release inbound delivery: %w", err)
+	}
+	return nil
+}
+
+// QueueReviewerSync keeps the post-back of the merge request's reviewers until the GitLab sync worker sends it
+func (r *IntegrationRepository) QueueReviewerSync(ctx context.Context, sync *domain.GitLabReviewerSync) error {
+	query := `
+        INSERT INTO gitlab_reviewer_syncs (pull_request_id, project_id, iid, reviewer_ids)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, status, next_attempt_at, created_at
+    `
+	err := r.pool.QueryRow(ctx, query, sync.PullRequestID, sync.ProjectID, sync.IID, sync.ReviewerIDs).
+		Scan(&sync.ID, &sync.Status, &sync.NextAttemptAt, &sync.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to queue gitlab reviewer sync: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueReviewerSyncs locks up to limit pending syncs that are due at now by moving their next attempt to leaseUntil.
+// Syncs claimed by another replica are skipped, a sync whose sender died is picked up again after the lease
+func (r *IntegrationRepository) ClaimDueReviewerSyncs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.GitLabReviewerSync, error) {
+	query := `
+        UPDATE gitlab_reviewer_syncs
+        SET next_attempt_at = $1
+        WHERE id IN (
+            SELECT id
+            FROM gitlab_reviewer_syncs
+            WHERE status = 'pending' AND next_attempt_at <= $2
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, pull_request_id, project_id, iid, reviewer_ids, status, attempts, next_attempt_at,
+                  last_attempt_at, last_error, delivered_at, created_at
+    `
+	rows, err := r.pool.Query(ctx, query, leaseUntil, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim gitlab reviewer syncs: %w", err)
+	}
+	defer rows.Close()
+
+	syncs := []domain.GitLabReviewerSync{}
+	for rows.Next() {
+		var sync domain.GitLabReviewerSync
+		err := rows.Scan(
+			&sync.ID,
+			&sync.PullRequestID,
+			&sync.ProjectID,
+			&sync.IID,
+			&sync.ReviewerIDs,
+			&sync.Status,
+			&sync.Attempts,
+			&sync.NextAttemptAt,
+			&sync.LastAttemptAt,
+			&sync.LastError,
+			&sync.DeliveredAt,
+			&sync.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gitlab reviewer sync: %w", err)
+		}
+		syncs = append(syncs, sync)
+	}
+	return syncs, rows.Err()
+}
+
+// SaveReviewerSyncAttempt stores the result of a sync attempt
+func (r *IntegrationRepository) SaveReviewerSyncAttempt(ctx context.Context, sync *domain.GitLabReviewerSync) error {
+	query := `
+        UPDATE gitlab_reviewer_syncs
+        SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, last_error = $5, delivered_at = $6
+        WHERE id = $7
+    `
+	_, err := r.pool.Exec(ctx, query,
+		sync.Status,
+		sync.Attempts,
+		sync.NextAttemptAt,
+		sync.LastAttemptAt,
+		sync.LastError,
+		sync.DeliveredAt,
+		sync.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save gitlab reviewer sync attempt: %w", err)
+	}
+	return nil
+}
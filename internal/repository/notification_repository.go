@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{pool: pool}
+}
+
+// QueueDigestItem keeps the notification until the user's next digest.
+// Nothing is queued when the item of the outbox event is already queued, the item's ID stays 0 then
+func (r *NotificationRepository) QueueDigestItem(ctx context.Context, item *domain.DigestItem) error {
+	query := `
+        INSERT INTO notification_digest_items (user_id, event_type, pull_request_id, summary, outbox_id)
+        VALUES ($1, $2, $3, $4, NULLIF($5::BIGINT, 0))
+        ON CONFLICT (outbox_id) DO NOTHING
+        RETURNING id, created_at
+    `
+	err := r.pool.QueryRow(ctx, query, item.UserID, item.EventType, item.PullRequestID, item.Summary, item.OutboxID).
+		Scan(&item.ID, &item.CreatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to queue digest item: %w", err)
+	}
+	return nil
+}
+
+// QueueEmail keeps the email until the email worker sends it.
+// Nothing is queued when the email of the outbox event is already queued, the email's ID stays 0 then
+func (r *NotificationRepository) QueueEmail(ctx context.Context, email *domain.OutgoingEmail) error {
+	query := `
+        INSERT INTO outgoing_emails (user_id, event_type, recipient, subject, html, outbox_id)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6::BIGINT, 0))
+        ON CONFLICT (outbox_id) DO NOTHING
+        RETURNING id, status, next_attempt_at, created_at
+    `
+	err := r.pool.QueryRow(ctx, query, email.UserID, email.EventType, email.Recipient, email.Subject, email.HTML, email.OutboxID).
+		Scan(&email.ID, &email.Status, &email.NextAttemptAt, &email.CreatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueEmails locks up to limit pending emails that are due at now by moving their next attempt to leaseUntil.
+// Emails claimed by another replica are skipped, an email whose sender died is picked up again after the lease
+func (r *NotificationRepository) ClaimDueEmails(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutgoingEmail, error) {
+	query := `
+        UPDATE outgoing_emails
+        SET next_attempt_at = $1
+        WHERE id IN (
+            SELECT id
+            FROM outgoing_emails
+            WHERE status = 'pending' AND next_attempt_at <= $2
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, user_id, event_type, recipient, subject, html, status, attempts, next_attempt_at,
+                  last_attempt_at, last_error, delivered_at, created_at
+    `
+	rows, err := r.pool.Query(ctx, query, leaseUntil, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim emails: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []domain.OutgoingEmail{}
+	for rows.Next() {
+		var email domain.OutgoingEmail
+		err := rows.Scan(
+			&email.ID,
+			&email.UserID,
+			&email.EventType,
+			&email.Recipient,
+			&email.Subject,
+			&email.HTML,
+			&email.Status,
+			&email.Attempts,
+			&email.NextAttemptAt,
+			&email.LastAttemptAt,
+			&email.LastError,
+			&email.DeliveredAt,
+			&email.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
+
+// SaveEmailAttempt stores the result of a sending attempt
+func (r *NotificationRepository) SaveEmailAttempt(ctx context.Context, email *domain.OutgoingEmail) error {
+	query := `
+        UPDATE outgoing_emails
+        SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4, last_error = $5, delivered_at = $6
+        WHERE id = $7
+    `
+	_, err := r.pool.Exec(ctx, query,
+		email.Status,
+		email.Attempts,
+		email.NextAttemptAt,
+		email.LastAttemptAt,
+		email.LastError,
+		email.DeliveredAt,
+		email.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save email attempt: %w", err)
+	}
+	return nil
+}
+
+// GetPendingDigestItems returns the notifications of the user not sent in a digest yet, oldest first
+func (r *NotificationRepository) GetPendingDigestItems(ctx context.Context, userID string) ([]domain.DigestItem, error) {
+	query := `
+        SELECT id, user_id, event_type, pull_request_id, summary, created_at
+        FROM notification_digest_items
+        WHERE user_id = $1 AND sent_at IS NULL
+        ORDER BY id
+    `
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.DigestItem{}
+	for rows.Next() {
+		var item domain.DigestItem
+		if err := rows.Scan(&item.ID, &item.UserID, &item.EventType, &item.PullRequestID, &item.Summary, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digest items: %w", err)
+	}
+	return items, nil
+}
+
+func (r *NotificationRepository) MarkDigestItemsSent(ctx context.Context, itemIDs []int64, at time.Time) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+	query := `UPDATE notification_digest_items SET sent_at = $1 WHERE id = ANY($2)`
+	if _, err := r.pool.Exec(ctx, query, at, itemIDs); err != nil {
+		return fmt.Errorf("failed to mark digest items sent: %w", err)
+	}
+	return nil
+}
+
+// ClaimDigest records the user's digest of the local day, false means it is already sent or being sent
+func (r *NotificationRepository) ClaimDigest(ctx context.Context, userID string, localDate time.Time) (bool, error) {
+	query := `
+        INSERT INTO user_digests (user_id, local_date)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, local_date) DO NOTHING
+    `
+	result, err := r.pool.Exec(ctx, query, userID, localDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// ReleaseDigest forgets a digest that failed to send so the next run retries it
+func (r *NotificationRepository) ReleaseDigest(ctx context.Context, userID string, localDate time.Time) error {
+	query := `DELETE FROM user_digests WHERE user_id = $1 AND local_date = $2`
+	if _, err := r.pool.Exec(ctx, query, userID, localDate); err != nil {
+		return fmt.Errorf("failed to release digest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+// insertOutbox stores the event in the transaction of the change, it is published only if the transaction commits
+func insertOutbox(ctx context.Context, tx pgx.Tx, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+	query := `INSERT INTO outbox (event_type, payload) VALUES ($1, $2)`
+	if _, err := tx.Exec(ctx, query, eventType, payload); err != nil {
+		return fmt.Errorf("failed to store %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// ProcessBatch locks up to limit unpublished messages in id order and hands them to handle one by one.
+// Messages locked by another relay are skipped, so relays on several replicas publish their batches concurrently
+// and the order holds only within a batch. Handled messages are marked published in the same transaction,
+// the first failed message stops the batch so that later messages of the batch are not published before it.
+// A message whose handling succeeded but whose batch was not committed is handled again, the publishers
+// key their rows by the message ID to queue it once
+func (r *OutboxRepository) ProcessBatch(ctx context.Context, limit int, handle func(domain.OutboxMessage) error) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	query := `
+        SELECT id, event_type, payload, created_at
+        FROM outbox
+        WHERE published_at IS NULL
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock outbox messages: %w", err)
+	}
+	messages := []domain.OutboxMessage{}
+	for rows.Next() {
+		var message domain.OutboxMessage
+		var payload []byte
+		if err := rows.Scan(&message.ID, &message.EventType, &payload, &message.CreatedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		message.Payload = payload
+		messages = append(messages, message)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to lock outbox messages: %w", err)
+	}
+
+	published := make([]int64, 0, len(messages))
+	var handleErr error
+	for _, message := range messages {
+		if handleErr = handle(message); handleErr != nil {
+			handleErr = fmt.Errorf("failed to publish outbox message %d: %w", message.ID, handleErr)
+			break
+		}
+		published = append(published, message.ID)
+	}
+
+	if len(published) > 0 {
+		updateQuery := `UPDATE outbox SET published_at = NOW() WHERE id = ANY($1)`
+		if _, err := tx.Exec(ctx, updateQuery, published); err != nil {
+			return 0, fmt.Errorf("failed to mark outbox messages published: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(published), handleErr
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"pr-reviewer-service/internal/domain"
@@ -21,7 +23,7 @@ func NewPRRepository(pool *pgxpool.Pool) *PRRepository {
 	return &PRRepository{pool: pool}
 }
 
-func (r *PRRepository) CreatePR(ctx context.Context, pr *domain.PullRequest) error {
+func (r *PRRepository) CreatePR(ctx context.Context, pr *domain.PullRequest, source domain.EventSource) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -33,25 +35,151 @@ func (r *PRRepository) CreatePR(ctx context.Context, pr *domain.PullRequest) err
 	}()
 
 	query := `
-        INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO pull_requests (
+            pull_request_id, pull_request_name, author_id, status,
+            assignment_strategy, changed_files, required_skills, assignment_seed, assignment_trace
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
     `
-	_, err = tx.Exec(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
+	changedFiles := pr.ChangedFiles
+	if changedFiles == nil {
+		changedFiles = []string{}
+	}
+	requiredSkills := pr.RequiredSkills
+	if requiredSkills == nil {
+		requiredSkills = []string{}
+	}
+	trace, err := marshalTrace(pr.AssignmentTrace)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		pr.Status,
+		pr.AssignmentStrategy,
+		changedFiles,
+		requiredSkills,
+		pr.AssignmentSeed,
+		trace,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
-	if len(pr.AssignedReviewers) > 0 {
-		reviewerQuery := `
-            INSERT INTO pr_reviewers (pull_request_id, user_id)
-            VALUES ($1, $2)
-        `
-		for _, reviewerID := range pr.AssignedReviewers {
-			_, err = tx.Exec(ctx, reviewerQuery, pr.PullRequestID, reviewerID)
-			if err != nil {
-				return fmt.Errorf("failed to assign reviewer: %w", err)
-			}
+	if err := insertReviewers(ctx, tx, pr); err != nil {
+		return err
+	}
+
+	if err := insertOutbox(ctx, tx, domain.WebhookPRCreated, prData(pr, pr.AssignedReviewers)); err != nil {
+		return err
+	}
+	created := source.StatusEvent(pr.PullRequestID, domain.EventCreated, "", pr.Status)
+	if err := insertEvents(ctx, tx, append([]domain.PREvent{created}, assignedEvents(pr, source)...)...); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// MarkReady moves a DRAFT PR to OPEN and stores its initial reviewers with the assignment details
+func (r *PRRepository) MarkReady(ctx context.Context, pr *domain.PullRequest, source domain.EventSource) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	trace, err := marshalTrace(pr.AssignmentTrace)
+	if err != nil {
+		return err
+	}
+	query := `
+        UPDATE pull_requests
+        SET status = $1, assignment_strategy = $2, assignment_seed = $3, assignment_trace = $4
+        WHERE pull_request_id = $5 AND status = $6
+    `
+	result, err := tx.Exec(ctx, query,
+		domain.StatusOpen,
+		pr.AssignmentStrategy,
+		pr.AssignmentSeed,
+		trace,
+		pr.PullRequestID,
+		domain.StatusDraft,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark PR ready: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("PR is not a draft")
+	}
+
+	if err := insertReviewers(ctx, tx, pr); err != nil {
+		return err
+	}
+
+	data := prData(pr, pr.AssignedReviewers)
+	data.PullRequest.Status = domain.StatusOpen
+	if err := insertOutbox(ctx, tx, domain.WebhookPRReady, data); err != nil {
+		return err
+	}
+	ready := source.StatusEvent(pr.PullRequestID, domain.EventStatusChanged, domain.StatusDraft, domain.StatusOpen)
+	if err := insertEvents(ctx, tx, append([]domain.PREvent{ready}, assignedEvents(pr, source)...)...); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetStatus moves the PR from one status to another, closed_at is set only for CLOSED PRs
+func (r *PRRepository) SetStatus(ctx context.Context, prID, from, to string, source domain.EventSource) error {
+	var closedAt *time.Time
+	if to == domain.StatusClosed {
+		now := time.Now()
+		closedAt = &now
+	}
+	query := `
+        UPDATE pull_requests
+        SET status = $1, closed_at = $2
+        WHERE pull_request_id = $3 AND status = $4
+    `
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, to, closedAt, prID, from)
+		if err != nil {
+			return fmt.Errorf("failed to set PR status: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("PR status has changed")
+		}
+		return insertEvents(ctx, tx, source.StatusEvent(prID, domain.EventStatusChanged, from, to))
+	})
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds
+func (r *PRRepository) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
 		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -60,6 +188,175 @@ func (r *PRRepository) CreatePR(ctx context.Context, pr *domain.PullRequest) err
 	return nil
 }
 
+func insertReviewers(ctx context.Context, tx pgx.Tx, pr *domain.PullRequest) error {
+	reviewerQuery := `
+        INSERT INTO pr_reviewers (pull_request_id, user_id, is_fallback)
+        VALUES ($1, $2, $3)
+    `
+	fallback := make(map[string]bool, len(pr.FallbackReviewers))
+	for _, reviewerID := range pr.FallbackReviewers {
+		fallback[reviewerID] = true
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if _, err := tx.Exec(ctx, reviewerQuery, pr.PullRequestID, reviewerID, fallback[reviewerID]); err != nil {
+			return fmt.Errorf("failed to assign reviewer: %w", err)
+		}
+	}
+	return nil
+}
+
+func assignedEvents(pr *domain.PullRequest, source domain.EventSource) []domain.PREvent {
+	events := make([]domain.PREvent, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		events = append(events, source.ReviewerEvent(pr.PullRequestID, domain.EventAssigned, reviewerID))
+	}
+	return events
+}
+
+// insertEvents appends the events to the PR timeline, empty fields are stored as NULL.
+// Reviewer assignments and reassignments are also stored in the outbox
+func insertEvents(ctx context.Context, tx pgx.Tx, events ...domain.PREvent) error {
+	query := `
+        INSERT INTO pr_events (
+            pull_request_id, event_type, actor_id, reviewer_id, previous_reviewer_id,
+            from_status, to_status, review_state, reason, assignment_trace
+        )
+        VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10)
+    `
+	for _, event := range events {
+		trace, err := marshalTrace(event.AssignmentTrace)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, query,
+			event.PullRequestID,
+			event.Type,
+			event.ActorID,
+			event.ReviewerID,
+			event.PreviousReviewerID,
+			event.FromStatus,
+			event.ToStatus,
+			event.ReviewState,
+			event.Reason,
+			trace,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record %s event: %w", event.Type, err)
+		}
+
+		var eventType string
+		switch event.Type {
+		case domain.EventAssigned:
+			eventType = domain.WebhookReviewerAssigned
+		case domain.EventReassigned:
+			eventType = domain.WebhookReviewerReassigned
+		default:
+			continue
+		}
+		data := domain.WebhookReviewerData{
+			PullRequestID:      event.PullRequestID,
+			ReviewerID:         event.ReviewerID,
+			PreviousReviewerID: event.PreviousReviewerID,
+			Reason:             event.Reason,
+		}
+		if err := insertOutbox(ctx, tx, eventType, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prData describes the PR in pr.* events
+func prData(pr *domain.PullRequest, reviewers []string) domain.WebhookPRData {
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return domain.WebhookPRData{
+		PullRequest: domain.PullRequestShort{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+		},
+		Reviewers: reviewers,
+	}
+}
+
+func marshalTrace(trace *domain.AssignmentTrace) ([]byte, error) {
+	if trace == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(trace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assignment trace: %w", err)
+	}
+	return data, nil
+}
+
+// GetAssignmentTrace returns the recorded initial reviewer pick, nil if it was not recorded
+func (r *PRRepository) GetAssignmentTrace(ctx context.Context, prID string) (*domain.AssignmentTrace, error) {
+	query := `SELECT assignment_trace FROM pull_requests WHERE pull_request_id = $1`
+	var data []byte
+	err := r.pool.QueryRow(ctx, query, prID).Scan(&data)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("PR not found")
+		}
+		return nil, fmt.Errorf("failed to get assignment trace: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var trace domain.AssignmentTrace
+	if err := json.Unmarshal(data, &trace); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal assignment trace: %w", err)
+	}
+	return &trace, nil
+}
+
+// GetReassignmentTraces returns the reassignments of the PR that were picked by a strategy, oldest first
+func (r *PRRepository) GetReassignmentTraces(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	query := `
+        SELECT id, pull_request_id, event_type, COALESCE(actor_id, ''), COALESCE(reviewer_id, ''),
+               COALESCE(previous_reviewer_id, ''), reason, assignment_trace, created_at
+        FROM pr_events
+        WHERE pull_request_id = $1 AND event_type = 'reassigned' AND assignment_trace IS NOT NULL
+        ORDER BY created_at, id
+    `
+	rows, err := r.pool.Query(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassignment traces: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.PREvent{}
+	for rows.Next() {
+		var event domain.PREvent
+		var data []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.PullRequestID,
+			&event.Type,
+			&event.ActorID,
+			&event.ReviewerID,
+			&event.PreviousReviewerID,
+			&event.Reason,
+			&data,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reassignment trace: %w", err)
+		}
+		var trace domain.AssignmentTrace
+		if err := json.Unmarshal(data, &trace); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal assignment trace: %w", err)
+		}
+		event.AssignmentTrace = &trace
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
 func (r *PRRepository) PRExists(ctx context.Context, prID string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`
 	var exists bool
@@ -70,21 +367,35 @@ func (r *PRRepository) PRExists(ctx context.Context, prID string) (bool, error)
 	return exists, nil
 }
 
-func (r *PRRepository) GetPRByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	query := `
-        SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-        FROM pull_requests
-        WHERE pull_request_id = $1
-    `
+const prColumns = `
+        pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.assignment_strategy, pr.changed_files,
+        pr.required_skills, pr.assignment_seed, pr.created_at, pr.merged_at, pr.closed_at
+`
+
+func scanPR(row pgx.Row) (*domain.PullRequest, error) {
 	var pr domain.PullRequest
-	err := r.pool.QueryRow(ctx, query, prID).Scan(
+	err := row.Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&pr.Status,
+		&pr.AssignmentStrategy,
+		&pr.ChangedFiles,
+		&pr.RequiredSkills,
+		&pr.AssignmentSeed,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.ClosedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (r *PRRepository) GetPRByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	query := `SELECT ` + prColumns + ` FROM pull_requests pr WHERE pr.pull_request_id = $1`
+	pr, err := scanPR(r.pool.QueryRow(ctx, query, prID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("PR not found")
@@ -92,37 +403,175 @@ func (r *PRRepository) GetPRByID(ctx context.Context, prID string) (*domain.Pull
 		return nil, fmt.Errorf("failed to get PR: %w", err)
 	}
 
-	reviewersQuery := `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`
-	rows, err := r.pool.Query(ctx, reviewersQuery, prID)
+	if err := r.loadReviewers(ctx, []*domain.PullRequest{pr}); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// ListPRs returns PRs matching the filter, newest first.
+// Ties of created_at are ordered by pull_request_id, so the cursor always points at a single row
+func (r *PRRepository) ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.AuthorID != "" {
+		addCondition("pr.author_id = $%d", filter.AuthorID)
+	}
+	if filter.ReviewerID != "" {
+		addCondition("EXISTS (SELECT 1 FROM pr_reviewers prr WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = $%d)", filter.ReviewerID)
+	}
+	if filter.TeamName != "" {
+		addCondition("u.team_name = $%d", filter.TeamName)
+	}
+	if filter.Status != "" {
+		addCondition("pr.status = $%d", filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("pr.created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("pr.created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.MergedFrom != nil {
+		addCondition("pr.merged_at >= $%d", *filter.MergedFrom)
+	}
+	if filter.MergedTo != nil {
+		addCondition("pr.merged_at < $%d", *filter.MergedTo)
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.PullRequestID)
+		conditions = append(conditions, fmt.Sprintf("(pr.created_at, pr.pull_request_id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + prColumns + ` FROM pull_requests pr INNER JOIN users u ON u.user_id = pr.author_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY pr.created_at DESC, pr.pull_request_id DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
 	}
 	defer rows.Close()
 
-	var reviewers []string
+	var result []*domain.PullRequest
 	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
-		reviewers = append(reviewers, userID)
+		result = append(result, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
 	}
-	pr.AssignedReviewers = reviewers
 
-	return &pr, nil
+	if err := r.loadReviewers(ctx, result); err != nil {
+		return nil, err
+	}
+
+	prs := make([]domain.PullRequest, len(result))
+	for i, pr := range result {
+		prs[i] = *pr
+	}
+	return prs, nil
 }
 
-func (r *PRRepository) MergePR(ctx context.Context, prID string) error {
+// loadReviewers fills reviewers and their reviews of the PRs with a single query
+func (r *PRRepository) loadReviewers(ctx context.Context, prs []*domain.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.PullRequest, len(prs))
+	prIDs := make([]string, len(prs))
+	for i, pr := range prs {
+		pr.AssignedReviewers = []string{}
+		pr.FallbackReviewers = []string{}
+		pr.Reviews = []domain.Review{}
+		byID[pr.PullRequestID] = pr
+		prIDs[i] = pr.PullRequestID
+	}
+
+	reviewersQuery := `
+        SELECT prr.pull_request_id, prr.user_id, prr.is_fallback, prr.review_state, prr.reviewed_at, u.team_name
+        FROM pr_reviewers prr
+        INNER JOIN users u ON u.user_id = prr.user_id
+        WHERE prr.pull_request_id = ANY($1)
+    `
+	rows, err := r.pool.Query(ctx, reviewersQuery, prIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		var review domain.Review
+		var isFallback bool
+		if err := rows.Scan(&prID, &review.UserID, &isFallback, &review.State, &review.ReviewedAt, &review.TeamName); err != nil {
+			return fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		pr := byID[prID]
+		pr.AssignedReviewers = append(pr.AssignedReviewers, review.UserID)
+		if isFallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, review.UserID)
+		}
+		pr.Reviews = append(pr.Reviews, review)
+	}
+	return rows.Err()
+}
+
+func (r *PRRepository) MergePR(ctx context.Context, prID string, source domain.EventSource) error {
 	query := `
         UPDATE pull_requests
         SET status = $1, merged_at = $2
         WHERE pull_request_id = $3 AND status = $4
+        RETURNING pull_request_name, author_id
     `
-	_, err := r.pool.Exec(ctx, query, domain.StatusMerged, time.Now(), prID, domain.StatusOpen)
-	if err != nil {
-		return fmt.Errorf("failed to merge PR: %w", err)
-	}
-	return nil
+	reviewersQuery := `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY assigned_at, user_id`
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		pr := domain.PullRequest{PullRequestID: prID, Status: domain.StatusMerged}
+		err := tx.QueryRow(ctx, query, domain.StatusMerged, time.Now(), prID, domain.StatusOpen).
+			Scan(&pr.PullRequestName, &pr.AuthorID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("PR is not open")
+			}
+			return fmt.Errorf("failed to merge PR: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, reviewersQuery, prID)
+		if err != nil {
+			return fmt.Errorf("failed to get reviewers: %w", err)
+		}
+		reviewers := []string{}
+		for rows.Next() {
+			var reviewerID string
+			if err := rows.Scan(&reviewerID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan reviewer: %w", err)
+			}
+			reviewers = append(reviewers, reviewerID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to get reviewers: %w", err)
+		}
+
+		if err := insertOutbox(ctx, tx, domain.WebhookPRMerged, prData(&pr, reviewers)); err != nil {
+			return err
+		}
+		return insertEvents(ctx, tx, source.StatusEvent(prID, domain.EventMerged, domain.StatusOpen, domain.StatusMerged))
+	})
 }
 
 func (r *PRRepository) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
@@ -135,25 +584,82 @@ func (r *PRRepository) IsReviewerAssigned(ctx context.Context, prID, userID stri
 	return exists, nil
 }
 
-func (r *PRRepository) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+func (r *PRRepository) ReassignReviewer(ctx context.Context, prID, oldUserID string, replacement domain.ReviewerReplacement, source domain.EventSource) error {
+	query := `
+        UPDATE pr_reviewers
+        SET user_id = $1, is_fallback = $2, assigned_at = NOW(), review_state = 'pending', reviewed_at = NULL
+        WHERE pull_request_id = $3 AND user_id = $4
+    `
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, replacement.UserID, replacement.IsFallback, prID, oldUserID)
+		if err != nil {
+			return fmt.Errorf("failed to reassign reviewer: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("reviewer assignment not found")
+		}
+		return insertEvents(ctx, tx, reassignedEvent(prID, oldUserID, replacement, source))
+	})
+}
+
+func reassignedEvent(prID, oldUserID string, replacement domain.ReviewerReplacement, source domain.EventSource) domain.PREvent {
+	event := source.ReviewerEvent(prID, domain.EventReassigned, replacement.UserID)
+	event.PreviousReviewerID = oldUserID
+	event.AssignmentTrace = replacement.Trace
+	return event
+}
+
+func (r *PRRepository) SetReviewState(ctx context.Context, prID, userID, state string, source domain.EventSource) error {
 	query := `
         UPDATE pr_reviewers
-        SET user_id = $1
+        SET review_state = $1, reviewed_at = NOW()
         WHERE pull_request_id = $2 AND user_id = $3
     `
-	result, err := r.pool.Exec(ctx, query, newUserID, prID, oldUserID)
-	if err != nil {
-		return fmt.Errorf("failed to reassign reviewer: %w", err)
-	}
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("reviewer assignment not found")
-	}
-	return nil
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, state, prID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to set review state: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("reviewer assignment not found")
+		}
+		event := source.Event(prID, domain.EventReviewed)
+		event.ReviewerID = userID
+		event.ReviewState = state
+		return insertEvents(ctx, tx, event)
+	})
+}
+
+func (r *PRRepository) AddReviewer(ctx context.Context, prID, userID string, source domain.EventSource) error {
+	query := `
+        INSERT INTO pr_reviewers (pull_request_id, user_id, is_fallback)
+        VALUES ($1, $2, false)
+    `
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, query, prID, userID); err != nil {
+			return fmt.Errorf("failed to add reviewer: %w", err)
+		}
+		return insertEvents(ctx, tx, source.ReviewerEvent(prID, domain.EventAssigned, userID))
+	})
+}
+
+func (r *PRRepository) RemoveReviewer(ctx context.Context, prID, userID string, source domain.EventSource) error {
+	query := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, query, prID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to remove reviewer: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("reviewer assignment not found")
+		}
+		return insertEvents(ctx, tx, source.ReviewerEvent(prID, domain.EventUnassigned, userID))
+	})
 }
 
 func (r *PRRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
 	query := `
-        SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+        SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, prr.assigned_at
         FROM pull_requests pr
         INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
         WHERE prr.user_id = $1
@@ -168,7 +674,7 @@ func (r *PRRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]d
 	var prs []domain.PullRequestShort
 	for rows.Next() {
 		var pr domain.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.AssignedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
 		prs = append(prs, pr)
@@ -176,6 +682,81 @@ func (r *PRRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]d
 	return prs, nil
 }
 
+// GetReviewerLoads returns the number of OPEN PRs each user is assigned to, the time of
+// the latest assignment, personal limits, skills and seniority. Users without assignments are present in the result with zero load
+func (r *PRRepository) GetReviewerLoads(ctx context.Context, userIDs []string) (map[string]domain.ReviewerLoad, error) {
+	result := make(map[string]domain.ReviewerLoad, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+        SELECT u.user_id,
+               u.max_open_reviews,
+               u.review_weight,
+               u.skills,
+               u.seniority,
+               COUNT(CASE WHEN pr.status = 'OPEN' THEN 1 END),
+               MAX(prr.assigned_at)
+        FROM users u
+        LEFT JOIN pr_reviewers prr ON prr.user_id = u.user_id
+        LEFT JOIN pull_requests pr ON prr.pull_request_id = pr.pull_request_id
+        WHERE u.user_id = ANY($1)
+        GROUP BY u.user_id
+    `
+	rows, err := r.pool.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer loads: %w", err)
+	}
+	defer rows.Close()
+
+	for _, userID := range userIDs {
+		result[userID] = domain.ReviewerLoad{Weight: domain.DefaultReviewWeight}
+	}
+	for rows.Next() {
+		var userID string
+		var load domain.ReviewerLoad
+		if err := rows.Scan(&userID, &load.MaxOpenReviews, &load.Weight, &load.Skills, &load.Seniority, &load.OpenReviews, &load.LastAssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer load: %w", err)
+		}
+		result[userID] = load
+	}
+
+	return result, nil
+}
+
+// GetRecentPairings returns how many of the author's latest lookback PRs each reviewer was assigned to
+func (r *PRRepository) GetRecentPairings(ctx context.Context, authorID string, lookback int) (map[string]int, error) {
+	query := `
+        SELECT prr.user_id, COUNT(*)
+        FROM (
+            SELECT pull_request_id
+            FROM pull_requests
+            WHERE author_id = $1
+            ORDER BY created_at DESC
+            LIMIT $2
+        ) recent
+        INNER JOIN pr_reviewers prr ON prr.pull_request_id = recent.pull_request_id
+        GROUP BY prr.user_id
+    `
+	rows, err := r.pool.Query(ctx, query, authorID, lookback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent pairings: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan recent pairing: %w", err)
+		}
+		result[userID] = count
+	}
+	return result, nil
+}
+
 // Batch operations here
 
 func (r *PRRepository) GetOpenPRsByReviewers(ctx context.Context, userIDs []string) (map[string][]string, error) {
@@ -210,7 +791,7 @@ func (r *PRRepository) GetOpenPRsByReviewers(ctx context.Context, userIDs []stri
 	return result, nil
 }
 
-func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]string) error {
+func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]domain.ReviewerReplacement, source domain.EventSource) error {
 	if len(reassignments) == 0 {
 		return nil
 	}
@@ -227,12 +808,12 @@ func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments
 
 	updateQuery := `
         UPDATE pr_reviewers
-        SET user_id = $1
-        WHERE pull_request_id = $2 AND user_id = $3
+        SET user_id = $1, is_fallback = $2, assigned_at = NOW(), review_state = 'pending', reviewed_at = NULL
+        WHERE pull_request_id = $3 AND user_id = $4
     `
 
 	for prID, reviewerMap := range reassignments {
-		for oldReviewerID, newReviewerID := range reviewerMap {
+		for oldReviewerID, replacement := range reviewerMap {
 			// check if such reviewer already exists for this PR
 			checkQuery := `
                 SELECT EXISTS(
@@ -241,7 +822,7 @@ func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments
                 )
             `
 			var exists bool
-			err := tx.QueryRow(ctx, checkQuery, prID, newReviewerID).Scan(&exists)
+			err := tx.QueryRow(ctx, checkQuery, prID, replacement.UserID).Scan(&exists)
 			if err != nil {
 				return fmt.Errorf("failed to check existing reviewer: %w", err)
 			}
@@ -250,10 +831,13 @@ func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments
 				continue
 			}
 
-			_, err = tx.Exec(ctx, updateQuery, newReviewerID, prID, oldReviewerID)
+			_, err = tx.Exec(ctx, updateQuery, replacement.UserID, replacement.IsFallback, prID, oldReviewerID)
 			if err != nil {
 				return fmt.Errorf("failed to reassign reviewer in PR %s: %w", prID, err)
 			}
+			if err := insertEvents(ctx, tx, reassignedEvent(prID, oldReviewerID, replacement, source)); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -264,22 +848,68 @@ func (r *PRRepository) BatchReassignReviewers(ctx context.Context, reassignments
 	return nil
 }
 
-func (r *PRRepository) GetPRWithReviewersAndAuthor(ctx context.Context, prID string) (string, string, []string, error) {
+// GetPRWithReviewersAndAuthor returns data needed to find replacements for the PR reviewers
+func (r *PRRepository) GetPRWithReviewersAndAuthor(ctx context.Context, prID string) (*domain.ReassignmentTask, error) {
 	query := `
-        SELECT pr.author_id, u.team_name, COALESCE(array_agg(prr.user_id) FILTER (WHERE prr.user_id IS NOT NULL), '{}')
+        SELECT pr.author_id, u.team_name, pr.changed_files, pr.required_skills,
+               COALESCE(array_agg(prr.user_id) FILTER (WHERE prr.user_id IS NOT NULL), '{}')
         FROM pull_requests pr
         INNER JOIN users u ON pr.author_id = u.user_id
         LEFT JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
         WHERE pr.pull_request_id = $1
-        GROUP BY pr.author_id, u.team_name
+        GROUP BY pr.pull_request_id, u.team_name
     `
 
-	var authorID, teamName string
-	var reviewers []string
-	err := r.pool.QueryRow(ctx, query, prID).Scan(&authorID, &teamName, &reviewers)
+	task := &domain.ReassignmentTask{PrID: prID}
+	err := r.pool.QueryRow(ctx, query, prID).Scan(
+		&task.AuthorID,
+		&task.TeamName,
+		&task.ChangedFiles,
+		&task.RequiredSkills,
+		&task.CurrentReviewers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR with reviewers and author: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetPREvents returns the timeline of the PR, oldest first
+func (r *PRRepository) GetPREvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	query := `
+        SELECT id, pull_request_id, event_type, COALESCE(actor_id, ''), COALESCE(reviewer_id, ''),
+               COALESCE(previous_reviewer_id, ''), COALESCE(from_status, ''), COALESCE(to_status, ''),
+               COALESCE(review_state, ''), reason, created_at
+        FROM pr_events
+        WHERE pull_request_id = $1
+        ORDER BY created_at, id
+    `
+	rows, err := r.pool.Query(ctx, query, prID)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to get PR with reviewers and author: %w", err)
+		return nil, fmt.Errorf("failed to get PR events: %w", err)
 	}
+	defer rows.Close()
 
-	return authorID, teamName, reviewers, nil
+	events := []domain.PREvent{}
+	for rows.Next() {
+		var event domain.PREvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.PullRequestID,
+			&event.Type,
+			&event.ActorID,
+			&event.ReviewerID,
+			&event.PreviousReviewerID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.ReviewState,
+			&event.Reason,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan PR event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
 }
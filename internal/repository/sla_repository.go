@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SLARepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSLARepository(pool *pgxpool.Pool) *SLARepository {
+	return &SLARepository{pool: pool}
+}
+
+const breachColumns = `
+    id, pull_request_id, reviewer_id, team_name, sla_hours, assigned_at, due_at, breached_at,
+    escalated_at, COALESCE(escalated_to, ''), resolved_at
+`
+
+func scanBreach(row pgx.Row) (*domain.SLABreach, error) {
+	var breach domain.SLABreach
+	err := row.Scan(
+		&breach.ID,
+		&breach.PullRequestID,
+		&breach.ReviewerID,
+		&breach.TeamName,
+		&breach.SLAHours,
+		&breach.AssignedAt,
+		&breach.DueAt,
+		&breach.BreachedAt,
+		&breach.EscalatedAt,
+		&breach.EscalatedTo,
+		&breach.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &breach, nil
+}
+
+// GetPendingAssignments returns the reviews not submitted yet on OPEN PRs by reviewers whose team has a review SLA,
+// together with their recorded breach
+func (r *SLARepository) GetPendingAssignments(ctx context.Context) ([]domain.SLAAssignment, error) {
+	query := `
+        SELECT prr.pull_request_id, prr.user_id, prr.assigned_at, u.team_name, u.timezone,
+               s.review_sla_hours, s.sla_escalation_hours, COALESCE(lead.user_id, ''),
+               COALESCE(b.id, 0), b.due_at, b.escalated_at
+        FROM pr_reviewers prr
+        INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id AND pr.status = 'OPEN'
+        INNER JOIN users u ON u.user_id = prr.user_id
+        INNER JOIN team_settings s ON s.team_name = u.team_name AND s.review_sla_hours > 0
+        LEFT JOIN users lead ON lead.team_name = u.team_name AND lead.is_team_lead AND lead.is_active
+        LEFT JOIN sla_breaches b ON b.pull_request_id = prr.pull_request_id
+            AND b.reviewer_id = prr.user_id
+            AND b.assigned_at = prr.assigned_at
+        WHERE prr.review_state = 'pending'
+        ORDER BY prr.assigned_at
+    `
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []domain.SLAAssignment{}
+	for rows.Next() {
+		var a domain.SLAAssignment
+		if err := rows.Scan(
+			&a.PullRequestID,
+			&a.ReviewerID,
+			&a.AssignedAt,
+			&a.TeamName,
+			&a.Timezone,
+			&a.SLAHours,
+			&a.EscalationHours,
+			&a.LeadID,
+			&a.BreachID,
+			&a.DueAt,
+			&a.EscalatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, rows.Err()
+}
+
+// CreateBreach records the breach of the assignment and stores sla.breached in the outbox.
+// Returns false when the breach is already recorded, for example by another replica
+func (r *SLARepository) CreateBreach(ctx context.Context, breach *domain.SLABreach) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	query := `
+        INSERT INTO sla_breaches (pull_request_id, reviewer_id, team_name, sla_hours, assigned_at, due_at, breached_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (pull_request_id, reviewer_id, assigned_at) DO NOTHING
+        RETURNING id
+    `
+	err = tx.QueryRow(ctx, query,
+		breach.PullRequestID,
+		breach.ReviewerID,
+		breach.TeamName,
+		breach.SLAHours,
+		breach.AssignedAt,
+		breach.DueAt,
+		breach.BreachedAt,
+	).Scan(&breach.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create SLA breach: %w", err)
+	}
+
+	data := domain.WebhookSLAData{
+		DueAt:         breach.DueAt,
+		BreachID:      breach.ID,
+		PullRequestID: breach.PullRequestID,
+		ReviewerID:    breach.ReviewerID,
+		TeamName:      breach.TeamName,
+		SLAHours:      breach.SLAHours,
+	}
+	if err := insertOutbox(ctx, tx, domain.WebhookSLABreached, data); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// EscalateBreach records the escalation of an open breach to the team lead and stores sla.escalated in the outbox.
+// Returns false when the breach is already escalated or resolved
+func (r *SLARepository) EscalateBreach(ctx context.Context, breachID int64, leadID string, at time.Time) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	query := `
+        UPDATE sla_breaches
+        SET escalated_at = $1, escalated_to = $2
+        WHERE id = $3 AND escalated_at IS NULL AND resolved_at IS NULL
+        RETURNING ` + breachColumns
+	breach, err := scanBreach(tx.QueryRow(ctx, query, at, leadID, breachID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to escalate SLA breach: %w", err)
+	}
+
+	data := domain.WebhookSLAData{
+		DueAt:         breach.DueAt,
+		BreachID:      breach.ID,
+		PullRequestID: breach.PullRequestID,
+		ReviewerID:    breach.ReviewerID,
+		TeamName:      breach.TeamName,
+		SLAHours:      breach.SLAHours,
+		LeadID:        leadID,
+	}
+	if err := insertOutbox(ctx, tx, domain.WebhookSLAEscalated, data); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// ResolveBreaches closes the open breaches whose review is no longer pending: it is submitted,
+// the reviewer is removed or reassigned, or the PR is not OPEN anymore
+func (r *SLARepository) ResolveBreaches(ctx context.Context, at time.Time) (int, error) {
+	query := `
+        UPDATE sla_breaches b
+        SET resolved_at = $1
+        WHERE b.resolved_at IS NULL
+          AND NOT EXISTS (
+              SELECT 1
+              FROM pr_reviewers prr
+              INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+              WHERE prr.pull_request_id = b.pull_request_id
+                AND prr.user_id = b.reviewer_id
+                AND prr.assigned_at = b.assigned_at
+                AND prr.review_state = 'pending'
+                AND pr.status = 'OPEN'
+          )
+    `
+	result, err := r.pool.Exec(ctx, query, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve SLA breaches: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *SLARepository) ListBreaches(ctx context.Context, filter domain.SLABreachFilter) ([]domain.SLABreach, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.TeamName != "" {
+		addCondition("team_name = $%d", filter.TeamName)
+	}
+	if filter.ReviewerID != "" {
+		addCondition("reviewer_id = $%d", filter.ReviewerID)
+	}
+	switch filter.Status {
+	case domain.SLABreachOpen:
+		conditions = append(conditions, "resolved_at IS NULL")
+	case domain.SLABreachResolved:
+		conditions = append(conditions, "resolved_at IS NOT NULL")
+	}
+	if filter.Cursor > 0 {
+		addCondition("id < $%d", filter.Cursor)
+	}
+
+	query := `SELECT ` + breachColumns + ` FROM sla_breaches`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA breaches: %w", err)
+	}
+	defer rows.Close()
+
+	breaches := []domain.SLABreach{}
+	for rows.Next() {
+		breach, err := scanBreach(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan SLA breach: %w", err)
+		}
+		breaches = append(breaches, *breach)
+	}
+	return breaches, rows.Err()
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"pr-reviewer-service/internal/domain"
 
@@ -25,10 +26,14 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*domain.Stati
         SELECT 
             COUNT(*) as total,
             COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as open,
-            COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged
+            COUNT(CASE WHEN status = 'MERGED' THEN 1 END) as merged,
+            COUNT(CASE WHEN status = 'CLOSED' THEN 1 END) as closed,
+            COUNT(CASE WHEN status = 'DRAFT' THEN 1 END) as draft
         FROM pull_requests
     `
-	err := r.pool.QueryRow(ctx, prStatsQuery).Scan(&stats.TotalPRs, &stats.OpenPRs, &stats.MergedPRs)
+	err := r.pool.QueryRow(ctx, prStatsQuery).Scan(
+		&stats.TotalPRs, &stats.OpenPRs, &stats.MergedPRs, &stats.ClosedPRs, &stats.DraftPRs,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PR stats: %w", err)
 	}
@@ -93,3 +98,47 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*domain.Stati
 
 	return stats, nil
 }
+
+// GetPairingDiversity groups review assignments of PRs created by the team's members since the given time by week.
+// The row without a week is the total over the whole period
+func (r *StatisticsRepository) GetPairingDiversity(ctx context.Context, teamName string, since time.Time) (*domain.PairingDiversity, error) {
+	query := `
+        SELECT date_trunc('week', pr.created_at) AS week,
+               COUNT(*) AS assignments,
+               COUNT(DISTINCT (pr.author_id, prr.user_id)) AS distinct_pairs
+        FROM pull_requests pr
+        INNER JOIN users u ON u.user_id = pr.author_id
+        INNER JOIN pr_reviewers prr ON prr.pull_request_id = pr.pull_request_id
+        WHERE u.team_name = $1 AND pr.created_at >= $2
+        GROUP BY GROUPING SETS ((week), ())
+        ORDER BY week NULLS FIRST
+    `
+	rows, err := r.pool.Query(ctx, query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pairing diversity: %w", err)
+	}
+	defer rows.Close()
+
+	result := &domain.PairingDiversity{
+		Since:    since,
+		TeamName: teamName,
+		Points:   []domain.PairingDiversityPoint{},
+	}
+	for rows.Next() {
+		var point domain.PairingDiversityPoint
+		if err := rows.Scan(&point.PeriodStart, &point.Assignments, &point.DistinctPairs); err != nil {
+			return nil, fmt.Errorf("failed to scan pairing diversity: %w", err)
+		}
+		if point.Assignments > 0 {
+			point.Diversity = float64(point.DistinctPairs) / float64(point.Assignments)
+		}
+
+		if point.PeriodStart == nil {
+			result.Overall = point
+			continue
+		}
+		result.Points = append(result.Points, point)
+	}
+
+	return result, nil
+}
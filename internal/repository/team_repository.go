@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"pr-reviewer-service/internal/domain"
 
@@ -18,12 +21,32 @@ func NewTeamRepository(pool *pgxpool.Pool) *TeamRepository {
 	return &TeamRepository{pool: pool}
 }
 
-func (r *TeamRepository) CreateTeam(ctx context.Context, teamName string) error {
+func (r *TeamRepository) CreateTeam(ctx context.Context, teamName, reviewerStrategy string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
 	query := `INSERT INTO teams (team_name) VALUES ($1)`
-	_, err := r.pool.Exec(ctx, query, teamName)
+	_, err = tx.Exec(ctx, query, teamName)
 	if err != nil {
 		return fmt.Errorf("failed to create team: %w", err)
 	}
+
+	settingsQuery := `INSERT INTO team_settings (team_name, reviewer_strategy) VALUES ($1, $2)`
+	_, err = tx.Exec(ctx, settingsQuery, teamName, reviewerStrategy)
+	if err != nil {
+		return fmt.Errorf("failed to create team settings: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -38,9 +61,14 @@ func (r *TeamRepository) TeamExists(ctx context.Context, teamName string) (bool,
 }
 
 func (r *TeamRepository) GetTeamWithMembers(ctx context.Context, teamName string) (*domain.Team, error) {
-	teamQuery := `SELECT team_name, created_at FROM teams WHERE team_name = $1`
+	teamQuery := `
+        SELECT t.team_name, COALESCE(s.reviewer_strategy, $2), t.created_at
+        FROM teams t
+        LEFT JOIN team_settings s ON s.team_name = t.team_name
+        WHERE t.team_name = $1
+    `
 	var team domain.Team
-	err := r.pool.QueryRow(ctx, teamQuery, teamName).Scan(&team.TeamName, &team.CreatedAt)
+	err := r.pool.QueryRow(ctx, teamQuery, teamName, domain.DefaultReviewerStrategy).Scan(&team.TeamName, &team.ReviewerStrategy, &team.CreatedAt)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("team not found")
@@ -49,7 +77,7 @@ func (r *TeamRepository) GetTeamWithMembers(ctx context.Context, teamName string
 	}
 
 	membersQuery := `
-        SELECT user_id, username, is_active
+        SELECT user_id, username, is_active, skills, seniority, is_team_lead
         FROM users
         WHERE team_name = $1
         ORDER BY username
@@ -63,7 +91,7 @@ func (r *TeamRepository) GetTeamWithMembers(ctx context.Context, teamName string
 	var members []domain.TeamMember
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Skills, &member.Seniority, &member.IsTeamLead); err != nil {
 			return nil, fmt.Errorf("failed to scan member: %w", err)
 		}
 		members = append(members, member)
@@ -74,8 +102,13 @@ func (r *TeamRepository) GetTeamWithMembers(ctx context.Context, teamName string
 }
 
 func (r *TeamRepository) GetAllTeams(ctx context.Context) ([]domain.Team, error) {
-	query := `SELECT team_name, created_at FROM teams ORDER BY team_name`
-	rows, err := r.pool.Query(ctx, query)
+	query := `
+        SELECT t.team_name, COALESCE(s.reviewer_strategy, $1), t.created_at
+        FROM teams t
+        LEFT JOIN team_settings s ON s.team_name = t.team_name
+        ORDER BY t.team_name
+    `
+	rows, err := r.pool.Query(ctx, query, domain.DefaultReviewerStrategy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all teams: %w", err)
 	}
@@ -84,13 +117,13 @@ func (r *TeamRepository) GetAllTeams(ctx context.Context) ([]domain.Team, error)
 	var teams []domain.Team
 	for rows.Next() {
 		var team domain.Team
-		if err := rows.Scan(&team.TeamName, &team.CreatedAt); err != nil {
+		if err := rows.Scan(&team.TeamName, &team.ReviewerStrategy, &team.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan team: %w", err)
 		}
 
 		// Get members for each team
 		membersQuery := `
-            SELECT user_id, username, is_active
+            SELECT user_id, username, is_active, skills, seniority, is_team_lead
             FROM users
             WHERE team_name = $1
             ORDER BY username
@@ -103,7 +136,7 @@ func (r *TeamRepository) GetAllTeams(ctx context.Context) ([]domain.Team, error)
 		members := []domain.TeamMember{}
 		for memberRows.Next() {
 			var member domain.TeamMember
-			if err := memberRows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+			if err := memberRows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Skills, &member.Seniority, &member.IsTeamLead); err != nil {
 				memberRows.Close()
 				return nil, fmt.Errorf("failed to scan member: %w", err)
 			}
@@ -117,3 +150,218 @@ func (r *TeamRepository) GetAllTeams(ctx context.Context) ([]domain.Team, error)
 
 	return teams, nil
 }
+
+// GetTeamSettings returns the team's settings, falling back to defaults for unconfigured teams
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	query := `
+        SELECT t.team_name, s.reviewer_count, s.min_reviewers, s.reviewer_strategy, s.code_owners_mode,
+               s.max_open_reviews, s.min_senior_reviewers, s.senior_level, s.pairing_lookback,
+               s.merge_min_approvals, s.merge_block_on_changes_requested, s.merge_require_team_approval,
+               s.review_sla_hours, s.sla_escalation_hours, s.updated_at
+        FROM teams t
+        LEFT JOIN team_settings s ON s.team_name = t.team_name
+        WHERE t.team_name = $1
+    `
+	var (
+		name             string
+		reviewerCount    *int
+		minReviewers     *int
+		reviewerStrategy *string
+		codeOwnersMode   *string
+		maxOpenReviews   *int
+		minSeniors       *int
+		seniorLevel      *string
+		pairingLookback  *int
+		minApprovals     *int
+		blockOnChanges   *bool
+		teamApproval     *bool
+		slaHours         *int
+		escalationHours  *int
+		updatedAt        *time.Time
+	)
+	err := r.pool.QueryRow(ctx, query, teamName).Scan(
+		&name,
+		&reviewerCount,
+		&minReviewers,
+		&reviewerStrategy,
+		&codeOwnersMode,
+		&maxOpenReviews,
+		&minSeniors,
+		&seniorLevel,
+		&pairingLookback,
+		&minApprovals,
+		&blockOnChanges,
+		&teamApproval,
+		&slaHours,
+		&escalationHours,
+		&updatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("team not found")
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	settings := domain.DefaultTeamSettings(name)
+	if updatedAt == nil {
+		return settings, nil
+	}
+
+	settings.ReviewerCount = *reviewerCount
+	settings.MinReviewers = *minReviewers
+	settings.ReviewerStrategy = *reviewerStrategy
+	settings.CodeOwnersMode = *codeOwnersMode
+	settings.MaxOpenReviews = maxOpenReviews
+	settings.MinSeniorReviewers = *minSeniors
+	settings.SeniorLevel = *seniorLevel
+	settings.PairingLookback = *pairingLookback
+	settings.MergePolicy = domain.MergePolicy{
+		MinApprovals:            *minApprovals,
+		BlockOnChangesRequested: *blockOnChanges,
+		RequireTeamApproval:     *teamApproval,
+	}
+	settings.ReviewSLAHours = *slaHours
+	settings.SLAEscalationHours = *escalationHours
+	settings.UpdatedAt = updatedAt
+	return settings, nil
+}
+
+func (r *TeamRepository) UpsertTeamSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	query := `
+        INSERT INTO team_settings (
+            team_name, reviewer_count, min_reviewers, reviewer_strategy, code_owners_mode, max_open_reviews,
+            min_senior_reviewers, senior_level, pairing_lookback,
+            merge_min_approvals, merge_block_on_changes_requested, merge_require_team_approval,
+            review_sla_hours, sla_escalation_hours
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        ON CONFLICT (team_name)
+        DO UPDATE SET
+            reviewer_count = EXCLUDED.reviewer_count,
+            min_reviewers = EXCLUDED.min_reviewers,
+            reviewer_strategy = EXCLUDED.reviewer_strategy,
+            code_owners_mode = EXCLUDED.code_owners_mode,
+            max_open_reviews = EXCLUDED.max_open_reviews,
+            min_senior_reviewers = EXCLUDED.min_senior_reviewers,
+            senior_level = EXCLUDED.senior_level,
+            pairing_lookback = EXCLUDED.pairing_lookback,
+            merge_min_approvals = EXCLUDED.merge_min_approvals,
+            merge_block_on_changes_requested = EXCLUDED.merge_block_on_changes_requested,
+            merge_require_team_approval = EXCLUDED.merge_require_team_approval,
+            review_sla_hours = EXCLUDED.review_sla_hours,
+            sla_escalation_hours = EXCLUDED.sla_escalation_hours,
+            updated_at = NOW()
+    `
+	_, err := r.pool.Exec(ctx, query,
+		settings.TeamName,
+		settings.ReviewerCount,
+		settings.MinReviewers,
+		settings.ReviewerStrategy,
+		settings.CodeOwnersMode,
+		settings.MaxOpenReviews,
+		settings.MinSeniorReviewers,
+		settings.SeniorLevel,
+		settings.PairingLookback,
+		settings.MergePolicy.MinApprovals,
+		settings.MergePolicy.BlockOnChangesRequested,
+		settings.MergePolicy.RequireTeamApproval,
+		settings.ReviewSLAHours,
+		settings.SLAEscalationHours,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save team settings: %w", err)
+	}
+	return nil
+}
+
+// GetFallbackTeams returns the team's fallback pools ordered by priority
+func (r *TeamRepository) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	query := `
+        SELECT fallback_team_name
+        FROM team_fallback_pools
+        WHERE team_name = $1
+        ORDER BY priority
+    `
+	rows, err := r.pool.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	fallbackTeams := []string{}
+	for rows.Next() {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		fallbackTeams = append(fallbackTeams, fallbackTeam)
+	}
+
+	return fallbackTeams, rows.Err()
+}
+
+// SetFallbackTeams replaces the team's fallback pools, the position in the list is the priority
+func (r *TeamRepository) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM team_fallback_pools WHERE team_name = $1`, teamName); err != nil {
+		return fmt.Errorf("failed to delete fallback teams: %w", err)
+	}
+
+	insertQuery := `
+        INSERT INTO team_fallback_pools (team_name, fallback_team_name, priority)
+        VALUES ($1, $2, $3)
+    `
+	for priority, fallbackTeam := range fallbackTeams {
+		if _, err := tx.Exec(ctx, insertQuery, teamName, fallbackTeam, priority); err != nil {
+			return fmt.Errorf("failed to insert fallback team: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetTeamLead makes the member the team's only lead, an empty userID leaves the team without a lead
+func (r *TeamRepository) SetTeamLead(ctx context.Context, teamName, userID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	clearQuery := `UPDATE users SET is_team_lead = false, updated_at = NOW() WHERE team_name = $1 AND is_team_lead`
+	if _, err := tx.Exec(ctx, clearQuery, teamName); err != nil {
+		return fmt.Errorf("failed to clear team lead: %w", err)
+	}
+	if userID != "" {
+		setQuery := `UPDATE users SET is_team_lead = true, updated_at = NOW() WHERE user_id = $1 AND team_name = $2`
+		result, err := tx.Exec(ctx, setQuery, userID, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to set team lead: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("user not found in team")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
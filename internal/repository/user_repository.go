@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"pr-reviewer-service/internal/domain"
 
@@ -18,18 +20,31 @@ func NewUserRepository(pool *pgxpool.Pool) *UserRepository {
 	return &UserRepository{pool: pool}
 }
 
+// CreateOrUpdateUser upserts the user. Skills and seniority of an existing user are kept
+// when user.Skills is nil and user.Seniority is empty, a user moved to another team stops being a team lead
 func (r *UserRepository) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
 	query := `
-        INSERT INTO users (user_id, username, team_name, is_active)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO users (user_id, username, team_name, is_active, skills, seniority)
+        VALUES ($1, $2, $3, $4, COALESCE($5::TEXT[], '{}'), COALESCE(NULLIF($6, ''), $7))
         ON CONFLICT (user_id)
         DO UPDATE SET
             username = EXCLUDED.username,
             team_name = EXCLUDED.team_name,
             is_active = EXCLUDED.is_active,
+            skills = COALESCE($5::TEXT[], users.skills),
+            seniority = COALESCE(NULLIF($6, ''), users.seniority),
+            is_team_lead = users.is_team_lead AND users.team_name = EXCLUDED.team_name,
             updated_at = NOW()
     `
-	_, err := r.pool.Exec(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	_, err := r.pool.Exec(ctx, query,
+		user.UserID,
+		user.Username,
+		user.TeamName,
+		user.IsActive,
+		user.Skills,
+		user.Seniority,
+		domain.DefaultSeniority,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create/update user: %w", err)
 	}
@@ -38,7 +53,8 @@ func (r *UserRepository) CreateOrUpdateUser(ctx context.Context, user *domain.Us
 
 func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-        SELECT user_id, username, team_name, is_active, created_at, updated_at
+        SELECT user_id, username, team_name, is_active, max_open_reviews, review_weight, skills, seniority,
+               COALESCE(email, ''), notification_preference, timezone, digest_hour, created_at, updated_at
         FROM users
         WHERE user_id = $1
     `
@@ -48,6 +64,14 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*domai
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.MaxOpenReviews,
+		&user.ReviewWeight,
+		&user.Skills,
+		&user.Seniority,
+		&user.Email,
+		&user.NotificationPreference,
+		&user.Timezone,
+		&user.DigestHour,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -60,16 +84,125 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*domai
 	return &user, nil
 }
 
+// SetUserActive updates the active status, deactivation of an active user is stored in the outbox
 func (r *UserRepository) SetUserActive(ctx context.Context, userID string, isActive bool) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	var wasActive bool
+	var teamName string
+	selectQuery := `SELECT is_active, team_name FROM users WHERE user_id = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, selectQuery, userID).Scan(&wasActive, &teamName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
 	query := `
         UPDATE users
         SET is_active = $1, updated_at = NOW()
         WHERE user_id = $2
     `
-	result, err := r.pool.Exec(ctx, query, isActive, userID)
-	if err != nil {
+	if _, err := tx.Exec(ctx, query, isActive, userID); err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
+	if wasActive && !isActive {
+		data := domain.WebhookUserData{UserID: userID, TeamName: teamName}
+		if err := insertOutbox(ctx, tx, domain.WebhookUserDeactivated, data); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) SetUserCapacity(ctx context.Context, userID string, maxOpenReviews *int, reviewWeight float64) error {
+	query := `
+        UPDATE users
+        SET max_open_reviews = $1, review_weight = $2, updated_at = NOW()
+        WHERE user_id = $3
+    `
+	result, err := r.pool.Exec(ctx, query, maxOpenReviews, reviewWeight, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user capacity: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+func (r *UserRepository) SetUserSkills(ctx context.Context, userID string, skills []string) error {
+	query := `
+        UPDATE users
+        SET skills = $1, updated_at = NOW()
+        WHERE user_id = $2
+    `
+	result, err := r.pool.Exec(ctx, query, skills, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user skills: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+func (r *UserRepository) SetUserSeniority(ctx context.Context, userID, seniority string) error {
+	query := `
+        UPDATE users
+        SET seniority = $1, updated_at = NOW()
+        WHERE user_id = $2
+    `
+	result, err := r.pool.Exec(ctx, query, seniority, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user seniority: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// SetUserNotifications sets the email address and the notification preference, an empty email removes the address
+func (r *UserRepository) SetUserNotifications(ctx context.Context, userID, email, preference string) error {
+	query := `
+        UPDATE users
+        SET email = NULLIF($1, ''), notification_preference = $2, updated_at = NOW()
+        WHERE user_id = $3
+    `
+	result, err := r.pool.Exec(ctx, query, email, preference, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user notifications: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// SetUserDigestSchedule sets the timezone and the local hour of the user's daily digest
+func (r *UserRepository) SetUserDigestSchedule(ctx context.Context, userID, timezone string, digestHour int) error {
+	query := `
+        UPDATE users
+        SET timezone = $1, digest_hour = $2, updated_at = NOW()
+        WHERE user_id = $3
+    `
+	result, err := r.pool.Exec(ctx, query, timezone, digestHour, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user digest schedule: %w", err)
+	}
 	if result.RowsAffected() == 0 {
 		return fmt.Errorf("user not found")
 	}
@@ -99,9 +232,37 @@ func (r *UserRepository) GetActiveTeamMembers(ctx context.Context, teamName stri
 	return users, nil
 }
 
+func (r *UserRepository) GetActiveUsers(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return []domain.User{}, nil
+	}
+
+	query := `
+        SELECT user_id, username, team_name, is_active
+        FROM users
+        WHERE user_id = ANY($1) AND is_active = true
+    `
+	rows, err := r.pool.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 	query := `
-        SELECT user_id, username, team_name, is_active, created_at, updated_at
+        SELECT user_id, username, team_name, is_active, max_open_reviews, review_weight, skills, seniority,
+               COALESCE(email, ''), notification_preference, timezone, digest_hour, created_at, updated_at
         FROM users
         ORDER BY team_name, username
     `
@@ -119,6 +280,14 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]domain.User, error)
 			&user.Username,
 			&user.TeamName,
 			&user.IsActive,
+			&user.MaxOpenReviews,
+			&user.ReviewWeight,
+			&user.Skills,
+			&user.Seniority,
+			&user.Email,
+			&user.NotificationPreference,
+			&user.Timezone,
+			&user.DigestHour,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -136,29 +305,54 @@ func (r *UserRepository) BatchDeactivateUsers(ctx context.Context, userIDs []str
 		return []string{}, nil
 	}
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
 	query := `
         UPDATE users
         SET is_active = false, updated_at = NOW()
         WHERE user_id = ANY($1) AND team_name != 'admins' AND is_active = true
-        RETURNING user_id
+        RETURNING user_id, team_name
     `
 
-	rows, err := r.pool.Query(ctx, query, userIDs)
+	rows, err := tx.Query(ctx, query, userIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to batch deactivate users: %w", err)
 	}
-	defer rows.Close()
 
-	var deactivated []string
+	var deactivated []domain.WebhookUserData
 	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
+		var user domain.WebhookUserData
+		if err := rows.Scan(&user.UserID, &user.TeamName); err != nil {
+			rows.Close()
 			return nil, fmt.Errorf("failed to scan deactivated user: %w", err)
 		}
-		deactivated = append(deactivated, userID)
+		deactivated = append(deactivated, user)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to batch deactivate users: %w", err)
+	}
+
+	userIDs = make([]string, len(deactivated))
+	for i, user := range deactivated {
+		if err := insertOutbox(ctx, tx, domain.WebhookUserDeactivated, user); err != nil {
+			return nil, err
+		}
+		userIDs[i] = user.UserID
 	}
 
-	return deactivated, nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return userIDs, nil
 }
 
 func (r *UserRepository) GetTeamMemberIDs(ctx context.Context, teamName string) ([]string, error) {
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	query := `
+        INSERT INTO webhook_subscriptions (url, event_types, secret, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, is_active, created_at
+    `
+	err := r.pool.QueryRow(ctx, query,
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Secret,
+		subscription.CreatedBy,
+	).Scan(&subscription.ID, &subscription.IsActive, &subscription.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	query := `
+        SELECT id, url, event_types, secret, is_active, created_by, created_at
+        FROM webhook_subscriptions
+        ORDER BY id
+    `
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+// GetSubscriptionsForEvent returns active subscriptions to the event type
+func (r *WebhookRepository) GetSubscriptionsForEvent(ctx context.Context, eventType string) ([]domain.WebhookSubscription, error) {
+	query := `
+        SELECT id, url, event_types, secret, is_active, created_by, created_at
+        FROM webhook_subscriptions
+        WHERE is_active AND $1 = ANY(event_types)
+        ORDER BY id
+    `
+	rows, err := r.pool.Query(ctx, query, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+func scanSubscriptions(rows pgx.Rows) ([]domain.WebhookSubscription, error) {
+	subscriptions := []domain.WebhookSubscription{}
+	for rows.Next() {
+		var subscription domain.WebhookSubscription
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.EventTypes,
+			&subscription.Secret,
+			&subscription.IsActive,
+			&subscription.CreatedBy,
+			&subscription.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// DisableSubscription stops deliveries to the subscription, its delivery log is kept
+func (r *WebhookRepository) DisableSubscription(ctx context.Context, subscriptionID int64) error {
+	query := `UPDATE webhook_subscriptions SET is_active = false WHERE id = $1`
+	result, err := r.pool.Exec(ctx, query, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to disable webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("webhook subscription not found")
+	}
+	return nil
+}
+
+// CreateDeliveries queues the deliveries in one transaction. A delivery of an outbox event that is already
+// queued for the subscription is skipped, its ID stays 0 then
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Warn("failed to rollback transaction", "error", err)
+		}
+	}()
+
+	query := `
+        INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, redelivery_of, outbox_id)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6::BIGINT, 0))
+        ON CONFLICT (outbox_id, subscription_id) DO NOTHING
+        RETURNING id, status, next_attempt_at, created_at
+    `
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		err := tx.QueryRow(ctx, query,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			delivery.EventType,
+			[]byte(delivery.Payload),
+			delivery.RedeliveryOf,
+			delivery.OutboxID,
+		).Scan(&delivery.ID, &delivery.Status, &delivery.NextAttemptAt, &delivery.CreatedAt)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+const deliveryColumns = `
+    d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+    d.next_attempt_at, d.last_attempt_at, d.last_status_code, d.last_error, d.delivered_at,
+    d.redelivery_of, d.created_at, s.url, s.secret
+`
+
+func scanDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.DeliveredAt,
+		&delivery.RedeliveryOf,
+		&delivery.CreatedAt,
+		&delivery.URL,
+		&delivery.Secret,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	return &delivery, nil
+}
+
+// ClaimDueDeliveries locks up to limit pending deliveries that are due at now by moving their next attempt to leaseUntil.
+// Deliveries claimed by another replica are skipped, a delivery whose sender died is picked up again after the lease
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = $1
+        FROM webhook_subscriptions s
+        WHERE s.id = d.subscription_id AND d.id IN (
+            SELECT due.id
+            FROM webhook_deliveries due
+            INNER JOIN webhook_subscriptions sub ON sub.id = due.subscription_id
+            WHERE due.status = 'pending' AND due.next_attempt_at <= $2 AND sub.is_active
+            ORDER BY due.next_attempt_at, due.id
+            LIMIT $3
+            FOR UPDATE OF due SKIP LOCKED
+        )
+        RETURNING ` + deliveryColumns
+	rows, err := r.pool.Query(ctx, query, leaseUntil, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// SaveAttempt stores the result of a delivery attempt
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4,
+            last_status_code = $5, last_error = $6, delivered_at = $7
+        WHERE id = $8
+    `
+	_, err := r.pool.Exec(ctx, query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery attempt: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+        FROM webhook_deliveries d
+        INNER JOIN webhook_subscriptions s ON s.id = d.subscription_id
+        WHERE d.id = $1
+    `
+	delivery, err := scanDelivery(r.pool.QueryRow(ctx, query, deliveryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries returns deliveries matching the filter, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.SubscriptionID > 0 {
+		addCondition("d.subscription_id = $%d", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		addCondition("d.status = $%d", filter.Status)
+	}
+	if filter.EventType != "" {
+		addCondition("d.event_type = $%d", filter.EventType)
+	}
+	if filter.Cursor > 0 {
+		addCondition("d.id < $%d", filter.Cursor)
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries d INNER JOIN webhook_subscriptions s ON s.id = d.subscription_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY d.id DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type AddAbsenceRequest struct {
+	StartsAt        time.Time `json:"starts_at" validate:"required"`
+	EndsAt          time.Time `json:"ends_at" validate:"required"`
+	Reason          string    `json:"reason,omitempty" validate:"max=255"`
+	HandOverReviews bool      `json:"hand_over_reviews"`
+}
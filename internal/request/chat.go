@@ -0,0 +1,14 @@
+package request
+
+type SetTeamChatRequest struct {
+	TeamName   string `json:"team_name" validate:"required,min=1,max=255"`
+	WebhookURL string `json:"webhook_url" validate:"required,url,max=2048"`
+	// Templates override the default messages, omitted templates use the defaults
+	Templates ChatTemplatesInput `json:"templates"`
+}
+
+type ChatTemplatesInput struct {
+	Assigned   string `json:"assigned,omitempty" validate:"max=4000"`
+	Reassigned string `json:"reassigned,omitempty" validate:"max=4000"`
+	Merged     string `json:"merged,omitempty" validate:"max=4000"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type UploadCodeOwnersRequest struct {
+	Content string `json:"content" validate:"required,max=1048576"`
+}
@@ -0,0 +1,68 @@
+package request
+
+type SetIntegrationAccountRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login" validate:"required,max=255"`
+	UserID   string `json:"user_id" validate:"required,max=255"`
+}
+
+// GitHubPullRequestPayload is the part of the GitHub pull_request webhook body the service reads
+type GitHubPullRequestPayload struct {
+	Action      string                `json:"action"`
+	Number      int                   `json:"number"`
+	PullRequest GitHubPullRequest     `json:"pull_request"`
+	Repository  GitHubRepositoryShort `json:"repository"`
+}
+
+type GitHubPullRequest struct {
+	Title  string          `json:"title"`
+	User   GitHubUserShort `json:"user"`
+	Number int             `json:"number"`
+	Draft  bool            `json:"draft"`
+	Merged bool            `json:"merged"`
+}
+
+type GitHubUserShort struct {
+	Login string `json:"login"`
+}
+
+type GitHubRepositoryShort struct {
+	FullName string `json:"full_name"`
+}
+
+// GitLabMergeRequestPayload is the part of the GitLab Merge Request Hook body the service reads
+type GitLabMergeRequestPayload struct {
+	ObjectKind       string                   `json:"object_kind"`
+	User             GitLabUserShort          `json:"user"`
+	Project          GitLabProjectShort       `json:"project"`
+	ObjectAttributes GitLabMergeRequest       `json:"object_attributes"`
+	Changes          GitLabMergeRequestChange `json:"changes"`
+}
+
+type GitLabUserShort struct {
+	Username string `json:"username"`
+}
+
+type GitLabProjectShort struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	ID                int64  `json:"id"`
+}
+
+type GitLabMergeRequest struct {
+	Action         string `json:"action"`
+	Title          string `json:"title"`
+	IID            int    `json:"iid"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+}
+
+// GitLabMergeRequestChange lists the changed attributes, older GitLab versions report draft as work_in_progress
+type GitLabMergeRequestChange struct {
+	Draft          *GitLabBoolChange `json:"draft"`
+	WorkInProgress *GitLabBoolChange `json:"work_in_progress"`
+}
+
+type GitLabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
@@ -1,16 +1,49 @@
 package request
 
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id" validate:"required,min=1,max=255"`
-	PullRequestName string `json:"pull_request_name" validate:"required,min=1,max=500"`
-	AuthorID        string `json:"author_id" validate:"required,min=1,max=255"`
+	PullRequestID   string   `json:"pull_request_id" validate:"required,min=1,max=255"`
+	PullRequestName string   `json:"pull_request_name" validate:"required,min=1,max=500"`
+	AuthorID        string   `json:"author_id" validate:"required,min=1,max=255"`
+	ChangedFiles    []string `json:"changed_files,omitempty" validate:"omitempty,max=1000,dive,required,max=4096"`
+	RequiredSkills  []string `json:"required_skills,omitempty" validate:"omitempty,max=50,dive,required,max=64"`
+	// Draft PRs get reviewers only when they are marked ready
+	Draft bool `json:"draft,omitempty"`
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
 }
 
+type ClosePRRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+}
+
+type ReopenPRRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+}
+
+type MarkReadyRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+}
+
 type ReassignPRRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
 	OldUserID     string `json:"old_user_id" validate:"required,min=1,max=255"`
+	// NewUserID is the chosen replacement, when empty it is picked by the team's strategy
+	NewUserID string `json:"new_user_id,omitempty" validate:"omitempty,min=1,max=255"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+	State         string `json:"state" validate:"required,oneof=pending approved changes_requested commented"`
+}
+
+type AddReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+	UserID        string `json:"user_id" validate:"required,min=1,max=255"`
+}
+
+type RemoveReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=255"`
+	UserID        string `json:"user_id" validate:"required,min=1,max=255"`
 }
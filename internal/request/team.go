@@ -1,16 +1,54 @@
 package request
 
 type CreateTeamRequest struct {
-	TeamName string            `json:"team_name" validate:"required,min=1,max=255"`
-	Members  []TeamMemberInput `json:"members" validate:"required,min=1,dive"`
+	TeamName         string            `json:"team_name" validate:"required,min=1,max=255"`
+	ReviewerStrategy string            `json:"reviewer_strategy,omitempty" validate:"omitempty,oneof=random round_robin least_loaded weighted"`
+	Members          []TeamMemberInput `json:"members" validate:"required,min=1,dive"`
 }
 
 type TeamMemberInput struct {
-	UserID   string `json:"user_id" validate:"required,min=1,max=255"`
-	Username string `json:"username" validate:"required,min=1,max=255"`
-	IsActive bool   `json:"is_active"`
+	UserID    string   `json:"user_id" validate:"required,min=1,max=255"`
+	Username  string   `json:"username" validate:"required,min=1,max=255"`
+	Skills    []string `json:"skills,omitempty" validate:"omitempty,max=50,dive,required,max=64"`
+	Seniority string   `json:"seniority,omitempty" validate:"omitempty,oneof=junior middle senior lead"`
+	IsActive  bool     `json:"is_active"`
 }
 
 type BatchDeactivateTeamRequest struct {
 	TeamName string `json:"team_name" validate:"required,min=1,max=255"`
 }
+
+type SetReviewerStrategyRequest struct {
+	TeamName         string `json:"team_name" validate:"required,min=1,max=255"`
+	ReviewerStrategy string `json:"reviewer_strategy" validate:"required,oneof=random round_robin least_loaded weighted"`
+}
+
+type SetFallbackTeamsRequest struct {
+	TeamName      string   `json:"team_name" validate:"required,min=1,max=255"`
+	FallbackTeams []string `json:"fallback_teams" validate:"max=10,dive,required,min=1,max=255"`
+}
+
+type UpdateTeamSettingsRequest struct {
+	MaxOpenReviews     *int   `json:"max_open_reviews,omitempty" validate:"omitempty,min=1"`
+	PairingLookback    *int   `json:"pairing_lookback,omitempty" validate:"omitempty,min=0,max=100"`
+	TeamName           string `json:"team_name" validate:"required,min=1,max=255"`
+	ReviewerStrategy   string `json:"reviewer_strategy" validate:"required,oneof=random round_robin least_loaded weighted"`
+	CodeOwnersMode     string `json:"code_owners_mode,omitempty" validate:"omitempty,oneof=off prefer require"`
+	SeniorLevel        string `json:"senior_level,omitempty" validate:"omitempty,oneof=junior middle senior lead"`
+	ReviewerCount      int    `json:"reviewer_count" validate:"min=0,max=10"`
+	MinReviewers       int    `json:"min_reviewers" validate:"min=0,max=10"`
+	MinSeniorReviewers int    `json:"min_senior_reviewers" validate:"min=0,max=10"`
+	// Merge policy, the defaults allow merging unconditionally
+	MergeMinApprovals            int  `json:"merge_min_approvals" validate:"min=0,max=10"`
+	MergeBlockOnChangesRequested bool `json:"merge_block_on_changes_requested"`
+	MergeRequireTeamApproval     bool `json:"merge_require_team_approval"`
+	// Review SLA in working hours, 0 disables it
+	ReviewSLAHours     int  `json:"review_sla_hours" validate:"min=0,max=1000"`
+	SLAEscalationHours *int `json:"sla_escalation_hours,omitempty" validate:"omitempty,min=0,max=1000"`
+}
+
+type SetTeamLeadRequest struct {
+	TeamName string `json:"team_name" validate:"required,min=1,max=255"`
+	// UserID is empty to remove the lead
+	UserID string `json:"user_id,omitempty" validate:"omitempty,max=255"`
+}
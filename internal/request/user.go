@@ -5,6 +5,36 @@ type SetUserActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+type SetUserCapacityRequest struct {
+	MaxOpenReviews *int    `json:"max_open_reviews,omitempty" validate:"omitempty,min=1"`
+	UserID         string  `json:"user_id" validate:"required,min=1,max=255"`
+	ReviewWeight   float64 `json:"review_weight" validate:"required,gt=0,lte=100"`
+}
+
+type SetUserSkillsRequest struct {
+	UserID string   `json:"user_id" validate:"required,min=1,max=255"`
+	Skills []string `json:"skills" validate:"max=50,dive,required,max=64"`
+}
+
+type SetUserSeniorityRequest struct {
+	UserID    string `json:"user_id" validate:"required,min=1,max=255"`
+	Seniority string `json:"seniority" validate:"required,oneof=junior middle senior lead"`
+}
+
+type SetUserNotificationsRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1,max=255"`
+	// Email is removed when empty
+	Email                  string `json:"email" validate:"omitempty,email,max=320"`
+	NotificationPreference string `json:"notification_preference" validate:"required,oneof=immediate digest off"`
+}
+
+type SetUserDigestScheduleRequest struct {
+	UserID   string `json:"user_id" validate:"required,min=1,max=255"`
+	Timezone string `json:"timezone" validate:"required,max=64"`
+	// DigestHour is the hour of the user's local time, nil is rejected so that 0 can be set
+	DigestHour *int `json:"digest_hour" validate:"required,min=0,max=23"`
+}
+
 type BatchDeactivateUsersRequest struct {
 	UserIDs []string `json:"user_ids" validate:"required,min=1,dive,required,min=1,max=255"`
 }
@@ -0,0 +1,12 @@
+package request
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	// Secret signs the payloads, a random one is generated when empty
+	Secret string `json:"secret,omitempty" validate:"max=255"`
+}
+
+type RedeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id" validate:"required,gt=0"`
+}
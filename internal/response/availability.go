@@ -0,0 +1,12 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type AbsenceResponse struct {
+	Absence dto.AbsenceDTO `json:"absence"`
+}
+
+type AbsencesResponse struct {
+	UserID   string           `json:"user_id"`
+	Absences []dto.AbsenceDTO `json:"absences"`
+}
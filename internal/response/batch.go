@@ -1,16 +1,21 @@
 package response
 
 type BatchDeactivateResponse struct {
-	DeactivatedUsers   []string             `json:"deactivated_users"`
-	ReassignedPRs      []PRReassignmentInfo `json:"reassigned_prs"`
-	SkippedUsers       []string             `json:"skipped_users"`
-	TotalDeactivated   int                  `json:"total_deactivated"`
-	TotalPRsReassigned int                  `json:"total_prs_reassigned"`
-	ProcessingTimeMs   int64                `json:"processing_time_ms"`
+	DeactivatedUsers     []string             `json:"deactivated_users"`
+	ReassignedPRs        []PRReassignmentInfo `json:"reassigned_prs"`
+	SkippedUsers         []string             `json:"skipped_users"`
+	UnderstaffedPRs      []string             `json:"understaffed_prs"`
+	CapacityExhaustedPRs []string             `json:"capacity_exhausted_prs"`
+	SeniorMissingPRs     []string             `json:"senior_missing_prs"`
+	TotalDeactivated     int                  `json:"total_deactivated"`
+	TotalPRsReassigned   int                  `json:"total_prs_reassigned"`
+	ProcessingTimeMs     int64                `json:"processing_time_ms"`
+	Seed                 int64                `json:"seed"`
 }
 
 type PRReassignmentInfo struct {
-	PullRequestID string   `json:"pull_request_id"`
-	OldReviewers  []string `json:"old_reviewers"`
-	NewReviewers  []string `json:"new_reviewers"`
+	PullRequestID     string   `json:"pull_request_id"`
+	OldReviewers      []string `json:"old_reviewers"`
+	NewReviewers      []string `json:"new_reviewers"`
+	FallbackReviewers []string `json:"fallback_reviewers"`
 }
@@ -0,0 +1,7 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type TeamChatSettingsResponse struct {
+	Chat dto.TeamChatSettingsDTO `json:"chat"`
+}
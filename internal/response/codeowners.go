@@ -0,0 +1,8 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type CodeOwnersResponse struct {
+	Rules []dto.CodeOwnerRuleDTO `json:"rules"`
+	Count int                    `json:"count"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type DigestPreviewResponse struct {
+	Digest   dto.DigestDTO `json:"digest"`
+	Subject  string        `json:"subject"`
+	HTML     string        `json:"html"`
+	WillSend bool          `json:"will_send"`
+	// Reason explains why the digest would not be sent
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type IntegrationAccountResponse struct {
+	Account dto.IntegrationAccountDTO `json:"account"`
+}
+
+type IntegrationAccountsResponse struct {
+	Accounts []dto.IntegrationAccountDTO `json:"accounts"`
+}
+
+type IngestResponse struct {
+	// Outcome is applied, ignored or duplicate
+	Outcome       string `json:"outcome"`
+	Event         string `json:"event"`
+	Action        string `json:"action,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	// PullRequest is the state after an applied event
+	PullRequest *dto.PullRequestDTO `json:"pull_request,omitempty"`
+}
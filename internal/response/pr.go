@@ -8,6 +8,17 @@ type PRResponse struct {
 	PR dto.PullRequestDTO `json:"pr"`
 }
 
+type PRListResponse struct {
+	PullRequests []dto.PullRequestDTO `json:"pull_requests"`
+	// NextCursor is passed as cursor to get the next page, it is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type PRTimelineResponse struct {
+	PullRequestID string           `json:"pull_request_id"`
+	Events        []dto.PREventDTO `json:"events"`
+}
+
 type ReassignResponse struct {
 	ReplacedBy string             `json:"replaced_by"`
 	PR         dto.PullRequestDTO `json:"pr"`
@@ -17,3 +28,19 @@ type UserReviewsResponse struct {
 	UserID       string                    `json:"user_id"`
 	PullRequests []dto.PullRequestShortDTO `json:"pull_requests"`
 }
+
+type AssignmentExplanationResponse struct {
+	PullRequestID     string                     `json:"pull_request_id"`
+	Strategy          string                     `json:"strategy"`
+	Candidates        []dto.ReviewerCandidateDTO `json:"candidates"`
+	RecordedReviewers []string                   `json:"recorded_reviewers"`
+	ReplayedReviewers []string                   `json:"replayed_reviewers"`
+	RequiredSkills    []string                   `json:"required_skills"`
+	SeniorLevel       string                     `json:"senior_level,omitempty"`
+	SeniorsNeeded     int                        `json:"seniors_needed"`
+	Seed              int64                      `json:"seed"`
+	RequestedCount    int                        `json:"requested_count"`
+	Reproducible      bool                       `json:"reproducible"`
+	// Reassignments are the later replacements picked by a strategy, oldest first
+	Reassignments []dto.ReassignmentExplanationDTO `json:"reassignments"`
+}
@@ -0,0 +1,9 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type SLABreachesResponse struct {
+	Breaches []dto.SLABreachDTO `json:"breaches"`
+	// NextCursor is passed as cursor to get the next page, it is omitted on the last page
+	NextCursor int64 `json:"next_cursor,omitempty"`
+}
@@ -1,13 +1,26 @@
 package response
 
-import "pr-reviewer-service/internal/dto"
+import (
+	"time"
+
+	"pr-reviewer-service/internal/dto"
+)
 
 type StatisticsResponse struct {
 	UserAssignments []dto.UserAssignmentStatDTO `json:"user_assignments"`
 	TotalPRs        int                         `json:"total_prs"`
 	OpenPRs         int                         `json:"open_prs"`
 	MergedPRs       int                         `json:"merged_prs"`
+	ClosedPRs       int                         `json:"closed_prs"`
+	DraftPRs        int                         `json:"draft_prs"`
 	TotalUsers      int                         `json:"total_users"`
 	ActiveUsers     int                         `json:"active_users"`
 	TotalTeams      int                         `json:"total_teams"`
 }
+
+type PairingDiversityResponse struct {
+	Since    time.Time                      `json:"since"`
+	TeamName string                         `json:"team_name"`
+	Overall  dto.PairingDiversityPointDTO   `json:"overall"`
+	Points   []dto.PairingDiversityPointDTO `json:"points"`
+}
@@ -10,3 +10,12 @@ type AllTeamsResponse struct {
 	Teams []dto.TeamDTO `json:"teams"`
 	Count int           `json:"count"`
 }
+
+type TeamSettingsResponse struct {
+	Settings dto.TeamSettingsDTO `json:"settings"`
+}
+
+type FallbackTeamsResponse struct {
+	TeamName      string   `json:"team_name"`
+	FallbackTeams []string `json:"fallback_teams"`
+}
@@ -6,6 +6,11 @@ type UserResponse struct {
 	User dto.UserDTO `json:"user"`
 }
 
+type UserSkillsResponse struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
 type AllUsersResponse struct {
 	Users []dto.UserDTO `json:"users"`
 	Count int           `json:"count"`
@@ -0,0 +1,23 @@
+package response
+
+import "pr-reviewer-service/internal/dto"
+
+type WebhookCreatedResponse struct {
+	Webhook dto.WebhookSubscriptionDTO `json:"webhook"`
+	// Secret is shown only once, receivers use it to verify X-Webhook-Signature-256
+	Secret string `json:"secret"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []dto.WebhookSubscriptionDTO `json:"webhooks"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []dto.WebhookDeliveryDTO `json:"deliveries"`
+	// NextCursor is passed as cursor to get the next page, it is omitted on the last page
+	NextCursor int64 `json:"next_cursor,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	Delivery dto.WebhookDeliveryDTO `json:"delivery"`
+}
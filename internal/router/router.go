@@ -14,6 +14,13 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+const (
+	// requestTimeout is the 300ms SLI of the API
+	requestTimeout = 300 * time.Millisecond
+	// auditExportTimeout limits streamed audit log exports
+	auditExportTimeout = 5 * time.Minute
+)
+
 func SetupRouter(
 	authHandler *handler.AuthHandler,
 	teamHandler *handler.TeamHandler,
@@ -21,7 +28,15 @@ func SetupRouter(
 	prHandler *handler.PRHandler,
 	healthHandler *handler.HealthHandler,
 	statisticsHandler *handler.StatisticsHandler,
+	codeOwnersHandler *handler.CodeOwnersHandler,
+	auditHandler *handler.AuditHandler,
+	webhookHandler *handler.WebhookHandler,
+	integrationHandler *handler.IntegrationHandler,
+	chatHandler *handler.ChatHandler,
+	digestHandler *handler.DigestHandler,
+	slaHandler *handler.SLAHandler,
 	authService middleware.AuthService,
+	auditRecorder middleware.AuditRecorder,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -30,45 +45,108 @@ func SetupRouter(
 	r.Use(chimiddleware.RealIP)
 	r.Use(chimiddleware.Recoverer)
 	r.Use(middleware2.LoggingMiddleware)
-	r.Use(chimiddleware.Timeout(300 * time.Millisecond)) // 300ms SLI
-
-	// Swagger documentation
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
-	// Public endpoints
-	r.Head("/health", healthHandler.Health)
-	r.Post("/auth/login", authHandler.Login)
-
-	// Protected endpoints (require JWT authentication)
+	// Regular endpoints have to answer within the SLI
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.AuthMiddleware(authService))
-
-		// Team endpoints
-		r.Post("/team/add", teamHandler.CreateTeam)
-		r.Get("/team/get", teamHandler.GetTeam)
-
-		// User endpoints
-		r.Get("/users/getReview", userHandler.GetReview)
-
-		// Pull Request endpoints
-		r.Post("/pullRequest/create", prHandler.CreatePR)
-		r.Post("/pullRequest/merge", prHandler.MergePR)
-		r.Post("/pullRequest/reassign", prHandler.ReassignReviewer)
+		r.Use(chimiddleware.Timeout(requestTimeout))
+
+		// Swagger documentation
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
+
+		// Public endpoints
+		r.Head("/health", healthHandler.Health)
+		r.Post("/auth/login", authHandler.Login)
+
+		// Code hosting webhooks, authenticated by their signatures
+		r.Post("/integrations/github/webhook", integrationHandler.GitHubWebhook)
+		r.Post("/integrations/gitlab/webhook", integrationHandler.GitLabWebhook)
+
+		// Protected endpoints (require JWT authentication)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(authService))
+			r.Use(middleware.AuditMiddleware(auditRecorder))
+
+			// Team endpoints
+			r.Post("/team/add", teamHandler.CreateTeam)
+			r.Get("/team/get", teamHandler.GetTeam)
+
+			// User endpoints
+			r.Get("/users/getReview", userHandler.GetReview)
+			r.Get("/users/skills", userHandler.GetSkills)
+			r.Post("/users/availability", userHandler.AddAbsence)
+			r.Get("/users/availability", userHandler.GetAbsences)
+			r.Delete("/users/availability", userHandler.DeleteAbsence)
+
+			// Pull Request endpoints
+			r.Get("/pullRequest/get", prHandler.GetPR)
+			r.Get("/pullRequest/list", prHandler.ListPRs)
+			r.Get("/pullRequest/timeline", prHandler.GetTimeline)
+			r.Post("/pullRequest/create", prHandler.CreatePR)
+			r.Post("/pullRequest/merge", prHandler.MergePR)
+			r.Post("/pullRequest/close", prHandler.ClosePR)
+			r.Post("/pullRequest/reopen", prHandler.ReopenPR)
+			r.Post("/pullRequest/markReady", prHandler.MarkReady)
+			r.Post("/pullRequest/review", prHandler.SubmitReview)
+			r.Post("/pullRequest/reassign", prHandler.ReassignReviewer)
+			r.Post("/pullRequest/addReviewer", prHandler.AddReviewer)
+			r.Post("/pullRequest/removeReviewer", prHandler.RemoveReviewer)
+
+			// Code owners endpoints
+			r.Get("/codeowners", codeOwnersHandler.GetRules)
+		})
+
+		// Admin-only endpoints (require JWT + admin team membership)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(authService))
+			// rejected attempts of non-admins are recorded too
+			r.Use(middleware.AuditMiddleware(auditRecorder))
+			r.Use(middleware.AdminMiddleware())
+
+			r.Post("/users/setIsActive", userHandler.SetIsActive)
+			r.Post("/users/setCapacity", userHandler.SetCapacity)
+			r.Post("/users/setSkills", userHandler.SetSkills)
+			r.Post("/users/setSeniority", userHandler.SetSeniority)
+			r.Post("/users/setNotifications", userHandler.SetNotifications)
+			r.Post("/users/setDigestSchedule", userHandler.SetDigestSchedule)
+			r.Post("/users/batchDeactivateTeam", userHandler.BatchDeactivateTeam)
+			r.Post("/users/batchDeactivateUsers", userHandler.BatchDeactivateUsers)
+			r.Post("/team/setReviewerStrategy", teamHandler.SetReviewerStrategy)
+			r.Get("/team/settings", teamHandler.GetSettings)
+			r.Put("/team/settings", teamHandler.UpdateSettings)
+			r.Post("/team/setLead", teamHandler.SetLead)
+			r.Get("/team/fallbacks", teamHandler.GetFallbackTeams)
+			r.Put("/team/fallbacks", teamHandler.SetFallbackTeams)
+			r.Get("/team/chat", chatHandler.GetSettings)
+			r.Put("/team/chat", chatHandler.SetSettings)
+			r.Delete("/team/chat", chatHandler.DeleteSettings)
+			r.Put("/codeowners", codeOwnersHandler.UploadRules)
+			r.Get("/admin/users", userHandler.ListAllUsers)
+			r.Get("/admin/users/digest", digestHandler.PreviewDigest)
+			r.Get("/admin/teams", teamHandler.ListAllTeams)
+			r.Get("/admin/pullRequest/explain", prHandler.ExplainAssignment)
+			r.Post("/admin/webhooks", webhookHandler.CreateSubscription)
+			r.Get("/admin/webhooks", webhookHandler.ListSubscriptions)
+			r.Delete("/admin/webhooks", webhookHandler.DisableSubscription)
+			r.Get("/admin/webhooks/deliveries", webhookHandler.ListDeliveries)
+			r.Post("/admin/webhooks/deliveries/redeliver", webhookHandler.Redeliver)
+			r.Get("/admin/sla/breaches", slaHandler.ListBreaches)
+			r.Get("/admin/integrations/accounts", integrationHandler.ListAccounts)
+			r.Put("/admin/integrations/accounts", integrationHandler.SetAccount)
+
+			// Statistics endpoint
+			r.Get("/statistics", statisticsHandler.GetStatistics)
+			r.Get("/statistics/pairingDiversity", statisticsHandler.GetPairingDiversity)
+		})
 	})
 
-	// Admin-only endpoints (require JWT + admin team membership)
+	// Audit log exports stream every matching entry and get their own deadline instead of the SLI
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.AuthMiddleware(authService))
+		r.Use(middleware.AuditMiddleware(auditRecorder))
 		r.Use(middleware.AdminMiddleware())
+		r.Use(middleware.ExportTimeout(requestTimeout, auditExportTimeout))
 
-		r.Post("/users/setIsActive", userHandler.SetIsActive)
-		r.Post("/users/batchDeactivateTeam", userHandler.BatchDeactivateTeam)
-		r.Post("/users/batchDeactivateUsers", userHandler.BatchDeactivateUsers)
-		r.Get("/admin/users", userHandler.ListAllUsers)
-		r.Get("/admin/teams", teamHandler.ListAllTeams)
-
-		// Statistics endpoint
-		r.Get("/statistics", statisticsHandler.GetStatistics)
+		r.Get("/admin/audit", auditHandler.GetAuditLog)
 	})
 
 	return r
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type AuditService struct {
+	repo AuditRepository
+}
+
+func NewAuditService(repo AuditRepository) *AuditService {
+	return &AuditService{
+		repo: repo,
+	}
+}
+
+// Record stores the entry written by the audit middleware
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := s.repo.CreateEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListEntries returns one page of the audit log matching the filter, newest first
+func (s *AuditService) ListEntries(ctx context.Context, filter domain.AuditFilter) (*domain.AuditPage, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = domain.DefaultAuditListLimit
+	case filter.Limit < 0 || filter.Limit > domain.MaxAuditListLimit:
+		return nil, fmt.Errorf("limit must be between 1 and %d: %w", domain.MaxAuditListLimit, my_errors.ErrInvalidInput)
+	}
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, err
+	}
+
+	// one extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	entries, err := s.repo.ListEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	page := &domain.AuditPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextCursor = page.Entries[limit-1].ID
+	}
+	return page, nil
+}
+
+// ExportEntries passes every entry matching the filter to write, newest first.
+// The limit and the cursor of the filter are ignored
+func (s *AuditService) ExportEntries(ctx context.Context, filter domain.AuditFilter, write func(domain.AuditEntry) error) error {
+	if err := validateAuditFilter(filter); err != nil {
+		return err
+	}
+
+	filter.Cursor = 0
+	filter.Limit = domain.MaxAuditListLimit
+	for {
+		entries, err := s.repo.ListEntries(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit entries: %w", err)
+		}
+		for _, entry := range entries {
+			if err := write(entry); err != nil {
+				return err
+			}
+		}
+		if len(entries) < filter.Limit {
+			return nil
+		}
+		filter.Cursor = entries[len(entries)-1].ID
+	}
+}
+
+func validateAuditFilter(filter domain.AuditFilter) error {
+	switch filter.Outcome {
+	case "", domain.AuditOutcomeSuccess, domain.AuditOutcomeFailure:
+	default:
+		return fmt.Errorf("outcome must be success or failure: %w", my_errors.ErrInvalidInput)
+	}
+	if filter.Cursor < 0 {
+		return fmt.Errorf("cursor must be positive: %w", my_errors.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// chatTemplateFuncs are available in chat templates: mention renders @username, mentions joins mentions of several users
+var chatTemplateFuncs = template.FuncMap{
+	"mention": mention,
+	"mentions": func(users []domain.ChatUser) string {
+		result := make([]string, len(users))
+		for i, user := range users {
+			result[i] = mention(user)
+		}
+		return strings.Join(result, ", ")
+	},
+}
+
+func mention(user domain.ChatUser) string {
+	return "@" + user.Username
+}
+
+// ChatService posts notifications about the team's PRs to the team's chat through an incoming webhook
+// compatible with Slack and Mattermost
+type ChatService struct {
+	repo     ChatRepository
+	teamRepo TeamRepositoryForStatistics
+	userRepo UserRepositoryForPR
+	prRepo   PRReader
+	client   *http.Client
+}
+
+func NewChatService(
+	repo ChatRepository,
+	teamRepo TeamRepositoryForStatistics,
+	userRepo UserRepositoryForPR,
+	prRepo PRReader,
+	client *http.Client,
+) *ChatService {
+	return &ChatService{
+		repo:     repo,
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		client:   client,
+	}
+}
+
+func (s *ChatService) GetSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	settings, err := s.repo.GetSettings(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrChatNotConfigured)
+	}
+	return settings, nil
+}
+
+// SetSettings replaces the team's chat settings, templates are checked by rendering a sample message
+func (s *ChatService) SetSettings(ctx context.Context, settings *domain.TeamChatSettings) (*domain.TeamChatSettings, error) {
+	if settings.TeamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetTeam, settings.TeamName)
+
+	parsed, err := url.Parse(settings.WebhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("webhook_url must be an absolute http or https URL: %w", my_errors.ErrInvalidInput)
+	}
+	for _, eventType := range []string{domain.WebhookPRCreated, domain.WebhookReviewerAssigned, domain.WebhookReviewerReassigned, domain.WebhookPRMerged} {
+		text, _ := settings.Templates.ForEvent(eventType)
+		if _, err := renderChatMessage(text, sampleChatMessage(eventType)); err != nil {
+			return nil, fmt.Errorf("template of %s: %v: %w", eventType, err, my_errors.ErrInvalidInput)
+		}
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, settings.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+	before, err := s.repo.FindSettings(ctx, settings.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat settings: %w", err)
+	}
+
+	if err := s.repo.UpsertSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save chat settings: %w", err)
+	}
+	audit.Change(ctx, before, settings)
+
+	return settings, nil
+}
+
+// DeleteSettings stops notifying the team's chat
+func (s *ChatService) DeleteSettings(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetTeam, teamName)
+	if err := s.repo.DeleteSettings(ctx, teamName); err != nil {
+		return fmt.Errorf("%w", my_errors.ErrChatNotConfigured)
+	}
+	return nil
+}
+
+// Publish queues a post about the initial reviewers, an added reviewer, a reassignment or a merge for the chat of the PR author's team.
+// A post that cannot be built is logged and dropped, a failure to queue it holds back the outbox like webhook deliveries.
+// A message published twice is posted once
+func (s *ChatService) Publish(ctx context.Context, message domain.OutboxMessage) error {
+	if _, ok := (domain.ChatTemplates{}).ForEvent(message.EventType); !ok {
+		return nil
+	}
+	post, err := s.buildPost(ctx, message)
+	if err != nil {
+		slog.Warn("failed to build chat notification", "event_type", message.EventType, "outbox_id", message.ID, "error", err)
+		return nil
+	}
+	if post == nil {
+		return nil
+	}
+	if err := s.repo.CreatePost(ctx, post); err != nil {
+		return fmt.Errorf("failed to queue %s chat post: %w", message.EventType, err)
+	}
+	return nil
+}
+
+// buildPost renders the message for the author's team, it returns nil when there is nothing to post
+func (s *ChatService) buildPost(ctx context.Context, message domain.OutboxMessage) (*domain.ChatPost, error) {
+	chatMessage := domain.ChatMessage{Event: message.EventType}
+	var authorID string
+	var reviewerIDs []string
+	var previousReviewerID string
+
+	if message.EventType == domain.WebhookReviewerAssigned || message.EventType == domain.WebhookReviewerReassigned {
+		var data domain.WebhookReviewerData
+		if err := json.Unmarshal(message.Payload, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		// the initial reviewers are already announced by pr.created or pr.ready, only later additions are posted
+		if message.EventType == domain.WebhookReviewerAssigned && data.Reason == domain.EventReasonAssignment {
+			return nil, nil
+		}
+		pr, err := s.prRepo.GetPRByID(ctx, data.PullRequestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PR %s: %w", data.PullRequestID, err)
+		}
+		chatMessage.PullRequest = domain.ChatPullRequest{ID: pr.PullRequestID, Name: pr.PullRequestName}
+		authorID = pr.AuthorID
+		reviewerIDs = []string{data.ReviewerID}
+		previousReviewerID = data.PreviousReviewerID
+	} else {
+		var data domain.WebhookPRData
+		if err := json.Unmarshal(message.Payload, &data); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		// drafts are announced once they are ready and have reviewers
+		if message.EventType != domain.WebhookPRMerged && (data.PullRequest.Status != domain.StatusOpen || len(data.Reviewers) == 0) {
+			return nil, nil
+		}
+		chatMessage.PullRequest = domain.ChatPullRequest{ID: data.PullRequest.PullRequestID, Name: data.PullRequest.PullRequestName}
+		authorID = data.PullRequest.AuthorID
+		reviewerIDs = data.Reviewers
+	}
+
+	author, err := s.userRepo.GetUserByID(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author %s: %w", authorID, err)
+	}
+	settings, err := s.repo.FindSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat settings: %w", err)
+	}
+	if settings == nil {
+		return nil, nil
+	}
+
+	chatMessage.Author = domain.ChatUser{UserID: author.UserID, Username: author.Username}
+	chatMessage.Reviewers = make([]domain.ChatUser, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		chatMessage.Reviewers[i] = s.chatUser(ctx, reviewerID)
+	}
+	if previousReviewerID != "" {
+		previous := s.chatUser(ctx, previousReviewerID)
+		chatMessage.PreviousReviewer = &previous
+	}
+
+	text, _ := settings.Templates.ForEvent(message.EventType)
+	rendered, err := renderChatMessage(text, chatMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s template of team %s: %w", message.EventType, settings.TeamName, err)
+	}
+	return &domain.ChatPost{TeamName: settings.TeamName, EventType: message.EventType, Text: rendered, OutboxID: message.ID}, nil
+}
+
+// DeliverDue sends up to limit chat posts whose attempt is due and returns how many were attempted.
+// Failed attempts are retried with exponential backoff until domain.MaxDeliveryAttempts is reached
+func (s *ChatService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	posts, err := s.repo.ClaimDuePosts(ctx, now, now.Add(domain.DeliveryLease(limit, s.client.Timeout)), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim chat posts: %w", err)
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		sendErr := s.send(ctx, post.WebhookURL, post.Text)
+
+		attemptedAt := time.Now()
+		post.Attempts++
+		post.LastAttemptAt = &attemptedAt
+		switch {
+		case sendErr == nil:
+			post.Status = domain.DeliveryDelivered
+			post.DeliveredAt = &attemptedAt
+			post.LastError = ""
+		case post.Attempts >= domain.MaxDeliveryAttempts:
+			post.Status = domain.DeliveryFailed
+			post.LastError = sendErr.Error()
+		default:
+			post.NextAttemptAt = attemptedAt.Add(domain.DeliveryBackoff(post.Attempts))
+			post.LastError = sendErr.Error()
+		}
+		if sendErr != nil {
+			slog.Warn("failed to send chat post", "team_name", post.TeamName, "post_id", post.ID, "attempts", post.Attempts, "error", sendErr)
+		}
+
+		if err := s.repo.SavePostAttempt(ctx, post); err != nil {
+			return i, fmt.Errorf("failed to save chat post attempt: %w", err)
+		}
+	}
+	return len(posts), nil
+}
+
+// chatUser falls back to the user ID when the user cannot be loaded
+func (s *ChatService) chatUser(ctx context.Context, userID string) domain.ChatUser {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.ChatUser{UserID: userID, Username: userID}
+	}
+	return domain.ChatUser{UserID: user.UserID, Username: user.Username}
+}
+
+// send posts the message in the {"text": ...} format of incoming webhooks, any non-2xx response is an error
+func (s *ChatService) send(ctx context.Context, webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("chat responded with %d: %s", resp.StatusCode, bytes.TrimSpace(errBody))
+	}
+	return nil
+}
+
+func renderChatMessage(text string, message domain.ChatMessage) (string, error) {
+	tmpl, err := template.New("chat").Funcs(chatTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, message); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sampleChatMessage has every field the event's templates can use
+func sampleChatMessage(eventType string) domain.ChatMessage {
+	message := domain.ChatMessage{
+		Event:       eventType,
+		PullRequest: domain.ChatPullRequest{ID: "pr-1", Name: "Sample PR"},
+		Author:      domain.ChatUser{UserID: "u1", Username: "author"},
+		Reviewers:   []domain.ChatUser{{UserID: "u2", Username: "reviewer"}},
+	}
+	if eventType == domain.WebhookReviewerReassigned {
+		message.PreviousReviewer = &domain.ChatUser{UserID: "u3", Username: "previous"}
+	}
+	return message
+}
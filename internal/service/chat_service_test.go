@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChatRepo keeps the team's settings and posts in memory, posts are claimed like in the database:
+// a claimed post is hidden until its lease ends
+type memoryChatRepo struct {
+	ChatRepository
+	mu       sync.Mutex
+	settings *domain.TeamChatSettings
+	posts    []domain.ChatPost
+}
+
+func (r *memoryChatRepo) FindSettings(_ context.Context, teamName string) (*domain.TeamChatSettings, error) {
+	if r.settings == nil || r.settings.TeamName != teamName {
+		return nil, nil
+	}
+	return r.settings, nil
+}
+
+func (r *memoryChatRepo) CreatePost(_ context.Context, post *domain.ChatPost) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post.ID = int64(len(r.posts) + 1)
+	post.Status = domain.DeliveryPending
+	r.posts = append(r.posts, *post)
+	return nil
+}
+
+func (r *memoryChatRepo) ClaimDuePosts(_ context.Context, now, leaseUntil time.Time, limit int) ([]domain.ChatPost, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimed := []domain.ChatPost{}
+	for i := range r.posts {
+		post := &r.posts[i]
+		if len(claimed) == limit || post.Status != domain.DeliveryPending || post.NextAttemptAt.After(now) {
+			continue
+		}
+		post.NextAttemptAt = leaseUntil
+		claimed = append(claimed, *post)
+	}
+	return claimed, nil
+}
+
+func (r *memoryChatRepo) SavePostAttempt(_ context.Context, post *domain.ChatPost) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.posts {
+		if r.posts[i].ID == post.ID {
+			r.posts[i] = *post
+		}
+	}
+	return nil
+}
+
+type chatUsers map[string]*domain.User
+
+func (u chatUsers) GetUserByID(_ context.Context, userID string) (*domain.User, error) {
+	user, ok := u[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+type chatPRs map[string]*domain.PullRequest
+
+func (p chatPRs) GetPRByID(_ context.Context, prID string) (*domain.PullRequest, error) {
+	pr, ok := p[prID]
+	if !ok {
+		return nil, errors.New("PR not found")
+	}
+	return pr, nil
+}
+
+func TestChatService_PublishAddedReviewer(t *testing.T) {
+	repo := &memoryChatRepo{settings: &domain.TeamChatSettings{TeamName: "backend", WebhookURL: "http://chat.example"}}
+	users := chatUsers{
+		"u1": {UserID: "u1", Username: "alice", TeamName: "backend"},
+		"u2": {UserID: "u2", Username: "bob", TeamName: "backend"},
+	}
+	prs := chatPRs{"pr-1": {PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}}
+	service := NewChatService(repo, nil, users, prs, &http.Client{})
+
+	assigned := func(reason string) domain.OutboxMessage {
+		payload, err := json.Marshal(domain.WebhookReviewerData{PullRequestID: "pr-1", ReviewerID: "u2", Reason: reason})
+		require.NoError(t, err)
+		return domain.OutboxMessage{EventType: domain.WebhookReviewerAssigned, Payload: payload}
+	}
+
+	// the initial reviewers are announced by pr.created or pr.ready
+	require.NoError(t, service.Publish(context.Background(), assigned(domain.EventReasonAssignment)))
+	assert.Empty(t, repo.posts)
+
+	require.NoError(t, service.Publish(context.Background(), assigned(domain.EventReasonManual)))
+	require.Len(t, repo.posts, 1)
+	assert.Equal(t, "backend", repo.posts[0].TeamName)
+	assert.Equal(t, domain.WebhookReviewerAssigned, repo.posts[0].EventType)
+	assert.Equal(t, `@bob you are assigned to review "Add search" (pr-1) by alice`, repo.posts[0].Text)
+}
+
+func TestChatService_DeliverDueKeepsBatchLeased(t *testing.T) {
+	const (
+		batch   = 50
+		timeout = 5 * time.Second
+	)
+	start := time.Now()
+
+	// while the batch is being sent, another sender looks for due posts at the moment
+	// the batch would end if every request took the whole client timeout
+	repo := &memoryChatRepo{}
+	var reclaimed atomic.Int64
+	chat := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claimed, err := repo.ClaimDuePosts(r.Context(), start.Add(batch*timeout), start.Add(time.Hour), batch)
+		assert.NoError(t, err)
+		reclaimed.Add(int64(len(claimed)))
+	}))
+	defer chat.Close()
+
+	for id := int64(1); id <= batch; id++ {
+		repo.posts = append(repo.posts, domain.ChatPost{
+			ID:            id,
+			TeamName:      "backend",
+			Text:          "hello",
+			WebhookURL:    chat.URL,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: start.Add(-time.Second),
+		})
+	}
+
+	service := NewChatService(repo, nil, nil, nil, &http.Client{Timeout: timeout})
+	sent, err := service.DeliverDue(context.Background(), batch)
+	require.NoError(t, err)
+	assert.Equal(t, batch, sent)
+	assert.Zero(t, reclaimed.Load(), "posts of the batch were claimed again before it ended")
+
+	for _, post := range repo.posts {
+		assert.Equal(t, domain.DeliveryDelivered, post.Status)
+		assert.Equal(t, 1, post.Attempts)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/codeowners"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type CodeOwnersService struct {
+	repo CodeOwnersRepository
+}
+
+func NewCodeOwnersService(repo CodeOwnersRepository) *CodeOwnersService {
+	return &CodeOwnersService{
+		repo: repo,
+	}
+}
+
+// UploadRules replaces all rules with the ones from a CODEOWNERS file
+func (s *CodeOwnersService) UploadRules(ctx context.Context, content string) ([]domain.CodeOwnerRule, error) {
+	audit.Target(ctx, domain.AuditTargetCodeOwners, "")
+	if content == "" {
+		return nil, fmt.Errorf("content: %w", my_errors.ErrEmptyField)
+	}
+
+	rules, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRules(ctx, rules); err != nil {
+		return nil, fmt.Errorf("failed to save code owner rules: %w", err)
+	}
+
+	updated, err := s.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	audit.Change(ctx, map[string][]domain.CodeOwnerRule{"rules": before}, map[string][]domain.CodeOwnerRule{"rules": updated})
+
+	return updated, nil
+}
+
+func (s *CodeOwnersService) GetRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code owner rules: %w", err)
+	}
+	return rules, nil
+}
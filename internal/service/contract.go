@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"pr-reviewer-service/internal/mail"
+
 	"pr-reviewer-service/internal/domain"
 )
 
@@ -14,46 +16,202 @@ type AuthRepository interface {
 }
 
 type PRRepository interface {
-	CreatePR(ctx context.Context, pr *domain.PullRequest) error
+	CreatePR(ctx context.Context, pr *domain.PullRequest, source domain.EventSource) error
 	PRExists(ctx context.Context, prID string) (bool, error)
 	GetPRByID(ctx context.Context, prID string) (*domain.PullRequest, error)
-	MergePR(ctx context.Context, prID string) error
+	ListPRs(ctx context.Context, filter domain.PRFilter) ([]domain.PullRequest, error)
+	MergePR(ctx context.Context, prID string, source domain.EventSource) error
+	MarkReady(ctx context.Context, pr *domain.PullRequest, source domain.EventSource) error
+	SetStatus(ctx context.Context, prID, from, to string, source domain.EventSource) error
 	IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
+	ReassignReviewer(ctx context.Context, prID, oldUserID string, replacement domain.ReviewerReplacement, source domain.EventSource) error
+	SetReviewState(ctx context.Context, prID, userID, state string, source domain.EventSource) error
+	AddReviewer(ctx context.Context, prID, userID string, source domain.EventSource) error
+	RemoveReviewer(ctx context.Context, prID, userID string, source domain.EventSource) error
+	GetPREvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+	GetAssignmentTrace(ctx context.Context, prID string) (*domain.AssignmentTrace, error)
+	GetReassignmentTraces(ctx context.Context, prID string) ([]domain.PREvent, error)
 	GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
 }
 
 type UserRepositoryForPR interface {
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
-	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
 }
 
 type StatisticsRepository interface {
 	GetStatistics(ctx context.Context) (*domain.Statistics, error)
+	GetPairingDiversity(ctx context.Context, teamName string, since time.Time) (*domain.PairingDiversity, error)
+}
+
+type TeamRepositoryForStatistics interface {
+	TeamExists(ctx context.Context, teamName string) (bool, error)
 }
 
 type TeamRepository interface {
-	CreateTeam(ctx context.Context, teamName string) error
+	CreateTeam(ctx context.Context, teamName, reviewerStrategy string) error
 	TeamExists(ctx context.Context, teamName string) (bool, error)
 	GetTeamWithMembers(ctx context.Context, teamName string) (*domain.Team, error)
 	GetAllTeams(ctx context.Context) ([]domain.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	UpsertTeamSettings(ctx context.Context, settings *domain.TeamSettings) error
+	GetFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+	SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error
+	SetTeamLead(ctx context.Context, teamName, userID string) error
 }
 
 type UserRepository interface {
 	CreateOrUpdateUser(ctx context.Context, user *domain.User) error
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
 	SetUserActive(ctx context.Context, userID string, isActive bool) error
+	SetUserCapacity(ctx context.Context, userID string, maxOpenReviews *int, reviewWeight float64) error
+	SetUserSkills(ctx context.Context, userID string, skills []string) error
+	SetUserSeniority(ctx context.Context, userID, seniority string) error
+	SetUserNotifications(ctx context.Context, userID, email, preference string) error
+	SetUserDigestSchedule(ctx context.Context, userID, timezone string, digestHour int) error
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
 	GetAllUsers(ctx context.Context) ([]domain.User, error)
 }
 
 type PRRepositoryForBatch interface {
 	GetOpenPRsByReviewers(ctx context.Context, userIDs []string) (map[string][]string, error)
-	BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]string) error
-	GetPRWithReviewersAndAuthor(ctx context.Context, prID string) (string, string, []string, error)
+	BatchReassignReviewers(ctx context.Context, reassignments map[string]map[string]domain.ReviewerReplacement, source domain.EventSource) error
+	GetPRWithReviewersAndAuthor(ctx context.Context, prID string) (*domain.ReassignmentTask, error)
 }
 
 type UserRepositoryForBatch interface {
 	BatchDeactivateUsers(ctx context.Context, userIDs []string) ([]string, error)
 	GetTeamMemberIDs(ctx context.Context, teamName string) ([]string, error)
 }
+
+type UserRepositoryForAssign interface {
+	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
+	GetActiveUsers(ctx context.Context, userIDs []string) ([]domain.User, error)
+}
+
+type ReviewerLoadRepository interface {
+	GetReviewerLoads(ctx context.Context, userIDs []string) (map[string]domain.ReviewerLoad, error)
+	GetRecentPairings(ctx context.Context, authorID string, lookback int) (map[string]int, error)
+}
+
+type TeamSettingsRepository interface {
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	GetFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+}
+
+type CodeOwnersRepository interface {
+	ReplaceRules(ctx context.Context, rules []domain.CodeOwnerRule) error
+	GetRules(ctx context.Context) ([]domain.CodeOwnerRule, error)
+}
+
+type AvailabilityRepository interface {
+	CreateAbsence(ctx context.Context, absence *domain.Absence) error
+	GetUserAbsences(ctx context.Context, userID string, at time.Time) ([]domain.Absence, error)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
+	GetPendingHandovers(ctx context.Context, at time.Time) ([]domain.Absence, error)
+	MarkHandedOver(ctx context.Context, absenceIDs []int64, at time.Time) error
+}
+
+type AvailabilityRepositoryForAssign interface {
+	GetAwayUsers(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error)
+}
+
+type AuditRepository interface {
+	CreateEntry(ctx context.Context, entry *domain.AuditEntry) error
+	ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	GetSubscriptionsForEvent(ctx context.Context, eventType string) ([]domain.WebhookSubscription, error)
+	DisableSubscription(ctx context.Context, subscriptionID int64) error
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+}
+
+type OutboxRepository interface {
+	ProcessBatch(ctx context.Context, limit int, handle func(domain.OutboxMessage) error) (int, error)
+}
+
+// EventPublisher delivers domain events to external subscribers
+type EventPublisher interface {
+	Publish(ctx context.Context, message domain.OutboxMessage) error
+}
+
+type IntegrationRepository interface {
+	UpsertAccount(ctx context.Context, account *domain.IntegrationAccount) error
+	ListAccounts(ctx context.Context, provider string) ([]domain.IntegrationAccount, error)
+	GetUserIDByLogin(ctx context.Context, provider, login string) (string, error)
+	GetLoginsByUserIDs(ctx context.Context, provider string, userIDs []string) ([]string, error)
+	ClaimDelivery(ctx context.Context, delivery *domain.InboundDelivery) (bool, error)
+	ReleaseDelivery(ctx context.Context, provider, deliveryID string) error
+	QueueReviewerSync(ctx context.Context, sync *domain.GitLabReviewerSync) error
+	ClaimDueReviewerSyncs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.GitLabReviewerSync, error)
+	SaveReviewerSyncAttempt(ctx context.Context, sync *domain.GitLabReviewerSync) error
+}
+
+type ChatRepository interface {
+	GetSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error)
+	FindSettings(ctx context.Context, teamName string) (*domain.TeamChatSettings, error)
+	UpsertSettings(ctx context.Context, settings *domain.TeamChatSettings) error
+	DeleteSettings(ctx context.Context, teamName string) error
+	CreatePost(ctx context.Context, post *domain.ChatPost) error
+	ClaimDuePosts(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.ChatPost, error)
+	SavePostAttempt(ctx context.Context, post *domain.ChatPost) error
+}
+
+type PRReader interface {
+	GetPRByID(ctx context.Context, prID string) (*domain.PullRequest, error)
+}
+
+type NotificationRepository interface {
+	QueueDigestItem(ctx context.Context, item *domain.DigestItem) error
+	QueueEmail(ctx context.Context, email *domain.OutgoingEmail) error
+	ClaimDueEmails(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutgoingEmail, error)
+	SaveEmailAttempt(ctx context.Context, email *domain.OutgoingEmail) error
+	GetPendingDigestItems(ctx context.Context, userID string) ([]domain.DigestItem, error)
+	MarkDigestItemsSent(ctx context.Context, itemIDs []int64, at time.Time) error
+	ClaimDigest(ctx context.Context, userID string, localDate time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID string, localDate time.Time) error
+}
+
+type UserRepositoryForDigest interface {
+	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
+	GetAllUsers(ctx context.Context) ([]domain.User, error)
+}
+
+type PRRepositoryForDigest interface {
+	GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+}
+
+type SLARepository interface {
+	GetPendingAssignments(ctx context.Context) ([]domain.SLAAssignment, error)
+	CreateBreach(ctx context.Context, breach *domain.SLABreach) (bool, error)
+	EscalateBreach(ctx context.Context, breachID int64, leadID string, at time.Time) (bool, error)
+	ResolveBreaches(ctx context.Context, at time.Time) (int, error)
+	ListBreaches(ctx context.Context, filter domain.SLABreachFilter) ([]domain.SLABreach, error)
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// MergeRequestReviewers shows the assigned reviewers on a GitLab merge request
+type MergeRequestReviewers interface {
+	SetReviewers(ctx context.Context, projectID int64, iid int, usernames []string) error
+}
+
+// PRLifecycle applies PR changes made in code hosting platforms
+type PRLifecycle interface {
+	CreatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error)
+	// RecordExternalMerge merges the PR without checking the team's merge policy
+	RecordExternalMerge(ctx context.Context, prID string) (*domain.PullRequest, error)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/mail"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// DigestService builds the daily digest of pending reviews and emails it at each user's local digest hour.
+// A digest is personal, so email is its only channel: team chats do not get digests
+type DigestService struct {
+	repo     NotificationRepository
+	userRepo UserRepositoryForDigest
+	prRepo   PRRepositoryForDigest
+	// mailer is nil when email is not configured, digests can only be previewed then
+	mailer Mailer
+}
+
+func NewDigestService(repo NotificationRepository, userRepo UserRepositoryForDigest, prRepo PRRepositoryForDigest, mailer Mailer) *DigestService {
+	return &DigestService{
+		repo:     repo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		mailer:   mailer,
+	}
+}
+
+// PreviewDigest builds the user's digest as it would be sent now, nothing is sent or marked as sent
+func (s *DigestService) PreviewDigest(ctx context.Context, userID string) (*domain.DigestPreview, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	digest, err := s.buildDigest(ctx, user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	subject, html, err := renderDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.DigestPreview{Digest: digest, Subject: subject, HTML: html}
+	switch {
+	case s.mailer == nil:
+		preview.Reason = "email is not configured, it is the only digest channel"
+	case !user.IsActive:
+		preview.Reason = "user is inactive"
+	case user.Email == "":
+		preview.Reason = "user has no email, it is the only digest channel"
+	case user.NotificationPreference == domain.NotifyOff:
+		preview.Reason = "user notifications are off"
+	case digest.Empty():
+		preview.Reason = "nothing is waiting for the user"
+	default:
+		preview.WillSend = true
+	}
+	return preview, nil
+}
+
+// SendDueDigests sends the digest of every active user whose local digest hour has come today and who has not
+// got today's digest yet. A digest that fails to send is retried on the next run. Returns the number of sent digests
+func (s *DigestService) SendDueDigests(ctx context.Context, now time.Time) (int, error) {
+	if s.mailer == nil {
+		return 0, nil
+	}
+	users, err := s.userRepo.GetAllUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	sent := 0
+	for i := range users {
+		user := &users[i]
+		if !user.IsActive || user.Email == "" || user.NotificationPreference == domain.NotifyOff {
+			continue
+		}
+		local := now.In(loadLocation(user.Timezone))
+		if local.Hour() < user.DigestHour {
+			continue
+		}
+		localDate := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+		ok, err := s.sendDigest(ctx, user, localDate, now)
+		if err != nil {
+			slog.Warn("failed to send digest", "user_id", user.UserID, "error", err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendDigest claims the user's digest of the local day and emails it, an empty digest is claimed but not sent
+func (s *DigestService) sendDigest(ctx context.Context, user *domain.User, localDate, now time.Time) (bool, error) {
+	claimed, err := s.repo.ClaimDigest(ctx, user.UserID, localDate)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	digest, err := s.buildDigest(ctx, user, now)
+	if err == nil {
+		if digest.Empty() {
+			return false, nil
+		}
+		err = s.deliverDigest(ctx, user, digest, now)
+	}
+	if err != nil {
+		if releaseErr := s.repo.ReleaseDigest(ctx, user.UserID, localDate); releaseErr != nil {
+			slog.Error("failed to release digest", "user_id", user.UserID, "error", releaseErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// deliverDigest emails the digest and marks its notifications as sent
+func (s *DigestService) deliverDigest(ctx context.Context, user *domain.User, digest *domain.Digest, now time.Time) error {
+	subject, html, err := renderDigest(digest)
+	if err != nil {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+	if err := s.mailer.Send(sendCtx, mail.Message{To: user.Email, Subject: subject, HTML: html}); err != nil {
+		return fmt.Errorf("failed to email digest: %w", err)
+	}
+
+	itemIDs := make([]int64, len(digest.Notifications))
+	for i, item := range digest.Notifications {
+		itemIDs[i] = item.ID
+	}
+	return s.repo.MarkDigestItemsSent(ctx, itemIDs, now)
+}
+
+// buildDigest collects the user's OPEN reviews, the longest waiting first, and the queued notifications
+func (s *DigestService) buildDigest(ctx context.Context, user *domain.User, now time.Time) (*domain.Digest, error) {
+	location := loadLocation(user.Timezone)
+	digest := &domain.Digest{
+		GeneratedAt:   now,
+		UserID:        user.UserID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Timezone:      location.String(),
+		LocalDate:     now.In(location).Format(time.DateOnly),
+		Reviews:       []domain.DigestReview{},
+		Notifications: []domain.DigestItem{},
+	}
+
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+	authors := map[string]string{}
+	for _, pr := range prs {
+		if pr.Status != domain.StatusOpen || pr.AssignedAt == nil {
+			continue
+		}
+		if _, ok := authors[pr.AuthorID]; !ok {
+			authors[pr.AuthorID] = pr.AuthorID
+			if author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID); err == nil {
+				authors[pr.AuthorID] = author.Username
+			}
+		}
+		digest.Reviews = append(digest.Reviews, domain.DigestReview{
+			AssignedAt:      *pr.AssignedAt,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Author:          authors[pr.AuthorID],
+			Waiting:         max(now.Sub(*pr.AssignedAt), 0),
+		})
+	}
+	sort.SliceStable(digest.Reviews, func(i, j int) bool {
+		return digest.Reviews[i].AssignedAt.Before(digest.Reviews[j].AssignedAt)
+	})
+
+	items, err := s.repo.GetPendingDigestItems(ctx, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest items: %w", err)
+	}
+	digest.Notifications = items
+
+	return digest, nil
+}
+
+// renderDigest returns the subject and the HTML body of the digest email
+func renderDigest(digest *domain.Digest) (string, string, error) {
+	data := mail.Digest{
+		Recipient:     digest.Username,
+		Date:          digest.LocalDate,
+		Reviews:       make([]mail.DigestReview, len(digest.Reviews)),
+		Notifications: make([]string, len(digest.Notifications)),
+	}
+	for i, review := range digest.Reviews {
+		data.Reviews[i] = mail.DigestReview{
+			PullRequestID:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			Author:          review.Author,
+			Waiting:         formatWaiting(review.Waiting),
+		}
+	}
+	for i, item := range digest.Notifications {
+		data.Notifications[i] = item.Summary
+	}
+
+	html, err := mail.Render(mail.TemplateDigest, data)
+	if err != nil {
+		return "", "", err
+	}
+	subject := fmt.Sprintf("Your review digest for %s: %d pending", digest.LocalDate, len(digest.Reviews))
+	return subject, html, nil
+}
+
+// loadLocation falls back to UTC when the stored timezone is unknown to this host
+func loadLocation(timezone string) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+	return location
+}
+
+// formatWaiting renders a duration as days, hours and minutes, for example "2d 5h" or "40m"
+func formatWaiting(waiting time.Duration) string {
+	days := int(waiting / (24 * time.Hour))
+	hours := int(waiting % (24 * time.Hour) / time.Hour)
+	minutes := int(waiting % time.Hour / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if days == 0 && (minutes > 0 || hours == 0) {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/mail"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+const (
+	// emailSendTimeout limits a single SMTP session
+	emailSendTimeout = 30 * time.Second
+	// emailLease is how long claimed emails stay hidden from other senders, it outlasts a batch of SMTP sessions
+	emailLease = 10 * time.Minute
+)
+
+// EmailService emails reviewers about their assignments and authors once their PRs have reviewers.
+// Review SLA breaches are emailed to the reviewer and escalations to the team lead. Users with the digest preference get the notifications in the daily digest instead.
+// Other emails are queued when the event is published and sent by the email worker outside the outbox transaction
+type EmailService struct {
+	repo     NotificationRepository
+	userRepo UserRepositoryForPR
+	prRepo   PRReader
+	mailer   Mailer
+}
+
+func NewEmailService(repo NotificationRepository, userRepo UserRepositoryForPR, prRepo PRReader, mailer Mailer) *EmailService {
+	return &EmailService{
+		repo:     repo,
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		mailer:   mailer,
+	}
+}
+
+// email is a notification to one user
+type email struct {
+	recipient     *domain.User
+	eventType     string
+	pullRequestID string
+	subject       string
+	// summary is the line of the notification in the digest
+	summary  string
+	template string
+	data     any
+}
+
+// Publish queues the notification of the user concerned by the outbox event for the email worker or the digest.
+// A notification that cannot be built is logged and dropped, a failure to queue it holds back the outbox.
+// A message published twice is queued once
+func (s *EmailService) Publish(ctx context.Context, message domain.OutboxMessage) error {
+	var notification *email
+	var err error
+	switch message.EventType {
+	case domain.WebhookReviewerAssigned, domain.WebhookReviewerReassigned:
+		notification, err = s.reviewerEmail(ctx, message)
+	case domain.WebhookPRCreated, domain.WebhookPRReady:
+		notification, err = s.authorEmail(ctx, message)
+	case domain.WebhookSLABreached, domain.WebhookSLAEscalated:
+		notification, err = s.slaEmail(ctx, message)
+	}
+	if err != nil {
+		slog.Warn("failed to build email notification", "event_type", message.EventType, "outbox_id", message.ID, "error", err)
+		return nil
+	}
+	if notification == nil {
+		return nil
+	}
+	if err := s.queue(ctx, message.ID, notification); err != nil {
+		return fmt.Errorf("failed to queue %s email: %w", message.EventType, err)
+	}
+	return nil
+}
+
+func (s *EmailService) reviewerEmail(ctx context.Context, message domain.OutboxMessage) (*email, error) {
+	var data domain.WebhookReviewerData
+	if err := json.Unmarshal(message.Payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	pr, err := s.prRepo.GetPRByID(ctx, data.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR %s: %w", data.PullRequestID, err)
+	}
+	reviewer, err := s.userRepo.GetUserByID(ctx, data.ReviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer %s: %w", data.ReviewerID, err)
+	}
+
+	assigned := mail.ReviewerAssigned{
+		Recipient:       reviewer.Username,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		Author:          s.username(ctx, pr.AuthorID),
+	}
+	summary := fmt.Sprintf("You are assigned to review %q by %s", pr.PullRequestName, assigned.Author)
+	if data.PreviousReviewerID != "" {
+		assigned.PreviousReviewer = s.username(ctx, data.PreviousReviewerID)
+		summary = fmt.Sprintf("You take over the review of %q by %s from %s", pr.PullRequestName, assigned.Author, assigned.PreviousReviewer)
+	}
+
+	return &email{
+		recipient:     reviewer,
+		eventType:     message.EventType,
+		pullRequestID: pr.PullRequestID,
+		subject:       "Review requested: " + pr.PullRequestName,
+		summary:       summary,
+		template:      mail.TemplateReviewerAssigned,
+		data:          assigned,
+	}, nil
+}
+
+// authorEmail tells the author the reviewers of a PR that is open for review, drafts have none yet
+func (s *EmailService) authorEmail(ctx context.Context, message domain.OutboxMessage) (*email, error) {
+	var data domain.WebhookPRData
+	if err := json.Unmarshal(message.Payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	if data.PullRequest.Status != domain.StatusOpen || len(data.Reviewers) == 0 {
+		return nil, nil
+	}
+	author, err := s.userRepo.GetUserByID(ctx, data.PullRequest.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author %s: %w", data.PullRequest.AuthorID, err)
+	}
+
+	reviewers := make([]string, len(data.Reviewers))
+	for i, reviewerID := range data.Reviewers {
+		reviewers[i] = s.username(ctx, reviewerID)
+	}
+
+	return &email{
+		recipient:     author,
+		eventType:     message.EventType,
+		pullRequestID: data.PullRequest.PullRequestID,
+		subject:       "Reviewers assigned: " + data.PullRequest.PullRequestName,
+		summary:       fmt.Sprintf("%q is reviewed by %s", data.PullRequest.PullRequestName, strings.Join(reviewers, ", ")),
+		template:      mail.TemplateReviewersReady,
+		data: mail.ReviewersReady{
+			Recipient:       author.Username,
+			PullRequestID:   data.PullRequest.PullRequestID,
+			PullRequestName: data.PullRequest.PullRequestName,
+			Reviewers:       reviewers,
+		},
+	}, nil
+}
+
+// slaEmail tells the reviewer about the breach, or the team lead about the escalation
+func (s *EmailService) slaEmail(ctx context.Context, message domain.OutboxMessage) (*email, error) {
+	var data domain.WebhookSLAData
+	if err := json.Unmarshal(message.Payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	pr, err := s.prRepo.GetPRByID(ctx, data.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR %s: %w", data.PullRequestID, err)
+	}
+
+	if message.EventType == domain.WebhookSLABreached {
+		reviewer, err := s.userRepo.GetUserByID(ctx, data.ReviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reviewer %s: %w", data.ReviewerID, err)
+		}
+		breached := mail.SLABreached{
+			Recipient:       reviewer.Username,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			Author:          s.username(ctx, pr.AuthorID),
+			DueAt:           formatDueAt(data.DueAt, reviewer.Timezone),
+			SLAHours:        data.SLAHours,
+		}
+		return &email{
+			recipient:     reviewer,
+			eventType:     message.EventType,
+			pullRequestID: pr.PullRequestID,
+			subject:       "Review overdue: " + pr.PullRequestName,
+			summary:       fmt.Sprintf("Your review of %q by %s was due by %s", pr.PullRequestName, breached.Author, breached.DueAt),
+			template:      mail.TemplateSLABreached,
+			data:          breached,
+		}, nil
+	}
+
+	lead, err := s.userRepo.GetUserByID(ctx, data.LeadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team lead %s: %w", data.LeadID, err)
+	}
+	escalated := mail.SLAEscalated{
+		Recipient:       lead.Username,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		Reviewer:        s.username(ctx, data.ReviewerID),
+		DueAt:           formatDueAt(data.DueAt, lead.Timezone),
+		SLAHours:        data.SLAHours,
+	}
+	return &email{
+		recipient:     lead,
+		eventType:     message.EventType,
+		pullRequestID: pr.PullRequestID,
+		subject:       "Review overdue in your team: " + pr.PullRequestName,
+		summary:       fmt.Sprintf("The review of %q by %s was due by %s", pr.PullRequestName, escalated.Reviewer, escalated.DueAt),
+		template:      mail.TemplateSLAEscalated,
+		data:          escalated,
+	}, nil
+}
+
+// queue keeps the email for the email worker or for the digest according to the recipient's preference.
+// An email whose template fails to render is logged and dropped, rendering it again would fail the same way
+func (s *EmailService) queue(ctx context.Context, outboxID int64, notification *email) error {
+	recipient := notification.recipient
+	if recipient.Email == "" {
+		return nil
+	}
+
+	switch recipient.NotificationPreference {
+	case domain.NotifyDigest:
+		return s.repo.QueueDigestItem(ctx, &domain.DigestItem{
+			UserID:        recipient.UserID,
+			EventType:     notification.eventType,
+			PullRequestID: notification.pullRequestID,
+			Summary:       notification.summary,
+			OutboxID:      outboxID,
+		})
+	case domain.NotifyImmediate:
+		html, err := mail.Render(notification.template, notification.data)
+		if err != nil {
+			slog.Warn("failed to render email", "event_type", notification.eventType, "user_id", recipient.UserID, "error", err)
+			return nil
+		}
+		return s.repo.QueueEmail(ctx, &domain.OutgoingEmail{
+			UserID:    recipient.UserID,
+			EventType: notification.eventType,
+			Recipient: recipient.Email,
+			Subject:   notification.subject,
+			HTML:      html,
+			OutboxID:  outboxID,
+		})
+	default:
+		return nil
+	}
+}
+
+// DeliverDue sends up to limit queued emails whose attempt is due and returns how many were attempted.
+// Failed attempts are retried with exponential backoff until domain.MaxDeliveryAttempts is reached
+func (s *EmailService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	emails, err := s.repo.ClaimDueEmails(ctx, now, now.Add(emailLease), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim emails: %w", err)
+	}
+
+	for i := range emails {
+		outgoing := &emails[i]
+		sendErr := s.send(ctx, outgoing)
+
+		attemptedAt := time.Now()
+		outgoing.Attempts++
+		outgoing.LastAttemptAt = &attemptedAt
+		switch {
+		case sendErr == nil:
+			outgoing.Status = domain.DeliveryDelivered
+			outgoing.DeliveredAt = &attemptedAt
+			outgoing.LastError = ""
+		case outgoing.Attempts >= domain.MaxDeliveryAttempts:
+			outgoing.Status = domain.DeliveryFailed
+			outgoing.LastError = sendErr.Error()
+		default:
+			outgoing.NextAttemptAt = attemptedAt.Add(domain.DeliveryBackoff(outgoing.Attempts))
+			outgoing.LastError = sendErr.Error()
+		}
+		if sendErr != nil {
+			slog.Warn("failed to send email", "user_id", outgoing.UserID, "email_id", outgoing.ID, "attempts", outgoing.Attempts, "error", sendErr)
+		}
+
+		if err := s.repo.SaveEmailAttempt(ctx, outgoing); err != nil {
+			return i, fmt.Errorf("failed to save email attempt: %w", err)
+		}
+	}
+	return len(emails), nil
+}
+
+func (s *EmailService) send(ctx context.Context, outgoing *domain.OutgoingEmail) error {
+	ctx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+	if err := s.mailer.Send(ctx, mail.Message{To: outgoing.Recipient, Subject: outgoing.Subject, HTML: outgoing.HTML}); err != nil {
+		return fmt.Errorf("failed to email %s: %w", outgoing.UserID, err)
+	}
+	return nil
+}
+
+// formatDueAt renders the deadline in the recipient's timezone
+func formatDueAt(dueAt time.Time, timezone string) string {
+	return dueAt.In(loadLocation(timezone)).Format("Mon, 02 Jan 2006 15:04 MST")
+}
+
+// username falls back to the user ID when the user cannot be loaded
+func (s *EmailService) username(ctx context.Context, userID string) string {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return userID
+	}
+	return user.Username
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// gitlabSyncTimeout limits posting the reviewers of one merge request back to GitLab
+const gitlabSyncTimeout = 30 * time.Second
+
+// IntegrationService applies PR changes received from code hosting webhooks
+type IntegrationService struct {
+	repo         IntegrationRepository
+	userRepo     UserRepositoryForPR
+	prs          PRLifecycle
+	githubSecret string
+	gitlabToken  string
+	// gitlabReviewers is nil when the reviewers are not posted back to GitLab
+	gitlabReviewers MergeRequestReviewers
+}
+
+func NewIntegrationService(
+	repo IntegrationRepository,
+	userRepo UserRepositoryForPR,
+	prs PRLifecycle,
+	githubSecret string,
+	gitlabToken string,
+	gitlabReviewers MergeRequestReviewers,
+) *IntegrationService {
+	return &IntegrationService{
+		repo:            repo,
+		userRepo:        userRepo,
+		prs:             prs,
+		githubSecret:    githubSecret,
+		gitlabToken:     gitlabToken,
+		gitlabReviewers: gitlabReviewers,
+	}
+}
+
+// SetAccount links an external login to a user, an existing link of the login is replaced
+func (s *IntegrationService) SetAccount(ctx context.Context, account *domain.IntegrationAccount) (*domain.IntegrationAccount, error) {
+	if !domain.ValidProvider(account.Provider) {
+		return nil, fmt.Errorf("unknown provider %s: %w", account.Provider, my_errors.ErrInvalidInput)
+	}
+	// logins are case-insensitive on code hosting platforms
+	account.Login = strings.ToLower(account.Login)
+	if account.Login == "" {
+		return nil, fmt.Errorf("login: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetIntegration, account.Provider+"/"+account.Login)
+
+	if _, err := s.userRepo.GetUserByID(ctx, account.UserID); err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+	if err := s.repo.UpsertAccount(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to save integration account: %w", err)
+	}
+	audit.Change(ctx, nil, account)
+
+	return account, nil
+}
+
+func (s *IntegrationService) ListAccounts(ctx context.Context, provider string) ([]domain.IntegrationAccount, error) {
+	accounts, err := s.repo.ListAccounts(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integration accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// VerifyGitHubSignature checks the X-Hub-Signature-256 header of a GitHub webhook
+func (s *IntegrationService) VerifyGitHubSignature(body []byte, signature string) error {
+	if s.githubSecret == "" {
+		return fmt.Errorf("github: %w", my_errors.ErrIntegrationNotSet)
+	}
+	expected := domain.WebhookSignature(s.githubSecret, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("%w", my_errors.ErrInvalidSignature)
+	}
+	return nil
+}
+
+// HandleGitHubPullRequest applies a pull_request webhook once per delivery ID
+func (s *IntegrationService) HandleGitHubPullRequest(
+	ctx context.Context,
+	deliveryID string,
+	event domain.GitHubPullRequestEvent,
+) (*domain.IngestResult, error) {
+	if deliveryID == "" {
+		return nil, fmt.Errorf("delivery ID: %w", my_errors.ErrEmptyField)
+	}
+	if event.Repository == "" || event.Number <= 0 {
+		return nil, fmt.Errorf("repository and number of the pull request: %w", my_errors.ErrInvalidInput)
+	}
+
+	result := &domain.IngestResult{
+		Outcome:       domain.IngestIgnored,
+		Action:        event.Action,
+		PullRequestID: event.PullRequestID(),
+	}
+	switch event.Action {
+	case domain.GitHubActionOpened, domain.GitHubActionReopened, domain.GitHubActionClosed, domain.GitHubActionReadyForReview:
+	default:
+		return result, nil
+	}
+
+	delivery := &domain.InboundDelivery{
+		Provider:      domain.ProviderGitHub,
+		DeliveryID:    deliveryID,
+		Event:         "pull_request",
+		Action:        event.Action,
+		PullRequestID: result.PullRequestID,
+	}
+	return s.applyOnce(ctx, delivery, result, func() (*domain.PullRequest, error) {
+		return s.applyGitHubEvent(ctx, event)
+	})
+}
+
+// applyOnce calls apply unless the delivery was already applied.
+// A delivery that fails is forgotten, so its redelivery is applied again
+func (s *IntegrationService) applyOnce(
+	ctx context.Context,
+	delivery *domain.InboundDelivery,
+	result *domain.IngestResult,
+	apply func() (*domain.PullRequest, error),
+) (*domain.IngestResult, error) {
+	claimed, err := s.repo.ClaimDelivery(ctx, delivery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record delivery: %w", err)
+	}
+	if !claimed {
+		result.Outcome = domain.IngestDuplicate
+		return result, nil
+	}
+
+	pr, err := apply()
+	if err != nil {
+		if releaseErr := s.repo.ReleaseDelivery(context.WithoutCancel(ctx), delivery.Provider, delivery.DeliveryID); releaseErr != nil {
+			slog.Error("failed to release inbound delivery", "provider", delivery.Provider, "delivery_id", delivery.DeliveryID, "error", releaseErr)
+		}
+		return nil, err
+	}
+
+	result.Outcome = domain.IngestApplied
+	result.PullRequest = pr
+	return result, nil
+}
+
+func (s *IntegrationService) applyGitHubEvent(ctx context.Context, event domain.GitHubPullRequestEvent) (*domain.PullRequest, error) {
+	prID := event.PullRequestID()
+	switch event.Action {
+	case domain.GitHubActionOpened:
+		authorID, err := s.repo.GetUserIDByLogin(ctx, domain.ProviderGitHub, strings.ToLower(event.AuthorLogin))
+		if err != nil {
+			return nil, fmt.Errorf("github login %s: %w", event.AuthorLogin, my_errors.ErrAccountNotMapped)
+		}
+		status := domain.StatusOpen
+		if event.Draft {
+			status = domain.StatusDraft
+		}
+		pr, err := s.prs.CreatePR(ctx, &domain.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   event.Title,
+			AuthorID:          authorID,
+			Status:            status,
+			AssignedReviewers: []string{},
+			FallbackReviewers: []string{},
+		})
+		if errors.Is(err, my_errors.ErrPRAlreadyExists) {
+			return s.prs.GetPR(ctx, prID)
+		}
+		return pr, err
+	case domain.GitHubActionReopened:
+		return s.prs.ReopenPR(ctx, prID)
+	case domain.GitHubActionClosed:
+		// the merge has already happened on GitHub, so the local merge policy cannot block it
+		if event.Merged {
+			return s.prs.RecordExternalMerge(ctx, prID)
+		}
+		return s.prs.ClosePR(ctx, prID)
+	default:
+		return s.prs.MarkReady(ctx, prID)
+	}
+}
+
+// VerifyGitLabToken checks the X-Gitlab-Token header of a GitLab webhook
+func (s *IntegrationService) VerifyGitLabToken(token string) error {
+	if s.gitlabToken == "" {
+		return fmt.Errorf("gitlab: %w", my_errors.ErrIntegrationNotSet)
+	}
+	if subtle.ConstantTimeCompare([]byte(s.gitlabToken), []byte(token)) != 1 {
+		return fmt.Errorf("%w", my_errors.ErrInvalidSignature)
+	}
+	return nil
+}
+
+// HandleGitLabMergeRequest applies a Merge Request Hook once per event UUID.
+// When posting back is configured, the reviewers assigned to a merge request that is opened or marked ready
+// are queued to be set as its reviewers in GitLab by SyncDueReviewers
+func (s *IntegrationService) HandleGitLabMergeRequest(
+	ctx context.Context,
+	deliveryID string,
+	event domain.GitLabMergeRequestEvent,
+) (*domain.IngestResult, error) {
+	if deliveryID == "" {
+		return nil, fmt.Errorf("delivery ID: %w", my_errors.ErrEmptyField)
+	}
+	if event.Project == "" || event.IID <= 0 {
+		return nil, fmt.Errorf("project and iid of the merge request: %w", my_errors.ErrInvalidInput)
+	}
+
+	result := &domain.IngestResult{
+		Outcome:       domain.IngestIgnored,
+		Action:        event.Action,
+		PullRequestID: event.PullRequestID(),
+	}
+	switch event.Action {
+	case domain.GitLabActionOpen, domain.GitLabActionReopen, domain.GitLabActionClose, domain.GitLabActionMerge:
+	case domain.GitLabActionUpdate:
+		// PRs cannot go back from open to draft, only marking ready is applied
+		if !event.DraftChanged || event.Draft {
+			return result, nil
+		}
+	default:
+		return result, nil
+	}
+
+	delivery := &domain.InboundDelivery{
+		Provider:      domain.ProviderGitLab,
+		DeliveryID:    deliveryID,
+		Event:         "merge_request",
+		Action:        event.Action,
+		PullRequestID: result.PullRequestID,
+	}
+	result, err := s.applyOnce(ctx, delivery, result, func() (*domain.PullRequest, error) {
+		return s.applyGitLabEvent(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := result.PullRequest
+	if s.gitlabReviewers != nil && pr != nil && pr.Status == domain.StatusOpen && len(pr.AssignedReviewers) > 0 &&
+		(event.Action == domain.GitLabActionOpen || event.Action == domain.GitLabActionUpdate) {
+		sync := &domain.GitLabReviewerSync{
+			PullRequestID: pr.PullRequestID,
+			ProjectID:     event.ProjectID,
+			IID:           event.IID,
+			ReviewerIDs:   pr.AssignedReviewers,
+		}
+		// the event is applied already, so a failure to queue the sync does not fail the webhook
+		if err := s.repo.QueueReviewerSync(context.WithoutCancel(ctx), sync); err != nil {
+			slog.Error("failed to queue gitlab reviewer sync", "pull_request_id", pr.PullRequestID, "error", err)
+		}
+	}
+	return result, nil
+}
+
+func (s *IntegrationService) applyGitLabEvent(ctx context.Context, event domain.GitLabMergeRequestEvent) (*domain.PullRequest, error) {
+	prID := event.PullRequestID()
+	switch event.Action {
+	case domain.GitLabActionOpen:
+		authorID, err := s.repo.GetUserIDByLogin(ctx, domain.ProviderGitLab, strings.ToLower(event.Username))
+		if err != nil {
+			return nil, fmt.Errorf("gitlab username %s: %w", event.Username, my_errors.ErrAccountNotMapped)
+		}
+		status := domain.StatusOpen
+		if event.Draft {
+			status = domain.StatusDraft
+		}
+		pr, err := s.prs.CreatePR(ctx, &domain.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   event.Title,
+			AuthorID:          authorID,
+			Status:            status,
+			AssignedReviewers: []string{},
+			FallbackReviewers: []string{},
+		})
+		if errors.Is(err, my_errors.ErrPRAlreadyExists) {
+			return s.prs.GetPR(ctx, prID)
+		}
+		return pr, err
+	case domain.GitLabActionReopen:
+		return s.prs.ReopenPR(ctx, prID)
+	case domain.GitLabActionClose:
+		return s.prs.ClosePR(ctx, prID)
+	case domain.GitLabActionMerge:
+		// the merge has already happened on GitLab, so the local merge policy cannot block it
+		return s.prs.RecordExternalMerge(ctx, prID)
+	default:
+		return s.prs.MarkReady(ctx, prID)
+	}
+}
+
+// SyncDueReviewers sets the reviewers of up to limit queued merge requests whose attempt is due in GitLab
+// and returns how many were attempted. Failed attempts are retried with exponential backoff
+// until domain.MaxDeliveryAttempts is reached
+func (s *IntegrationService) SyncDueReviewers(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	syncs, err := s.repo.ClaimDueReviewerSyncs(ctx, now, now.Add(domain.DeliveryLease(limit, gitlabSyncTimeout)), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim gitlab reviewer syncs: %w", err)
+	}
+
+	for i := range syncs {
+		sync := &syncs[i]
+		syncErr := s.syncGitLabReviewers(ctx, sync)
+
+		attemptedAt := time.Now()
+		sync.Attempts++
+		sync.LastAttemptAt = &attemptedAt
+		switch {
+		case syncErr == nil:
+			sync.Status = domain.DeliveryDelivered
+			sync.DeliveredAt = &attemptedAt
+			sync.LastError = ""
+		case sync.Attempts >= domain.MaxDeliveryAttempts:
+			sync.Status = domain.DeliveryFailed
+			sync.LastError = syncErr.Error()
+		default:
+			sync.NextAttemptAt = attemptedAt.Add(domain.DeliveryBackoff(sync.Attempts))
+			sync.LastError = syncErr.Error()
+		}
+		if syncErr != nil {
+			slog.Warn("failed to set gitlab reviewers", "pull_request_id", sync.PullRequestID, "sync_id", sync.ID, "attempts", sync.Attempts, "error", syncErr)
+		}
+
+		if err := s.repo.SaveReviewerSyncAttempt(ctx, sync); err != nil {
+			return i, fmt.Errorf("failed to save gitlab reviewer sync attempt: %w", err)
+		}
+	}
+	return len(syncs), nil
+}
+
+// syncGitLabReviewers sets the reviewers with a mapped GitLab username as the merge request's reviewers
+func (s *IntegrationService) syncGitLabReviewers(ctx context.Context, sync *domain.GitLabReviewerSync) error {
+	ctx, cancel := context.WithTimeout(ctx, gitlabSyncTimeout)
+	defer cancel()
+
+	usernames, err := s.repo.GetLoginsByUserIDs(ctx, domain.ProviderGitLab, sync.ReviewerIDs)
+	if err != nil {
+		return err
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+	return s.gitlabReviewers.SetReviewers(ctx, sync.ProjectID, sync.IID, usernames)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// OutboxService relays domain events stored in the outbox to the publishers
+type OutboxService struct {
+	repo       OutboxRepository
+	publishers []EventPublisher
+}
+
+func NewOutboxService(repo OutboxRepository, publishers ...EventPublisher) *OutboxService {
+	return &OutboxService{
+		repo:       repo,
+		publishers: publishers,
+	}
+}
+
+// Relay publishes up to limit oldest unpublished events in order and returns how many were published.
+// Publishing is at-least-once: an event is published again to every publisher if one of them fails
+// or the relay stops before marking it published, the publishers drop the repeats by the event ID.
+// Relays on several replicas publish different batches concurrently, so events are unordered across replicas
+func (s *OutboxService) Relay(ctx context.Context, limit int) (int, error) {
+	published, err := s.repo.ProcessBatch(ctx, limit, func(message domain.OutboxMessage) error {
+		for _, publisher := range s.publishers {
+			if err := publisher.Publish(ctx, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return published, fmt.Errorf("failed to relay outbox: %w", err)
+	}
+	return published, nil
+}
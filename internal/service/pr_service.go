@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"slices"
+	"strings"
 
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/middleware"
 	"pr-reviewer-service/internal/my_errors"
 
 	"pr-reviewer-service/internal/domain"
@@ -13,12 +16,16 @@ import (
 type PRService struct {
 	prRepo   PRRepository
 	userRepo UserRepositoryForPR
+	assigner *ReviewerAssigner
+	seeds    SeedSource
 }
 
-func NewPRService(prRepo PRRepository, userRepo UserRepositoryForPR) *PRService {
+func NewPRService(prRepo PRRepository, userRepo UserRepositoryForPR, assigner *ReviewerAssigner, seeds SeedSource) *PRService {
 	return &PRService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
+		assigner: assigner,
+		seeds:    seeds,
 	}
 }
 
@@ -26,6 +33,7 @@ func (s *PRService) CreatePR(ctx context.Context, pr *domain.PullRequest) (*doma
 	if pr.PullRequestID == "" {
 		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetPR, pr.PullRequestID)
 	if pr.PullRequestName == "" {
 		return nil, fmt.Errorf("pull_request_name: %w", my_errors.ErrEmptyField)
 	}
@@ -50,16 +58,30 @@ func (s *PRService) CreatePR(ctx context.Context, pr *domain.PullRequest) (*doma
 		return nil, fmt.Errorf("author is not active")
 	}
 
-	activeMembers, err := s.userRepo.GetActiveTeamMembers(ctx, author.TeamName, author.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get active team members: %w", err)
+	pr.RequiredSkills = domain.NormalizeSkills(pr.RequiredSkills)
+
+	// drafts get their reviewers when they are marked ready
+	if pr.Status == domain.StatusDraft {
+		pr.AssignedReviewers = []string{}
+		pr.FallbackReviewers = []string{}
+		if err := s.prRepo.CreatePR(ctx, pr, eventSource(ctx, "")); err != nil {
+			return nil, fmt.Errorf("failed to create PR: %w", err)
+		}
+		draft, err := s.prRepo.GetPRByID(ctx, pr.PullRequestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get created PR: %w", err)
+		}
+		audit.Change(ctx, nil, draft)
+		return draft, nil
 	}
 
-	reviewers := selectRandomReviewers(activeMembers, 2)
-	pr.AssignedReviewers = reviewers
+	assignment, err := s.assignInitialReviewers(ctx, pr, author)
+	if err != nil {
+		return nil, err
+	}
 	pr.Status = domain.StatusOpen
 
-	if err := s.prRepo.CreatePR(ctx, pr); err != nil {
+	if err := s.prRepo.CreatePR(ctx, pr, eventSource(ctx, domain.EventReasonAssignment)); err != nil {
 		return nil, fmt.Errorf("failed to create PR: %w", err)
 	}
 
@@ -67,14 +89,199 @@ func (s *PRService) CreatePR(ctx context.Context, pr *domain.PullRequest) (*doma
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created PR: %w", err)
 	}
+	createdPR.Assignment = assignment.Report()
+	audit.Change(ctx, nil, createdPR)
 
 	return createdPR, nil
 }
 
+// MarkReady moves a draft PR to OPEN and assigns its reviewers
+func (s *PRService) MarkReady(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
+
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	// Idempotency: a PR that is already open keeps its reviewers
+	if pr.Status == domain.StatusOpen {
+		return pr, nil
+	}
+	before := *pr
+	if err := checkTransition(pr, domain.StatusOpen); err != nil {
+		return nil, err
+	}
+	if pr.Status != domain.StatusDraft {
+		return nil, fmt.Errorf("only drafts can be marked ready, use reopen: %w", my_errors.ErrInvalidStatusTransition)
+	}
+
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrAuthorNotFound)
+	}
+
+	assignment, err := s.assignInitialReviewers(ctx, pr, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.MarkReady(ctx, pr, eventSource(ctx, domain.EventReasonAssignment)); err != nil {
+		return nil, fmt.Errorf("failed to mark PR ready: %w", err)
+	}
+
+	readyPR, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	readyPR.Assignment = assignment.Report()
+	audit.Change(ctx, &before, readyPR)
+
+	return readyPR, nil
+}
+
+// ClosePR closes an open or draft PR without merging it.
+// Reviewers stay on the PR but it no longer counts towards their load
+func (s *PRService) ClosePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
+
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	// Idempotency: if already closed, return the current state
+	if pr.Status == domain.StatusClosed {
+		return pr, nil
+	}
+
+	return s.setStatus(ctx, pr, domain.StatusClosed)
+}
+
+// ReopenPR returns a closed PR to OPEN. A PR that was closed before it got reviewers returns to DRAFT
+func (s *PRService) ReopenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
+
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	// Idempotency: open PRs and drafts are returned as they are
+	if pr.Status == domain.StatusOpen || pr.Status == domain.StatusDraft {
+		return pr, nil
+	}
+
+	status := domain.StatusOpen
+	if pr.AssignmentSeed == nil && len(pr.AssignedReviewers) == 0 {
+		status = domain.StatusDraft
+	}
+
+	return s.setStatus(ctx, pr, status)
+}
+
+func (s *PRService) setStatus(ctx context.Context, pr *domain.PullRequest, status string) (*domain.PullRequest, error) {
+	if err := checkTransition(pr, status); err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.SetStatus(ctx, pr.PullRequestID, pr.Status, status, eventSource(ctx, "")); err != nil {
+		return nil, fmt.Errorf("failed to set PR status: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPRByID(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	audit.Change(ctx, pr, updatedPR)
+
+	return updatedPR, nil
+}
+
+// checkTransition validates a status change against the PR lifecycle
+func checkTransition(pr *domain.PullRequest, status string) error {
+	if pr.Status == domain.StatusMerged {
+		return fmt.Errorf("%w", my_errors.ErrPRAlreadyMerged)
+	}
+	if !domain.CanTransition(pr.Status, status) {
+		return fmt.Errorf("%s to %s: %w", pr.Status, status, my_errors.ErrInvalidStatusTransition)
+	}
+	return nil
+}
+
+// assignInitialReviewers picks the first reviewers of the PR according to the author's team settings
+// and records them together with the assignment details in pr
+func (s *PRService) assignInitialReviewers(ctx context.Context, pr *domain.PullRequest, author *domain.User) (*domain.Assignment, error) {
+	settings, err := s.assigner.TeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := s.assigner.Assign(ctx, AssignmentRequest{
+		Settings:       settings,
+		AuthorID:       author.UserID,
+		Exclude:        map[string]bool{author.UserID: true},
+		ChangedFiles:   pr.ChangedFiles,
+		RequiredSkills: pr.RequiredSkills,
+		Count:          settings.ReviewerCount,
+		Seed:           s.seeds.NextSeed(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign reviewers: %w", err)
+	}
+	if len(assignment.Reviewers) < settings.MinReviewers {
+		if assignment.CapacityExhausted() {
+			return nil, fmt.Errorf("team %s requires %d reviewers, found %d, at capacity: %v: %w",
+				author.TeamName, settings.MinReviewers, len(assignment.Reviewers), assignment.AtCapacity, my_errors.ErrReviewersAtCapacity)
+		}
+		return nil, fmt.Errorf("team %s requires %d reviewers, found %d: %w",
+			author.TeamName, settings.MinReviewers, len(assignment.Reviewers), my_errors.ErrNotEnoughReviewers)
+	}
+	if assignment.SeniorShortfall > 0 {
+		return nil, fmt.Errorf("team %s requires %d reviewers of level %s or above, found %d: %w",
+			author.TeamName, settings.MinSeniorReviewers, settings.SeniorLevel,
+			assignment.SeniorsNeeded-assignment.SeniorShortfall, my_errors.ErrSeniorReviewerRequired)
+	}
+
+	pr.AssignedReviewers = assignment.Reviewers
+	pr.FallbackReviewers = []string{}
+	for _, reviewerID := range assignment.Reviewers {
+		if assignment.FallbackReviewers[reviewerID] {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, reviewerID)
+		}
+	}
+	pr.AssignmentStrategy = assignment.Strategy
+	pr.AssignmentSeed = &assignment.Seed
+	pr.AssignmentTrace = assignment.Trace()
+
+	return assignment, nil
+}
+
 func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.mergePR(ctx, prID, true)
+}
+
+// RecordExternalMerge marks the PR merged after it was merged on the code hosting platform.
+// The team's merge policy is not checked, since the merge has already happened there
+func (s *PRService) RecordExternalMerge(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.mergePR(ctx, prID, false)
+}
+
+// mergePR merges an OPEN PR, checking the merge policy of the author's team when enforcePolicy is set
+func (s *PRService) mergePR(ctx context.Context, prID string, enforcePolicy bool) (*domain.PullRequest, error) {
 	if prID == "" {
 		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
 
 	pr, err := s.prRepo.GetPRByID(ctx, prID)
 	if err != nil {
@@ -85,8 +292,16 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 	if pr.Status == domain.StatusMerged {
 		return pr, nil
 	}
+	if err := checkTransition(pr, domain.StatusMerged); err != nil {
+		return nil, err
+	}
+	if enforcePolicy {
+		if err := s.checkMergePolicy(ctx, pr); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.prRepo.MergePR(ctx, prID); err != nil {
+	if err := s.prRepo.MergePR(ctx, prID, eventSource(ctx, "")); err != nil {
 		return nil, fmt.Errorf("failed to merge PR: %w", err)
 	}
 
@@ -94,25 +309,72 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullReque
 	if err != nil {
 		return nil, fmt.Errorf("failed to get merged PR: %w", err)
 	}
+	audit.Change(ctx, pr, mergedPR)
 
 	return mergedPR, nil
 }
 
-func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (string, *domain.PullRequest, error) {
+// checkMergePolicy fails when the reviews of the PR do not satisfy the merge policy of the author's team
+func (s *PRService) checkMergePolicy(ctx context.Context, pr *domain.PullRequest) error {
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return fmt.Errorf("%w", my_errors.ErrAuthorNotFound)
+	}
+	settings, err := s.assigner.TeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return err
+	}
+	if violations := settings.MergePolicy.Violations(author.TeamName, pr.Reviews); len(violations) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(violations, "; "), my_errors.ErrMergeBlocked)
+	}
+	return nil
+}
+
+// SubmitReview sets the review state of the reviewer on the PR
+func (s *PRService) SubmitReview(ctx context.Context, prID, userID, state string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
+	if !domain.ValidReviewState(state) {
+		return nil, fmt.Errorf("state must be pending, approved, changes_requested or commented: %w", my_errors.ErrInvalidInput)
+	}
+
+	pr, err := s.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, fmt.Errorf("%w", my_errors.ErrReviewerIsNotAssigned)
+	}
+
+	if err := s.prRepo.SetReviewState(ctx, prID, userID, state, eventSource(ctx, "")); err != nil {
+		return nil, fmt.Errorf("failed to set review state: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	audit.Change(ctx, pr, updatedPR)
+
+	return updatedPR, nil
+}
+
+// ReassignReviewer replaces oldUserID on the PR with newUserID.
+// When newUserID is empty the replacement is picked by the strategy of the old reviewer's team
+func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (string, *domain.PullRequest, error) {
 	if prID == "" {
 		return "", nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
 	if oldUserID == "" {
 		return "", nil, fmt.Errorf("old_user_id: %w", my_errors.ErrEmptyField)
 	}
 
-	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	pr, err := s.getOpenPR(ctx, prID)
 	if err != nil {
-		return "", nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
-	}
-
-	if pr.Status == domain.StatusMerged {
-		return "", nil, fmt.Errorf("%w", my_errors.ErrPRAlreadyMerged)
+		return "", nil, err
 	}
 
 	isAssigned, err := s.prRepo.IsReviewerAssigned(ctx, prID, oldUserID)
@@ -123,38 +385,88 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string
 		return "", nil, fmt.Errorf("%w", my_errors.ErrReviewerIsNotAssigned)
 	}
 
-	oldUser, err := s.userRepo.GetUserByID(ctx, oldUserID)
-	if err != nil {
-		return "", nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	if newUserID != "" {
+		if err := s.checkManualReviewer(ctx, pr, newUserID); err != nil {
+			return "", nil, err
+		}
+		author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w", my_errors.ErrAuthorNotFound)
+		}
+		settings, err := s.assigner.TeamSettings(ctx, author.TeamName)
+		if err != nil {
+			return "", nil, err
+		}
+		// a manual choice is not limited by capacity or skills, but it cannot break the senior reviewer rule
+		if settings.MinSeniorReviewers > 0 {
+			if err := s.checkSeniorsKept(ctx, pr, oldUserID, newUserID, settings); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := s.prRepo.ReassignReviewer(ctx, prID, oldUserID, domain.ReviewerReplacement{UserID: newUserID}, eventSource(ctx, domain.EventReasonManual)); err != nil {
+			return "", nil, fmt.Errorf("failed to reassign reviewer: %w", err)
+		}
+
+		updatedPR, err := s.prRepo.GetPRByID(ctx, prID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get updated PR: %w", err)
+		}
+		audit.Change(ctx, pr, updatedPR)
+		return newUserID, updatedPR, nil
 	}
 
-	activeMembers, err := s.userRepo.GetActiveTeamMembers(ctx, oldUser.TeamName, oldUserID)
+	oldUser, err := s.userRepo.GetUserByID(ctx, oldUserID)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to get active team members: %w", err)
+		return "", nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
 	}
 
-	// Excluding current reviewers
-	availableCandidates := []domain.User{}
-	for _, member := range activeMembers {
-		isCurrentReviewer := false
-		for _, reviewerID := range pr.AssignedReviewers {
-			if member.UserID == reviewerID {
-				isCurrentReviewer = true
-				break
-			}
-		}
-		if !isCurrentReviewer {
-			availableCandidates = append(availableCandidates, member)
+	// Excluding the author and current reviewers
+	exclude := map[string]bool{pr.AuthorID: true, oldUserID: true}
+	keptReviewers := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		exclude[reviewerID] = true
+		if reviewerID != oldUserID {
+			keptReviewers = append(keptReviewers, reviewerID)
 		}
 	}
 
-	if len(availableCandidates) == 0 {
+	settings, err := s.assigner.TeamSettings(ctx, oldUser.TeamName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	assignment, err := s.assigner.Assign(ctx, AssignmentRequest{
+		Settings:       settings,
+		AuthorID:       pr.AuthorID,
+		Exclude:        exclude,
+		ChangedFiles:   pr.ChangedFiles,
+		RequiredSkills: pr.RequiredSkills,
+		KeptReviewers:  keptReviewers,
+		Count:          1,
+		Seed:           s.seeds.NextSeed(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to assign reviewer: %w", err)
+	}
+	if len(assignment.Reviewers) == 0 {
+		if assignment.CapacityExhausted() {
+			return "", nil, fmt.Errorf("at capacity: %v: %w", assignment.AtCapacity, my_errors.ErrReviewersAtCapacity)
+		}
 		return "", nil, fmt.Errorf("%w", my_errors.ErrNoActiveReviewerWasFound)
 	}
+	// a senior can be replaced only by another senior while the team's policy needs one
+	if assignment.SeniorShortfall > 0 && domain.SeniorityAtLeast(oldUser.Seniority, settings.SeniorLevel) {
+		return "", nil, fmt.Errorf("replacing %s leaves fewer than %d reviewers of level %s or above: %w",
+			oldUserID, settings.MinSeniorReviewers, settings.SeniorLevel, my_errors.ErrSeniorReviewerRequired)
+	}
+	newReviewerID := assignment.Reviewers[0]
 
-	newReviewer := availableCandidates[rand.Intn(len(availableCandidates))]
-
-	if err := s.prRepo.ReassignReviewer(ctx, prID, oldUserID, newReviewer.UserID); err != nil {
+	replacement := domain.ReviewerReplacement{
+		Trace:      assignment.Trace(),
+		UserID:     newReviewerID,
+		IsFallback: assignment.FallbackReviewers[newReviewerID],
+	}
+	if err := s.prRepo.ReassignReviewer(ctx, prID, oldUserID, replacement, eventSource(ctx, domain.EventReasonAssignment)); err != nil {
 		return "", nil, fmt.Errorf("failed to reassign reviewer: %w", err)
 	}
 
@@ -162,42 +474,309 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to get updated PR: %w", err)
 	}
+	updatedPR.Assignment = assignment.Report()
+	audit.Change(ctx, pr, updatedPR)
 
-	return newReviewer.UserID, updatedPR, nil
+	return newReviewerID, updatedPR, nil
 }
 
-func (s *PRService) GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+// AddReviewer assigns one more reviewer chosen by the caller
+func (s *PRService) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
 	if userID == "" {
 		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
 	}
 
-	prs, err := s.prRepo.GetPRsByReviewer(ctx, userID)
+	pr, err := s.getOpenPR(ctx, prID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+		return nil, err
+	}
+	if err := s.checkManualReviewer(ctx, pr, userID); err != nil {
+		return nil, err
 	}
 
-	return prs, nil
+	if err := s.prRepo.AddReviewer(ctx, prID, userID, eventSource(ctx, domain.EventReasonManual)); err != nil {
+		return nil, fmt.Errorf("failed to add reviewer: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	audit.Change(ctx, pr, updatedPR)
+
+	return updatedPR, nil
 }
 
-func selectRandomReviewers(users []domain.User, count int) []string {
-	if len(users) <= count {
-		result := make([]string, len(users))
-		for i, u := range users {
-			result[i] = u.UserID
+// RemoveReviewer unassigns the reviewer without a replacement.
+// The PR has to keep at least min_reviewers and min_senior_reviewers of the author's team
+func (s *PRService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetPR, prID)
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+
+	pr, err := s.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(pr.AssignedReviewers, userID) {
+		return nil, fmt.Errorf("%w", my_errors.ErrReviewerIsNotAssigned)
+	}
+
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrAuthorNotFound)
+	}
+	settings, err := s.assigner.TeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.AssignedReviewers)-1 < settings.MinReviewers {
+		return nil, fmt.Errorf("team %s requires %d reviewers: %w",
+			author.TeamName, settings.MinReviewers, my_errors.ErrNotEnoughReviewers)
+	}
+	if settings.MinSeniorReviewers > 0 {
+		if err := s.checkSeniorsKept(ctx, pr, userID, "", settings); err != nil {
+			return nil, err
 		}
-		return result
 	}
 
-	selected := make(map[int]bool)
-	result := []string{}
+	if err := s.prRepo.RemoveReviewer(ctx, prID, userID, eventSource(ctx, domain.EventReasonManual)); err != nil {
+		return nil, fmt.Errorf("failed to remove reviewer: %w", err)
+	}
 
-	for len(result) < count {
-		idx := rand.Intn(len(users))
-		if !selected[idx] {
-			selected[idx] = true
-			result = append(result, users[idx].UserID)
+	updatedPR, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	audit.Change(ctx, pr, updatedPR)
+
+	return updatedPR, nil
+}
+
+// checkSeniorsKept fails when removing a senior reviewer (replaced by addedID unless it is empty) leaves
+// the PR with fewer reviewers of the team's senior level than the team requires
+func (s *PRService) checkSeniorsKept(ctx context.Context, pr *domain.PullRequest, removedID, addedID string, settings *domain.TeamSettings) error {
+	removed, err := s.userRepo.GetUserByID(ctx, removedID)
+	if err != nil {
+		return fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+	if !domain.SeniorityAtLeast(removed.Seniority, settings.SeniorLevel) {
+		return nil
+	}
+
+	reviewers := pr.AssignedReviewers
+	if addedID != "" {
+		reviewers = append(slices.Clone(reviewers), addedID)
+	}
+
+	seniors := 0
+	for _, reviewerID := range reviewers {
+		if reviewerID == removedID {
+			continue
+		}
+		reviewer, err := s.userRepo.GetUserByID(ctx, reviewerID)
+		if err != nil {
+			return fmt.Errorf("failed to get reviewer %s: %w", reviewerID, err)
 		}
+		if domain.SeniorityAtLeast(reviewer.Seniority, settings.SeniorLevel) {
+			seniors++
+		}
+	}
+	if seniors < settings.MinSeniorReviewers {
+		return fmt.Errorf("removing %s leaves fewer than %d reviewers of level %s or above: %w",
+			removedID, settings.MinSeniorReviewers, settings.SeniorLevel, my_errors.ErrSeniorReviewerRequired)
+	}
+	return nil
+}
+
+// getOpenPR returns the PR if its reviewers can still be changed
+func (s *PRService) getOpenPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	if pr.Status == domain.StatusMerged {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRAlreadyMerged)
+	}
+	if pr.Status != domain.StatusOpen {
+		return nil, fmt.Errorf("%s: %w", pr.Status, my_errors.ErrPRNotOpen)
+	}
+
+	return pr, nil
+}
+
+// checkManualReviewer validates a reviewer chosen by the caller instead of the team's strategy.
+// Capacity, availability and skills are not checked for such reviewers
+func (s *PRService) checkManualReviewer(ctx context.Context, pr *domain.PullRequest, userID string) error {
+	if userID == pr.AuthorID {
+		return fmt.Errorf("%w", my_errors.ErrReviewerIsAuthor)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+	if !user.IsActive {
+		return fmt.Errorf("%s: %w", userID, my_errors.ErrUserIsNotActive)
 	}
 
-	return result
+	if slices.Contains(pr.AssignedReviewers, userID) {
+		return fmt.Errorf("%w", my_errors.ErrReviewerAlreadyAssigned)
+	}
+
+	return nil
+}
+
+// ExplainAssignment replays the initial reviewer pick of the PR and the later replacements
+// picked by a strategy from their recorded seeds and candidates
+func (s *PRService) ExplainAssignment(ctx context.Context, prID string) (*domain.AssignmentExplanation, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+
+	exists, err := s.prRepo.PRExists(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PR existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	trace, err := s.prRepo.GetAssignmentTrace(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment trace: %w", err)
+	}
+	if trace == nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrAssignmentTraceNotFound)
+	}
+
+	replayed, err := s.assigner.Replay(trace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay assignment: %w", err)
+	}
+
+	events, err := s.prRepo.GetReassignmentTraces(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassignment traces: %w", err)
+	}
+	reassignments := make([]domain.ReassignmentExplanation, 0, len(events))
+	for _, event := range events {
+		eventReplayed, err := s.assigner.Replay(event.AssignmentTrace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay reassignment: %w", err)
+		}
+		reassignments = append(reassignments, domain.ReassignmentExplanation{
+			Event:        event,
+			Replayed:     eventReplayed,
+			Reproducible: slices.Equal(eventReplayed, event.AssignmentTrace.Reviewers),
+		})
+	}
+
+	return &domain.AssignmentExplanation{
+		Trace:         trace,
+		PullRequestID: prID,
+		Replayed:      replayed,
+		Reassignments: reassignments,
+		Reproducible:  slices.Equal(replayed, trace.Reviewers),
+	}, nil
+}
+
+func (s *PRService) GetPR(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	return pr, nil
+}
+
+// GetTimeline returns the history of the PR, oldest events first
+func (s *PRService) GetTimeline(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("pull_request_id: %w", my_errors.ErrEmptyField)
+	}
+
+	exists, err := s.prRepo.PRExists(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PR existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrPRNotFound)
+	}
+
+	events, err := s.prRepo.GetPREvents(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListPRs returns one page of PRs matching the filter, newest first
+func (s *PRService) ListPRs(ctx context.Context, filter domain.PRFilter) (*domain.PRPage, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = domain.DefaultPRListLimit
+	case filter.Limit < 0 || filter.Limit > domain.MaxPRListLimit:
+		return nil, fmt.Errorf("limit must be between 1 and %d: %w", domain.MaxPRListLimit, my_errors.ErrInvalidInput)
+	}
+	switch filter.Status {
+	case "", domain.StatusOpen, domain.StatusMerged, domain.StatusClosed, domain.StatusDraft:
+	default:
+		return nil, fmt.Errorf("status must be OPEN, MERGED, CLOSED or DRAFT: %w", my_errors.ErrInvalidInput)
+	}
+
+	// one extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.prRepo.ListPRs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	page := &domain.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		page.NextCursor = domain.PRCursor{CreatedAt: *last.CreatedAt, PullRequestID: last.PullRequestID}.Encode()
+	}
+	return page, nil
+}
+
+func (s *PRService) GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+
+	prs, err := s.prRepo.GetPRsByReviewer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+
+	return prs, nil
+}
+
+// eventSource attributes PR events to the authenticated user of the request.
+// Background jobs have no user, their events are stored without an actor
+func eventSource(ctx context.Context, reason string) domain.EventSource {
+	return domain.EventSource{ActorID: actorID(ctx), Reason: reason}
+}
+
+// actorID returns the authenticated user of the request, empty for background jobs
+func actorID(ctx context.Context) string {
+	userID, _ := ctx.Value(middleware.UserIDKey).(string)
+	return userID
 }
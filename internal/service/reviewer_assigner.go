@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"pr-reviewer-service/internal/codeowners"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// Candidate tiers: code owners of the changed files are picked before other team members,
+// members of fallback teams are picked last, in the order of the fallback priority
+const (
+	tierCodeOwner = iota
+	tierTeam
+	tierFallback
+)
+
+// AssignmentRequest describes which reviewers are needed
+type AssignmentRequest struct {
+	// Settings of the team the reviewers are picked from
+	Settings *domain.TeamSettings
+	// AuthorID is used to avoid reviewers who recently reviewed the same author
+	AuthorID string
+	// Exclude contains users that must not be picked (author, current reviewers, etc.)
+	Exclude map[string]bool
+	// PendingLoad contains assignments that are made but not stored yet (used by batch operations)
+	PendingLoad map[string]int
+	// ChangedFiles are used to find code owners of the PR
+	ChangedFiles []string
+	// RequiredSkills are preferred to be covered by the picked reviewers
+	RequiredSkills []string
+	// KeptReviewers stay on the PR, the skills they cover are not required from the picked reviewers
+	KeptReviewers []string
+	Count         int
+	// Seed of the random generator used for the pick
+	Seed int64
+	// Now is the moment candidates must be available at, the current time when zero
+	Now time.Time
+}
+
+// ReviewerAssigner selects reviewers among active team members using the team's settings.
+// It is shared by PR creation, reassignment and batch deactivation
+type ReviewerAssigner struct {
+	userRepo         UserRepositoryForAssign
+	loadRepo         ReviewerLoadRepository
+	settingsRepo     TeamSettingsRepository
+	codeOwnersRepo   CodeOwnersRepository
+	availabilityRepo AvailabilityRepositoryForAssign
+}
+
+func NewReviewerAssigner(
+	userRepo UserRepositoryForAssign,
+	loadRepo ReviewerLoadRepository,
+	settingsRepo TeamSettingsRepository,
+	codeOwnersRepo CodeOwnersRepository,
+	availabilityRepo AvailabilityRepositoryForAssign,
+) *ReviewerAssigner {
+	return &ReviewerAssigner{
+		userRepo:         userRepo,
+		loadRepo:         loadRepo,
+		settingsRepo:     settingsRepo,
+		codeOwnersRepo:   codeOwnersRepo,
+		availabilityRepo: availabilityRepo,
+	}
+}
+
+func (a *ReviewerAssigner) TeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	settings, err := a.settingsRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (a *ReviewerAssigner) Assign(ctx context.Context, req AssignmentRequest) (*domain.Assignment, error) {
+	selector, err := NewReviewerSelector(req.Settings.ReviewerStrategy)
+	if err != nil {
+		return nil, err
+	}
+	if req.Now.IsZero() {
+		req.Now = time.Now()
+	}
+
+	requiredSkills, keptSeniors, err := a.keptCoverage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	seniorsNeeded := max(req.Settings.MinSeniorReviewers-keptSeniors, 0)
+
+	assignment := &domain.Assignment{
+		FallbackReviewers: make(map[string]bool),
+		Strategy:          selector.Name(),
+		Reviewers:         []string{},
+		AtCapacity:        []string{},
+		Candidates:        []domain.ReviewerCandidate{},
+		Requested:         req.Count,
+		Seed:              req.Seed,
+		RequiredSkills:    requiredSkills,
+		MissingSkills:     requiredSkills,
+		SeniorLevel:       req.Settings.SeniorLevel,
+		SeniorsNeeded:     seniorsNeeded,
+		SeniorShortfall:   seniorsNeeded,
+	}
+	if req.Count <= 0 {
+		return assignment, nil
+	}
+
+	members, err := a.userRepo.GetActiveTeamMembers(ctx, req.Settings.TeamName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active team members: %w", err)
+	}
+
+	tiers := make(map[string]int)
+	for _, member := range members {
+		if !req.Exclude[member.UserID] {
+			tiers[member.UserID] = tierTeam
+		}
+	}
+
+	// restricted is set when only code owners may review the PR
+	restricted := false
+	if req.Settings.CodeOwnersMode != domain.CodeOwnersOff && len(req.ChangedFiles) > 0 {
+		owners, hasRules, err := a.findCodeOwners(ctx, req.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+
+		// when owners are required, only they can review files covered by the rules
+		if req.Settings.CodeOwnersMode == domain.CodeOwnersRequire && hasRules {
+			tiers = make(map[string]int)
+			restricted = true
+		}
+		for _, userID := range owners {
+			if !req.Exclude[userID] {
+				tiers[userID] = tierCodeOwner
+			}
+		}
+	}
+
+	candidates, atCapacity, err := a.buildCandidates(ctx, tiers, req.PendingLoad, req.Settings.MaxOpenReviews, req.Now)
+	if err != nil {
+		return nil, err
+	}
+	assignment.AtCapacity = append(assignment.AtCapacity, atCapacity...)
+
+	// top up from the fallback pools when the team alone cannot provide enough reviewers or seniors
+	if (len(candidates) < req.Count || countSeniors(candidates, req.Settings.SeniorLevel) < seniorsNeeded) && !restricted {
+		fallbackCandidates, atCapacity, err := a.fallbackCandidates(ctx, req, tiers)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, fallbackCandidates...)
+		assignment.AtCapacity = append(assignment.AtCapacity, atCapacity...)
+	}
+
+	if err := a.setRecentPairings(ctx, req, candidates); err != nil {
+		return nil, err
+	}
+	if candidates != nil {
+		assignment.Candidates = candidates
+	}
+
+	rng := rand.New(rand.NewSource(req.Seed))
+	picked, missingSkills, seniorsPicked := pickReviewers(
+		selector, rng, candidates, req.Count, requiredSkills, req.Settings.SeniorLevel, seniorsNeeded,
+	)
+	assignment.MissingSkills = missingSkills
+	assignment.SeniorShortfall = max(seniorsNeeded-seniorsPicked, 0)
+	for _, selected := range picked {
+		assignment.Reviewers = append(assignment.Reviewers, selected.UserID)
+		if selected.Tier >= tierFallback {
+			assignment.FallbackReviewers[selected.UserID] = true
+		}
+	}
+
+	return assignment, nil
+}
+
+// Replay repeats a recorded pick using the stored candidates and seed
+func (a *ReviewerAssigner) Replay(trace *domain.AssignmentTrace) ([]string, error) {
+	selector, err := NewReviewerSelector(trace.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(trace.Seed))
+	reviewers := []string{}
+	picked, _, _ := pickReviewers(
+		selector, rng, trace.Candidates, trace.Count, trace.RequiredSkills, trace.SeniorLevel, trace.SeniorsNeeded,
+	)
+	for _, selected := range picked {
+		reviewers = append(reviewers, selected.UserID)
+	}
+	return reviewers, nil
+}
+
+// setRecentPairings counts how many of the author's latest PRs each candidate reviewed
+func (a *ReviewerAssigner) setRecentPairings(ctx context.Context, req AssignmentRequest, candidates []domain.ReviewerCandidate) error {
+	if req.AuthorID == "" || req.Settings.PairingLookback <= 0 || len(candidates) == 0 {
+		return nil
+	}
+
+	pairings, err := a.loadRepo.GetRecentPairings(ctx, req.AuthorID, req.Settings.PairingLookback)
+	if err != nil {
+		return fmt.Errorf("failed to get recent pairings: %w", err)
+	}
+	for i := range candidates {
+		candidates[i].RecentPairings = pairings[candidates[i].UserID]
+	}
+	return nil
+}
+
+// keptCoverage returns the required skills that none of the kept reviewers has
+// and the number of kept reviewers of the team's senior level or above
+func (a *ReviewerAssigner) keptCoverage(ctx context.Context, req AssignmentRequest) ([]string, int, error) {
+	if len(req.KeptReviewers) == 0 {
+		return req.RequiredSkills, 0, nil
+	}
+
+	loads, err := a.loadRepo.GetReviewerLoads(ctx, req.KeptReviewers)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get kept reviewers: %w", err)
+	}
+
+	skills := []string{}
+	for _, skill := range req.RequiredSkills {
+		covered := false
+		for _, userID := range req.KeptReviewers {
+			if loads[userID].Covers(skill) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			skills = append(skills, skill)
+		}
+	}
+
+	seniors := 0
+	for _, userID := range req.KeptReviewers {
+		if loads[userID].IsAtLeast(req.Settings.SeniorLevel) {
+			seniors++
+		}
+	}
+	return skills, seniors, nil
+}
+
+// fallbackCandidates returns available members of the team's fallback pools and members at their limits.
+// Users already present in tiers are skipped
+func (a *ReviewerAssigner) fallbackCandidates(
+	ctx context.Context,
+	req AssignmentRequest,
+	tiers map[string]int,
+) ([]domain.ReviewerCandidate, []string, error) {
+	fallbackTeams, err := a.settingsRepo.GetFallbackTeams(ctx, req.Settings.TeamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+
+	var result []domain.ReviewerCandidate
+	var atCapacity []string
+	for priority, teamName := range fallbackTeams {
+		settings, err := a.TeamSettings(ctx, teamName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		members, err := a.userRepo.GetActiveTeamMembers(ctx, teamName, "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get fallback team members: %w", err)
+		}
+
+		teamTiers := make(map[string]int)
+		for _, member := range members {
+			if _, ok := tiers[member.UserID]; ok || req.Exclude[member.UserID] {
+				continue
+			}
+			teamTiers[member.UserID] = tierFallback + priority
+			tiers[member.UserID] = tierFallback + priority
+		}
+
+		candidates, teamAtCapacity, err := a.buildCandidates(ctx, teamTiers, req.PendingLoad, settings.MaxOpenReviews, req.Now)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, candidates...)
+		atCapacity = append(atCapacity, teamAtCapacity...)
+	}
+
+	return result, atCapacity, nil
+}
+
+// buildCandidates loads the review load of the users and drops those who are away at now.
+// Users who reached their own or the team's maxOpenReviews are returned separately
+func (a *ReviewerAssigner) buildCandidates(
+	ctx context.Context,
+	tiers map[string]int,
+	pendingLoad map[string]int,
+	teamMaxOpenReviews *int,
+	now time.Time,
+) ([]domain.ReviewerCandidate, []string, error) {
+	if len(tiers) == 0 {
+		return nil, nil, nil
+	}
+
+	userIDs := make([]string, 0, len(tiers))
+	for userID := range tiers {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	away, err := a.availabilityRepo.GetAwayUsers(ctx, userIDs, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get away users: %w", err)
+	}
+
+	loads, err := a.loadRepo.GetReviewerLoads(ctx, userIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reviewer loads: %w", err)
+	}
+
+	candidates := make([]domain.ReviewerCandidate, 0, len(userIDs))
+	var atCapacity []string
+	for _, userID := range userIDs {
+		if away[userID] {
+			continue
+		}
+
+		load := loads[userID]
+		load.OpenReviews += pendingLoad[userID]
+
+		// users at their own or the team's review limit are not picked
+		if atLimit(load.OpenReviews, load.MaxOpenReviews) || atLimit(load.OpenReviews, teamMaxOpenReviews) {
+			atCapacity = append(atCapacity, userID)
+			continue
+		}
+
+		candidates = append(candidates, domain.ReviewerCandidate{
+			ReviewerLoad: load,
+			UserID:       userID,
+			Tier:         tiers[userID],
+		})
+	}
+
+	return candidates, atCapacity, nil
+}
+
+func atLimit(openReviews int, limit *int) bool {
+	return limit != nil && openReviews >= *limit
+}
+
+// findCodeOwners returns active owners of the changed files.
+// hasRules reports whether any of the files is covered by a rule with owners
+func (a *ReviewerAssigner) findCodeOwners(ctx context.Context, changedFiles []string) ([]string, bool, error) {
+	rules, err := a.codeOwnersRepo.GetRules(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get code owner rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, false, nil
+	}
+
+	matcher, err := codeowners.NewMatcher(rules)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ownerUsers := make(map[string]bool)
+	ownerTeams := make(map[string]bool)
+	for _, file := range changedFiles {
+		rule := matcher.Match(file)
+		if rule == nil {
+			continue
+		}
+		for _, userID := range rule.Users {
+			ownerUsers[userID] = true
+		}
+		for _, teamName := range rule.Teams {
+			ownerTeams[teamName] = true
+		}
+	}
+	hasRules := len(ownerUsers) > 0 || len(ownerTeams) > 0
+
+	owners := make(map[string]bool)
+	for teamName := range ownerTeams {
+		members, err := a.userRepo.GetActiveTeamMembers(ctx, teamName, "")
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get owner team members: %w", err)
+		}
+		for _, member := range members {
+			owners[member.UserID] = true
+		}
+	}
+
+	userIDs := make([]string, 0, len(ownerUsers))
+	for userID := range ownerUsers {
+		userIDs = append(userIDs, userID)
+	}
+	activeUsers, err := a.userRepo.GetActiveUsers(ctx, userIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get owner users: %w", err)
+	}
+	for _, user := range activeUsers {
+		owners[user.UserID] = true
+	}
+
+	result := make([]string, 0, len(owners))
+	for userID := range owners {
+		result = append(result, userID)
+	}
+	return result, hasRules, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeastLoadedSelectorWeighsLoad(t *testing.T) {
+	withWeight := func(c domain.ReviewerCandidate, weight float64) domain.ReviewerCandidate {
+		c.Weight = weight
+		return c
+	}
+
+	assertSelects(t, leastLoadedSelector{}, []selectCase{
+		{
+			name: "load is relative to weight",
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 2),
+				withWeight(candidate("u2", 3), 2),
+				withWeight(candidate("u3", 1), 0.25),
+			},
+			count: 3,
+			want:  []string{"u2", "u1", "u3"},
+		},
+		{
+			name: "missing weight counts as default",
+			candidates: []domain.ReviewerCandidate{
+				withWeight(candidate("u1", 2), 0),
+				candidate("u2", 1),
+			},
+			count: 1,
+			want:  []string{"u2"},
+		},
+	})
+}
+
+func TestRandomSelectorsFollowReviewWeight(t *testing.T) {
+	assertShares(t, []shareCase{
+		{
+			name:     "random follows review weight",
+			selector: randomSelector{},
+			candidates: []domain.ReviewerCandidate{
+				{UserID: "u1", ReviewerLoad: domain.ReviewerLoad{Weight: 3}},
+				candidate("u2", 0),
+			},
+			share: 0.75,
+		},
+		{
+			name:     "weighted combines weight and load",
+			selector: weightedSelector{},
+			candidates: []domain.ReviewerCandidate{
+				{UserID: "u1", ReviewerLoad: domain.ReviewerLoad{Weight: 4, OpenReviews: 1}},
+				candidate("u2", 0),
+			},
+			share: 2.0 / 3,
+		},
+	})
+}
+
+func TestAtLimit(t *testing.T) {
+	limit := 2
+	assert.False(t, atLimit(5, nil))
+	assert.False(t, atLimit(1, &limit))
+	assert.True(t, atLimit(2, &limit))
+	assert.True(t, atLimit(3, &limit))
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+func TestPickReviewersByTier(t *testing.T) {
+	withTier := func(c domain.ReviewerCandidate, tier int) domain.ReviewerCandidate {
+		c.Tier = tier
+		return c
+	}
+
+	assertPicks(t, []pickCase{
+		{
+			name: "lower tier first",
+			candidates: []domain.ReviewerCandidate{
+				withTier(candidate("f1", 0), tierFallback),
+				withTier(candidate("u1", 3), tierTeam),
+				withTier(candidate("u2", 5), tierTeam),
+			},
+			count:       2,
+			want:        []string{"u1", "u2"},
+			wantMissing: []string{},
+		},
+		{
+			name: "higher tier tops up",
+			candidates: []domain.ReviewerCandidate{
+				withTier(candidate("f2", 0), tierFallback+1),
+				withTier(candidate("f1", 1), tierFallback),
+				withTier(candidate("u1", 3), tierTeam),
+			},
+			count:       2,
+			want:        []string{"u1", "f1"},
+			wantMissing: []string{},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+func TestLeastLoadedSelectorAvoidsRecentPairings(t *testing.T) {
+	withPairings := func(c domain.ReviewerCandidate, pairings int) domain.ReviewerCandidate {
+		c.RecentPairings = pairings
+		return c
+	}
+
+	assertSelects(t, leastLoadedSelector{}, []selectCase{
+		{
+			name: "equal load is broken by fewer recent pairings",
+			candidates: []domain.ReviewerCandidate{
+				withPairings(candidate("u1", 0), 1),
+				withPairings(candidate("u2", 1), 0),
+				withPairings(candidate("u3", 0), 0),
+			},
+			count: 2,
+			want:  []string{"u3", "u1"},
+		},
+	})
+}
+
+func TestRandomSelectorsAvoidRecentPairings(t *testing.T) {
+	assertShares(t, []shareCase{
+		{
+			name:     "random lowers recent pairings",
+			selector: randomSelector{},
+			candidates: []domain.ReviewerCandidate{
+				{UserID: "u1", ReviewerLoad: domain.ReviewerLoad{Weight: 1}, RecentPairings: 3},
+				candidate("u2", 0),
+			},
+			share: 0.2,
+		},
+	})
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// ReviewerSelector picks up to count reviewers out of the candidates
+type ReviewerSelector interface {
+	Name() string
+	// Select must use only rng as a source of randomness, so that a pick can be replayed from its seed
+	Select(rng *rand.Rand, candidates []domain.ReviewerCandidate, count int) []domain.ReviewerCandidate
+}
+
+// NewReviewerSelector returns the selector for the given strategy name
+func NewReviewerSelector(strategy string) (ReviewerSelector, error) {
+	switch strategy {
+	case domain.StrategyRandom:
+		return randomSelector{}, nil
+	case domain.StrategyRoundRobin:
+		return roundRobinSelector{}, nil
+	case domain.StrategyLeastLoaded:
+		return leastLoadedSelector{}, nil
+	case domain.StrategyWeighted:
+		return weightedSelector{}, nil
+	default:
+		return nil, fmt.Errorf("%s: %w", strategy, my_errors.ErrInvalidReviewerStrategy)
+	}
+}
+
+// pickReviewers makes the whole pick: up to seniorsNeeded reviewers of seniorLevel or above
+// are picked first, then the other seats. Skill coverage is preferred in both steps.
+// It returns the picked reviewers, the skills none of them has and the number of picked seniors
+func pickReviewers(
+	selector ReviewerSelector,
+	rng *rand.Rand,
+	candidates []domain.ReviewerCandidate,
+	count int,
+	requiredSkills []string,
+	seniorLevel string,
+	seniorsNeeded int,
+) ([]domain.ReviewerCandidate, []string, int) {
+	var result []domain.ReviewerCandidate
+	rest := candidates
+	uncovered := requiredSkills
+	if seniorsNeeded > 0 {
+		var seniors []domain.ReviewerCandidate
+		for _, c := range candidates {
+			if c.IsAtLeast(seniorLevel) {
+				seniors = append(seniors, c)
+			}
+		}
+
+		result, uncovered = selectReviewers(selector, rng, seniors, min(seniorsNeeded, count), requiredSkills)
+		for _, picked := range result {
+			rest = withoutCandidate(rest, picked.UserID)
+		}
+	}
+
+	others, missing := selectReviewers(selector, rng, rest, count-len(result), uncovered)
+	result = append(result, others...)
+
+	return result, missing, countSeniors(result, seniorLevel)
+}
+
+func countSeniors(candidates []domain.ReviewerCandidate, seniorLevel string) int {
+	seniors := 0
+	for _, c := range candidates {
+		if c.IsAtLeast(seniorLevel) {
+			seniors++
+		}
+	}
+	return seniors
+}
+
+// selectReviewers picks up to count reviewers covering the required skills first. While some skill
+// is not covered, the candidates covering most of the remaining skills compete (lower tier first)
+// and the selector decides among them. The other seats are filled by tier.
+// Skills that none of the picked reviewers has are returned as missing
+func selectReviewers(
+	selector ReviewerSelector,
+	rng *rand.Rand,
+	candidates []domain.ReviewerCandidate,
+	count int,
+	requiredSkills []string,
+) ([]domain.ReviewerCandidate, []string) {
+	uncovered := make([]string, len(requiredSkills))
+	copy(uncovered, requiredSkills)
+
+	result := make([]domain.ReviewerCandidate, 0, count)
+	rest := candidates
+	for len(result) < count && len(uncovered) > 0 {
+		var best []domain.ReviewerCandidate
+		bestCovered := 0
+		for _, c := range rest {
+			covered := coveredSkills(c, uncovered)
+			switch {
+			case covered == 0:
+				continue
+			case covered > bestCovered || (covered == bestCovered && c.Tier < best[0].Tier):
+				best = []domain.ReviewerCandidate{c}
+				bestCovered = covered
+			case covered == bestCovered && c.Tier == best[0].Tier:
+				best = append(best, c)
+			}
+		}
+		if len(best) == 0 {
+			break
+		}
+
+		picked := selector.Select(rng, best, 1)[0]
+		result = append(result, picked)
+		rest = withoutCandidate(rest, picked.UserID)
+
+		remaining := uncovered[:0]
+		for _, skill := range uncovered {
+			if !picked.Covers(skill) {
+				remaining = append(remaining, skill)
+			}
+		}
+		uncovered = remaining
+	}
+
+	result = append(result, selectByTier(selector, rng, rest, count-len(result))...)
+
+	// reviewers picked by tier may cover the skills too
+	missing := []string{}
+	for _, skill := range uncovered {
+		coveredByPicked := false
+		for _, c := range result {
+			if c.Covers(skill) {
+				coveredByPicked = true
+				break
+			}
+		}
+		if !coveredByPicked {
+			missing = append(missing, skill)
+		}
+	}
+	return result, missing
+}
+
+func coveredSkills(c domain.ReviewerCandidate, skills []string) int {
+	covered := 0
+	for _, skill := range skills {
+		if c.Covers(skill) {
+			covered++
+		}
+	}
+	return covered
+}
+
+func withoutCandidate(candidates []domain.ReviewerCandidate, userID string) []domain.ReviewerCandidate {
+	result := make([]domain.ReviewerCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.UserID != userID {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// selectByTier fills the selection from the lowest tier first, applying the selector inside each tier
+func selectByTier(
+	selector ReviewerSelector,
+	rng *rand.Rand,
+	candidates []domain.ReviewerCandidate,
+	count int,
+) []domain.ReviewerCandidate {
+	byTier := make(map[int][]domain.ReviewerCandidate)
+	tiers := []int{}
+	for _, c := range candidates {
+		if _, ok := byTier[c.Tier]; !ok {
+			tiers = append(tiers, c.Tier)
+		}
+		byTier[c.Tier] = append(byTier[c.Tier], c)
+	}
+	sort.Ints(tiers)
+
+	result := make([]domain.ReviewerCandidate, 0, count)
+	for _, tier := range tiers {
+		if len(result) >= count {
+			break
+		}
+		result = append(result, selector.Select(rng, byTier[tier], count-len(result))...)
+	}
+	return result
+}
+
+// randomSelector picks candidates at random, proportionally to their review weight
+type randomSelector struct{}
+
+func (randomSelector) Name() string {
+	return domain.StrategyRandom
+}
+
+func (randomSelector) Select(rng *rand.Rand, candidates []domain.ReviewerCandidate, count int) []domain.ReviewerCandidate {
+	return weightedPick(rng, candidates, count, reviewWeight)
+}
+
+// roundRobinSelector picks the candidates that were assigned the longest time ago.
+// Candidates who have never been assigned go first. Review weights are not used here
+type roundRobinSelector struct{}
+
+func (roundRobinSelector) Name() string {
+	return domain.StrategyRoundRobin
+}
+
+func (roundRobinSelector) Select(rng *rand.Rand, candidates []domain.ReviewerCandidate, count int) []domain.ReviewerCandidate {
+	result := shuffled(rng, candidates)
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].LastAssignedAt, result[j].LastAssignedAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+	return firstN(result, count)
+}
+
+// leastLoadedSelector picks the candidates with the fewest OPEN review assignments relative to their review weight.
+// Ties are broken by fewer recent reviews of the author, then randomly
+type leastLoadedSelector struct{}
+
+func (leastLoadedSelector) Name() string {
+	return domain.StrategyLeastLoaded
+}
+
+func (leastLoadedSelector) Select(rng *rand.Rand, candidates []domain.ReviewerCandidate, count int) []domain.ReviewerCandidate {
+	result := shuffled(rng, candidates)
+	sort.SliceStable(result, func(i, j int) bool {
+		a := float64(result[i].OpenReviews) / reviewWeight(result[i])
+		b := float64(result[j].OpenReviews) / reviewWeight(result[j])
+		if a != b {
+			return a < b
+		}
+		return result[i].RecentPairings < result[j].RecentPairings
+	})
+	return firstN(result, count)
+}
+
+// weightedSelector picks candidates randomly with probability proportional to their review weight
+// and inversely proportional to their load
+type weightedSelector struct{}
+
+func (weightedSelector) Name() string {
+	return domain.StrategyWeighted
+}
+
+func (weightedSelector) Select(rng *rand.Rand, candidates []domain.ReviewerCandidate, count int) []domain.ReviewerCandidate {
+	return weightedPick(rng, candidates, count, candidateWeight)
+}
+
+// weightedPick draws up to count distinct candidates with probability proportional to weight
+func weightedPick(
+	rng *rand.Rand,
+	candidates []domain.ReviewerCandidate,
+	count int,
+	weight func(domain.ReviewerCandidate) float64,
+) []domain.ReviewerCandidate {
+	pool := make([]domain.ReviewerCandidate, len(candidates))
+	copy(pool, candidates)
+
+	result := make([]domain.ReviewerCandidate, 0, count)
+	for len(result) < count && len(pool) > 0 {
+		total := 0.0
+		for _, c := range pool {
+			total += weight(c)
+		}
+
+		point := rng.Float64() * total
+		idx := len(pool) - 1
+		for i, c := range pool {
+			point -= weight(c)
+			if point < 0 {
+				idx = i
+				break
+			}
+		}
+
+		result = append(result, pool[idx])
+		pool = append(pool[:idx], pool[idx+1:]...)
+	}
+
+	return result
+}
+
+func candidateWeight(c domain.ReviewerCandidate) float64 {
+	return reviewWeight(c) / float64(1+c.OpenReviews)
+}
+
+// reviewWeight returns the user's review weight (the default for candidates without one)
+// lowered for those who recently reviewed the same author
+func reviewWeight(c domain.ReviewerCandidate) float64 {
+	weight := c.Weight
+	if weight <= 0 {
+		weight = domain.DefaultReviewWeight
+	}
+	return weight / float64(1+c.RecentPairings)
+}
+
+func shuffled(rng *rand.Rand, candidates []domain.ReviewerCandidate) []domain.ReviewerCandidate {
+	result := make([]domain.ReviewerCandidate, len(candidates))
+	copy(result, candidates)
+	rng.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result
+}
+
+func firstN(candidates []domain.ReviewerCandidate, n int) []domain.ReviewerCandidate {
+	if len(candidates) > n {
+		return candidates[:n]
+	}
+	return candidates
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/my_errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func candidate(userID string, openReviews int) domain.ReviewerCandidate {
+	return domain.ReviewerCandidate{
+		UserID:       userID,
+		ReviewerLoad: domain.ReviewerLoad{OpenReviews: openReviews, Weight: domain.DefaultReviewWeight},
+	}
+}
+
+func userIDs(candidates []domain.ReviewerCandidate) []string {
+	result := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.UserID)
+	}
+	return result
+}
+
+// selectCase is a case of a selector picking from the candidates with a fixed seed
+type selectCase struct {
+	name       string
+	candidates []domain.ReviewerCandidate
+	count      int
+	want       []string
+}
+
+func assertSelects(t *testing.T, selector ReviewerSelector, tests []selectCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selector.Select(rand.New(rand.NewSource(1)), tt.candidates, tt.count)
+			assert.Equal(t, tt.want, userIDs(got))
+		})
+	}
+}
+
+// shareCase is a case of a random selector, share is the expected share of draws won by the first candidate
+type shareCase struct {
+	name       string
+	selector   ReviewerSelector
+	candidates []domain.ReviewerCandidate
+	share      float64
+}
+
+func assertShares(t *testing.T, tests []shareCase) {
+	t.Helper()
+	const draws = 2000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			won := 0
+			for seed := int64(0); seed < draws; seed++ {
+				picked := tt.selector.Select(rand.New(rand.NewSource(seed)), tt.candidates, 1)
+				require.Len(t, picked, 1)
+				if picked[0].UserID == tt.candidates[0].UserID {
+					won++
+				}
+			}
+			assert.InDelta(t, tt.share, float64(won)/draws, 0.05)
+		})
+	}
+}
+
+// pickCase is a case of pickReviewers with the least loaded selector, seniors are of the senior level or above
+type pickCase struct {
+	name           string
+	candidates     []domain.ReviewerCandidate
+	count          int
+	requiredSkills []string
+	seniorsNeeded  int
+	want           []string
+	wantMissing    []string
+	wantSeniors    int
+}
+
+func assertPicks(t *testing.T, tests []pickCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missing, seniors := pickReviewers(
+				leastLoadedSelector{},
+				rand.New(rand.NewSource(1)),
+				tt.candidates,
+				tt.count,
+				tt.requiredSkills,
+				domain.SenioritySenior,
+				tt.seniorsNeeded,
+			)
+			assert.Equal(t, tt.want, userIDs(got))
+			assert.Equal(t, tt.wantMissing, missing)
+			assert.Equal(t, tt.wantSeniors, seniors)
+		})
+	}
+}
+
+func TestNewReviewerSelector(t *testing.T) {
+	for _, strategy := range []string{
+		domain.StrategyRandom,
+		domain.StrategyRoundRobin,
+		domain.StrategyLeastLoaded,
+		domain.StrategyWeighted,
+	} {
+		selector, err := NewReviewerSelector(strategy)
+		require.NoError(t, err)
+		assert.Equal(t, strategy, selector.Name())
+	}
+
+	_, err := NewReviewerSelector("fastest")
+	assert.ErrorIs(t, err, my_errors.ErrInvalidReviewerStrategy)
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	assignedAt := func(c domain.ReviewerCandidate, ago time.Duration) domain.ReviewerCandidate {
+		at := now.Add(-ago)
+		c.LastAssignedAt = &at
+		return c
+	}
+
+	assertSelects(t, roundRobinSelector{}, []selectCase{
+		{
+			name: "oldest assignment first",
+			candidates: []domain.ReviewerCandidate{
+				assignedAt(candidate("u1", 0), time.Hour),
+				assignedAt(candidate("u2", 0), 3*time.Hour),
+				assignedAt(candidate("u3", 0), 2*time.Hour),
+			},
+			count: 3,
+			want:  []string{"u2", "u3", "u1"},
+		},
+		{
+			name: "never assigned go first",
+			candidates: []domain.ReviewerCandidate{
+				assignedAt(candidate("u1", 0), 3*time.Hour),
+				candidate("u2", 0),
+				assignedAt(candidate("u3", 0), time.Hour),
+			},
+			count: 2,
+			want:  []string{"u2", "u1"},
+		},
+		{
+			name: "load is ignored",
+			candidates: []domain.ReviewerCandidate{
+				assignedAt(candidate("u1", 0), time.Hour),
+				assignedAt(candidate("u2", 5), 2*time.Hour),
+			},
+			count: 1,
+			want:  []string{"u2"},
+		},
+		{
+			name: "count above candidates",
+			candidates: []domain.ReviewerCandidate{
+				assignedAt(candidate("u1", 0), time.Hour),
+			},
+			count: 2,
+			want:  []string{"u1"},
+		},
+	})
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	assertSelects(t, leastLoadedSelector{}, []selectCase{
+		{
+			name: "fewest open reviews first",
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 3),
+				candidate("u2", 0),
+				candidate("u3", 1),
+			},
+			count: 3,
+			want:  []string{"u2", "u3", "u1"},
+		},
+		{
+			name: "count above candidates",
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 1),
+			},
+			count: 2,
+			want:  []string{"u1"},
+		},
+	})
+}
+
+func TestRandomSelectorsFollowLoad(t *testing.T) {
+	assertShares(t, []shareCase{
+		{
+			name:     "random ignores load",
+			selector: randomSelector{},
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 3),
+				candidate("u2", 0),
+			},
+			share: 0.5,
+		},
+		{
+			name:     "weighted lowers loaded candidates",
+			selector: weightedSelector{},
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 1),
+				candidate("u2", 0),
+			},
+			share: 1.0 / 3,
+		},
+	})
+}
+
+func TestPickReviewers(t *testing.T) {
+	assertPicks(t, []pickCase{
+		{
+			name: "the selector decides without requirements",
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 2),
+				candidate("u2", 0),
+				candidate("u3", 1),
+			},
+			count:       2,
+			want:        []string{"u2", "u3"},
+			wantMissing: []string{},
+		},
+	})
+}
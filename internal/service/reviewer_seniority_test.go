@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickReviewersPrefersSeniors(t *testing.T) {
+	withSeniority := func(c domain.ReviewerCandidate, seniority string) domain.ReviewerCandidate {
+		c.Seniority = seniority
+		return c
+	}
+
+	assertPicks(t, []pickCase{
+		{
+			name: "seniors are picked first",
+			candidates: []domain.ReviewerCandidate{
+				withSeniority(candidate("u1", 0), domain.SeniorityJunior),
+				withSeniority(candidate("u2", 5), domain.SeniorityLead),
+				withSeniority(candidate("u3", 1), domain.SeniorityMiddle),
+			},
+			count:         2,
+			seniorsNeeded: 1,
+			want:          []string{"u2", "u1"},
+			wantMissing:   []string{},
+			wantSeniors:   1,
+		},
+		{
+			name: "senior shortfall",
+			candidates: []domain.ReviewerCandidate{
+				withSeniority(candidate("u1", 0), domain.SeniorityJunior),
+				withSeniority(candidate("u2", 1), domain.SenioritySenior),
+				withSeniority(candidate("u3", 2), domain.SeniorityMiddle),
+			},
+			count:         3,
+			seniorsNeeded: 2,
+			want:          []string{"u2", "u1", "u3"},
+			wantMissing:   []string{},
+			wantSeniors:   1,
+		},
+	})
+}
+
+func TestCountSeniors(t *testing.T) {
+	candidates := []domain.ReviewerCandidate{
+		{UserID: "u1", ReviewerLoad: domain.ReviewerLoad{Seniority: domain.SeniorityJunior}},
+		{UserID: "u2", ReviewerLoad: domain.ReviewerLoad{Seniority: domain.SenioritySenior}},
+		{UserID: "u3", ReviewerLoad: domain.ReviewerLoad{Seniority: domain.SeniorityLead}},
+	}
+	assert.Equal(t, 2, countSeniors(candidates, domain.SenioritySenior))
+	assert.Equal(t, 1, countSeniors(candidates, domain.SeniorityLead))
+	assert.Equal(t, 3, countSeniors(candidates, domain.SeniorityJunior))
+}
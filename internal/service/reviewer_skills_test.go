@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+func TestPickReviewersCoversSkills(t *testing.T) {
+	withSkills := func(c domain.ReviewerCandidate, skills ...string) domain.ReviewerCandidate {
+		c.Skills = skills
+		return c
+	}
+
+	assertPicks(t, []pickCase{
+		{
+			name: "skills beat load",
+			candidates: []domain.ReviewerCandidate{
+				candidate("u1", 0),
+				withSkills(candidate("u2", 4), "go"),
+				withSkills(candidate("u3", 2), "sql"),
+				candidate("u4", 1),
+			},
+			count:          2,
+			requiredSkills: []string{"go", "sql"},
+			want:           []string{"u3", "u2"},
+			wantMissing:    []string{},
+		},
+		{
+			name: "most skills covered first",
+			candidates: []domain.ReviewerCandidate{
+				withSkills(candidate("u1", 0), "go"),
+				withSkills(candidate("u2", 3), "go", "sql"),
+				candidate("u3", 1),
+			},
+			count:          2,
+			requiredSkills: []string{"go", "sql"},
+			want:           []string{"u2", "u1"},
+			wantMissing:    []string{},
+		},
+		{
+			name: "uncovered skills are missing",
+			candidates: []domain.ReviewerCandidate{
+				withSkills(candidate("u1", 0), "go"),
+				candidate("u2", 0),
+			},
+			count:          1,
+			requiredSkills: []string{"go", "rust"},
+			want:           []string{"u1"},
+			wantMissing:    []string{"rust"},
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// SeedSource provides seeds for reviewer selection. Every pick is made with
+// a generator created from its own seed, so it can be replayed later
+type SeedSource interface {
+	NextSeed() int64
+}
+
+// NewRandomSeedSource returns a source of unpredictable seeds
+func NewRandomSeedSource() SeedSource {
+	return randomSeedSource{}
+}
+
+// NewSeedSource returns a deterministic sequence of seeds started from seed.
+// It is used to get reproducible assignments, e.g. in tests
+func NewSeedSource(seed int64) SeedSource {
+	return &sequenceSeedSource{rng: rand.New(rand.NewSource(seed))}
+}
+
+type randomSeedSource struct{}
+
+func (randomSeedSource) NextSeed() int64 {
+	return rand.Int63()
+}
+
+type sequenceSeedSource struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (s *sequenceSeedSource) NextSeed() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Int63()
+}
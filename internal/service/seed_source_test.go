@@ -0,0 +1,57 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedSource(t *testing.T) {
+	first, second := NewSeedSource(42), NewSeedSource(42)
+	for range 5 {
+		assert.Equal(t, first.NextSeed(), second.NextSeed())
+	}
+	assert.NotEqual(t, NewSeedSource(42).NextSeed(), NewSeedSource(43).NextSeed())
+}
+
+func TestSelectorsAreReproducible(t *testing.T) {
+	candidates := []domain.ReviewerCandidate{
+		candidate("u1", 0),
+		candidate("u2", 0),
+		candidate("u3", 1),
+		candidate("u4", 2),
+		candidate("u5", 0),
+	}
+
+	for _, selector := range []ReviewerSelector{
+		randomSelector{},
+		roundRobinSelector{},
+		leastLoadedSelector{},
+		weightedSelector{},
+	} {
+		t.Run(selector.Name(), func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				first := selector.Select(rand.New(rand.NewSource(seed)), candidates, 3)
+				second := selector.Select(rand.New(rand.NewSource(seed)), candidates, 3)
+				assert.Equal(t, userIDs(first), userIDs(second))
+				assert.Len(t, first, 3)
+				assert.ElementsMatch(t, userIDs(first), uniqueIDs(userIDs(first)))
+			}
+		})
+	}
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+// SLAService flags reviews that exceed the review SLA of the reviewer's team. The reviewer is notified
+// when the SLA is breached and the team lead when the breach is still open after the escalation delay.
+// Notifications are sla.breached and sla.escalated events of the outbox
+type SLAService struct {
+	repo SLARepository
+}
+
+func NewSLAService(repo SLARepository) *SLAService {
+	return &SLAService{repo: repo}
+}
+
+// CheckBreaches resolves the breaches whose review is no longer pending, then records new breaches and escalations.
+// Deadlines are counted in working hours of the reviewer's timezone
+func (s *SLAService) CheckBreaches(ctx context.Context, now time.Time) (*domain.SLACheckResult, error) {
+	result := &domain.SLACheckResult{}
+
+	resolved, err := s.repo.ResolveBreaches(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	result.Resolved = resolved
+
+	assignments, err := s.repo.GetPendingAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range assignments {
+		assignment := &assignments[i]
+		location := loadLocation(assignment.Timezone)
+
+		if assignment.BreachID == 0 {
+			due := domain.AddWorkingHours(assignment.AssignedAt, assignment.SLAHours, location)
+			if now.Before(due) {
+				continue
+			}
+			breach := &domain.SLABreach{
+				AssignedAt:    assignment.AssignedAt,
+				DueAt:         due,
+				BreachedAt:    now,
+				PullRequestID: assignment.PullRequestID,
+				ReviewerID:    assignment.ReviewerID,
+				TeamName:      assignment.TeamName,
+				SLAHours:      assignment.SLAHours,
+			}
+			created, err := s.repo.CreateBreach(ctx, breach)
+			if err != nil {
+				slog.Warn("failed to record SLA breach", "pull_request_id", assignment.PullRequestID, "reviewer_id", assignment.ReviewerID, "error", err)
+				continue
+			}
+			if !created {
+				continue
+			}
+			result.Breached++
+			assignment.BreachID = breach.ID
+			assignment.DueAt = &breach.DueAt
+		}
+
+		if assignment.EscalatedAt != nil || assignment.LeadID == "" || assignment.LeadID == assignment.ReviewerID {
+			continue
+		}
+		if now.Before(domain.AddWorkingHours(*assignment.DueAt, assignment.EscalationHours, location)) {
+			continue
+		}
+		escalated, err := s.repo.EscalateBreach(ctx, assignment.BreachID, assignment.LeadID, now)
+		if err != nil {
+			slog.Warn("failed to escalate SLA breach", "breach_id", assignment.BreachID, "error", err)
+			continue
+		}
+		if escalated {
+			result.Escalated++
+		}
+	}
+
+	return result, nil
+}
+
+// ListBreaches returns the breaches newest first, status open lists the current ones and resolved the historical ones
+func (s *SLAService) ListBreaches(ctx context.Context, filter domain.SLABreachFilter) (*domain.SLABreachPage, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = domain.DefaultSLABreachListLimit
+	case filter.Limit < 0 || filter.Limit > domain.MaxSLABreachListLimit:
+		return nil, fmt.Errorf("limit must be between 1 and %d: %w", domain.MaxSLABreachListLimit, my_errors.ErrInvalidInput)
+	}
+	switch filter.Status {
+	case "", domain.SLABreachOpen, domain.SLABreachResolved:
+	default:
+		return nil, fmt.Errorf("status must be open or resolved: %w", my_errors.ErrInvalidInput)
+	}
+
+	// one extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	breaches, err := s.repo.ListBreaches(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA breaches: %w", err)
+	}
+
+	page := &domain.SLABreachPage{Breaches: breaches}
+	if len(breaches) > limit {
+		page.Breaches = breaches[:limit]
+		page.NextCursor = page.Breaches[limit-1].ID
+	}
+	return page, nil
+}
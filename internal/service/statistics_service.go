@@ -3,17 +3,27 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"pr-reviewer-service/internal/my_errors"
 
 	"pr-reviewer-service/internal/domain"
 )
 
+const (
+	DefaultDiversityWeeks = 12
+	MaxDiversityWeeks     = 104
+)
+
 type StatisticsService struct {
-	repo StatisticsRepository
+	repo     StatisticsRepository
+	teamRepo TeamRepositoryForStatistics
 }
 
-func NewStatisticsService(repo StatisticsRepository) *StatisticsService {
+func NewStatisticsService(repo StatisticsRepository, teamRepo TeamRepositoryForStatistics) *StatisticsService {
 	return &StatisticsService{
-		repo: repo,
+		repo:     repo,
+		teamRepo: teamRepo,
 	}
 }
 
@@ -25,3 +35,28 @@ func (s *StatisticsService) GetStatistics(ctx context.Context) (*domain.Statisti
 
 	return stats, nil
 }
+
+// GetPairingDiversity returns the weekly pairing diversity of the team's PRs over the last weeks
+func (s *StatisticsService) GetPairingDiversity(ctx context.Context, teamName string, weeks int) (*domain.PairingDiversity, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	if weeks <= 0 || weeks > MaxDiversityWeeks {
+		return nil, fmt.Errorf("weeks must be between 1 and %d: %w", MaxDiversityWeeks, my_errors.ErrInvalidInput)
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+
+	since := time.Now().AddDate(0, 0, -7*weeks)
+	diversity, err := s.repo.GetPairingDiversity(ctx, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pairing diversity: %w", err)
+	}
+	return diversity, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"pr-reviewer-service/internal/audit"
 	"pr-reviewer-service/internal/my_errors"
 
 	"pr-reviewer-service/internal/domain"
@@ -25,11 +26,19 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 	if team.TeamName == "" {
 		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetTeam, team.TeamName)
 
 	if len(team.Members) == 0 {
 		return nil, fmt.Errorf("team must have at least one member: %w", my_errors.ErrInvalidInput)
 	}
 
+	if team.ReviewerStrategy == "" {
+		team.ReviewerStrategy = domain.DefaultReviewerStrategy
+	}
+	if _, err := NewReviewerSelector(team.ReviewerStrategy); err != nil {
+		return nil, err
+	}
+
 	// Checking for duplicate user_id within a command
 	userIDs := make(map[string]bool)
 	for _, member := range team.Members {
@@ -39,6 +48,9 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 		if member.Username == "" {
 			return nil, fmt.Errorf("username: %w", my_errors.ErrInvalidInput)
 		}
+		if member.Seniority != "" && !domain.ValidSeniority(member.Seniority) {
+			return nil, fmt.Errorf("seniority of %s must be junior, middle, senior or lead: %w", member.UserID, my_errors.ErrInvalidInput)
+		}
 		if userIDs[member.UserID] {
 			return nil, fmt.Errorf("duplicate user_id in team members: %s", member.UserID)
 		}
@@ -53,16 +65,21 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 		return nil, fmt.Errorf("%w", my_errors.ErrTeamAlreadyExists)
 	}
 
-	if err := s.teamRepo.CreateTeam(ctx, team.TeamName); err != nil {
+	if err := s.teamRepo.CreateTeam(ctx, team.TeamName, team.ReviewerStrategy); err != nil {
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
 	for _, member := range team.Members {
 		user := &domain.User{
-			UserID:   member.UserID,
-			Username: member.Username,
-			TeamName: team.TeamName,
-			IsActive: member.IsActive,
+			UserID:    member.UserID,
+			Username:  member.Username,
+			TeamName:  team.TeamName,
+			IsActive:  member.IsActive,
+			Seniority: member.Seniority,
+		}
+		// skills of existing users are kept when the member has none in the payload
+		if member.Skills != nil {
+			user.Skills = domain.NormalizeSkills(member.Skills)
 		}
 		if err := s.userRepo.CreateOrUpdateUser(ctx, user); err != nil {
 			return nil, fmt.Errorf("failed to create/update user: %w", err)
@@ -73,6 +90,7 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created team: %w", err)
 	}
+	audit.Change(ctx, nil, createdTeam)
 
 	return createdTeam, nil
 }
@@ -97,3 +115,232 @@ func (s *TeamService) GetAllTeams(ctx context.Context) ([]domain.Team, error) {
 	}
 	return teams, nil
 }
+
+func (s *TeamService) GetSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+
+	return settings, nil
+}
+
+func (s *TeamService) UpdateSettings(ctx context.Context, settings *domain.TeamSettings) (*domain.TeamSettings, error) {
+	if settings.TeamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetTeam, settings.TeamName)
+	if err := validateTeamSettings(settings); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, settings.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+	before, err := s.teamRepo.GetTeamSettings(ctx, settings.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	if err := s.teamRepo.UpsertTeamSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update team settings: %w", err)
+	}
+
+	updated, err := s.teamRepo.GetTeamSettings(ctx, settings.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated team settings: %w", err)
+	}
+	audit.Change(ctx, before, updated)
+
+	return updated, nil
+}
+
+// SetReviewerStrategy changes only the strategy in the team settings.
+//
+// Deprecated: use UpdateSettings
+func (s *TeamService) SetReviewerStrategy(ctx context.Context, teamName, strategy string) (*domain.Team, error) {
+	settings, err := s.GetSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.ReviewerStrategy = strategy
+	if _, err := s.UpdateSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetTeamWithMembers(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated team: %w", err)
+	}
+
+	return team, nil
+}
+
+func (s *TeamService) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+
+	fallbackTeams, err := s.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+
+	return fallbackTeams, nil
+}
+
+// SetFallbackTeams replaces the fallback pools of the team.
+// Pools are used in the given order when the team cannot provide enough reviewers
+func (s *TeamService) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) ([]string, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetTeam, teamName)
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+
+	seen := make(map[string]bool, len(fallbackTeams))
+	for _, fallbackTeam := range fallbackTeams {
+		switch {
+		case fallbackTeam == teamName:
+			return nil, fmt.Errorf("team cannot fall back to itself: %w", my_errors.ErrInvalidInput)
+		case fallbackTeam == domain.TeamAdmins:
+			return nil, fmt.Errorf("%s cannot be used as a fallback team: %w", domain.TeamAdmins, my_errors.ErrInvalidInput)
+		case seen[fallbackTeam]:
+			return nil, fmt.Errorf("duplicate fallback team %s: %w", fallbackTeam, my_errors.ErrInvalidInput)
+		}
+		seen[fallbackTeam] = true
+
+		exists, err := s.teamRepo.TeamExists(ctx, fallbackTeam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("fallback team %s: %w", fallbackTeam, my_errors.ErrTeamNotFound)
+		}
+	}
+
+	before, err := s.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+
+	if err := s.teamRepo.SetFallbackTeams(ctx, teamName, fallbackTeams); err != nil {
+		return nil, fmt.Errorf("failed to set fallback teams: %w", err)
+	}
+
+	updated, err := s.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	audit.Change(ctx, map[string][]string{"fallback_teams": before}, map[string][]string{"fallback_teams": updated})
+
+	return updated, nil
+}
+
+// SetTeamLead makes the member the team's lead, SLA breaches of the team are escalated to the lead.
+// An empty userID removes the lead
+func (s *TeamService) SetTeamLead(ctx context.Context, teamName, userID string) (*domain.Team, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetTeam, teamName)
+
+	team, err := s.teamRepo.GetTeamWithMembers(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrTeamNotFound)
+	}
+
+	before := ""
+	found := userID == ""
+	for _, member := range team.Members {
+		if member.IsTeamLead {
+			before = member.UserID
+		}
+		if member.UserID == userID {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("user %s is not a member of team %s: %w", userID, teamName, my_errors.ErrUserNotFound)
+	}
+
+	if err := s.teamRepo.SetTeamLead(ctx, teamName, userID); err != nil {
+		return nil, fmt.Errorf("failed to set team lead: %w", err)
+	}
+
+	updated, err := s.teamRepo.GetTeamWithMembers(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated team: %w", err)
+	}
+	audit.Change(ctx, map[string]string{"team_lead": before}, map[string]string{"team_lead": userID})
+
+	return updated, nil
+}
+
+func validateTeamSettings(settings *domain.TeamSettings) error {
+	if _, err := NewReviewerSelector(settings.ReviewerStrategy); err != nil {
+		return err
+	}
+	switch settings.CodeOwnersMode {
+	case "":
+		settings.CodeOwnersMode = domain.DefaultCodeOwnersMode
+	case domain.CodeOwnersOff, domain.CodeOwnersPrefer, domain.CodeOwnersRequire:
+	default:
+		return fmt.Errorf("code_owners_mode must be off, prefer or require: %w", my_errors.ErrInvalidInput)
+	}
+	if settings.ReviewerCount < 0 || settings.ReviewerCount > domain.MaxReviewerCount {
+		return fmt.Errorf("reviewer_count must be between 0 and %d: %w", domain.MaxReviewerCount, my_errors.ErrInvalidInput)
+	}
+	if settings.MinReviewers < 0 || settings.MinReviewers > settings.ReviewerCount {
+		return fmt.Errorf("min_reviewers must be between 0 and reviewer_count: %w", my_errors.ErrInvalidInput)
+	}
+	switch {
+	case settings.SeniorLevel == "":
+		settings.SeniorLevel = domain.SenioritySenior
+	case !domain.ValidSeniority(settings.SeniorLevel):
+		return fmt.Errorf("senior_level must be junior, middle, senior or lead: %w", my_errors.ErrInvalidInput)
+	}
+	if settings.MinSeniorReviewers < 0 || settings.MinSeniorReviewers > settings.ReviewerCount {
+		return fmt.Errorf("min_senior_reviewers must be between 0 and reviewer_count: %w", my_errors.ErrInvalidInput)
+	}
+	if settings.PairingLookback < 0 || settings.PairingLookback > domain.MaxPairingLookback {
+		return fmt.Errorf("pairing_lookback must be between 0 and %d: %w", domain.MaxPairingLookback, my_errors.ErrInvalidInput)
+	}
+	if settings.MergePolicy.MinApprovals < 0 || settings.MergePolicy.MinApprovals > domain.MaxReviewerCount {
+		return fmt.Errorf("merge_min_approvals must be between 0 and %d: %w", domain.MaxReviewerCount, my_errors.ErrInvalidInput)
+	}
+	if settings.MaxOpenReviews != nil && *settings.MaxOpenReviews <= 0 {
+		return fmt.Errorf("max_open_reviews must be positive: %w", my_errors.ErrInvalidInput)
+	}
+	if settings.ReviewSLAHours < 0 || settings.ReviewSLAHours > domain.MaxSLAHours {
+		return fmt.Errorf("review_sla_hours must be between 0 and %d: %w", domain.MaxSLAHours, my_errors.ErrInvalidInput)
+	}
+	if settings.SLAEscalationHours < 0 || settings.SLAEscalationHours > domain.MaxSLAHours {
+		return fmt.Errorf("sla_escalation_hours must be between 0 and %d: %w", domain.MaxSLAHours, my_errors.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/my_errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTeamSettings(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		change  func(s *domain.TeamSettings)
+		wantErr error
+	}{
+		{
+			name:   "defaults are valid",
+			change: func(s *domain.TeamSettings) {},
+		},
+		{
+			name: "reviewers can be turned off",
+			change: func(s *domain.TeamSettings) {
+				s.ReviewerCount = 0
+			},
+		},
+		{
+			name: "minimum equal to the count",
+			change: func(s *domain.TeamSettings) {
+				s.ReviewerCount = 3
+				s.MinReviewers = 3
+				s.MaxOpenReviews = limit(5)
+			},
+		},
+		{
+			name: "unknown strategy",
+			change: func(s *domain.TeamSettings) {
+				s.ReviewerStrategy = "fastest"
+			},
+			wantErr: my_errors.ErrInvalidReviewerStrategy,
+		},
+		{
+			name: "count above the maximum",
+			change: func(s *domain.TeamSettings) {
+				s.ReviewerCount = domain.MaxReviewerCount + 1
+			},
+			wantErr: my_errors.ErrInvalidInput,
+		},
+		{
+			name: "negative count",
+			change: func(s *domain.TeamSettings) {
+				s.ReviewerCount = -1
+			},
+			wantErr: my_errors.ErrInvalidInput,
+		},
+		{
+			name: "minimum above the count",
+			change: func(s *domain.TeamSettings) {
+				s.MinReviewers = s.ReviewerCount + 1
+			},
+			wantErr: my_errors.ErrInvalidInput,
+		},
+		{
+			name: "zero open reviews limit",
+			change: func(s *domain.TeamSettings) {
+				s.MaxOpenReviews = limit(0)
+			},
+			wantErr: my_errors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.DefaultTeamSettings("backend")
+			tt.change(settings)
+
+			err := validateTeamSettings(settings)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
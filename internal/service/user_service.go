@@ -3,25 +3,45 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"pr-reviewer-service/internal/audit"
 	"pr-reviewer-service/internal/my_errors"
 
 	"pr-reviewer-service/internal/domain"
 )
 
 type UserService struct {
-	userRepo      UserRepository
-	userBatchRepo UserRepositoryForBatch
-	prRepo        PRRepositoryForBatch
+	userRepo         UserRepository
+	userBatchRepo    UserRepositoryForBatch
+	prRepo           PRRepositoryForBatch
+	availabilityRepo AvailabilityRepository
+	assigner         *ReviewerAssigner
+	seeds            SeedSource
 }
 
-func NewUserService(userRepo UserRepository, userBatchRepo UserRepositoryForBatch, prRepo PRRepositoryForBatch) *UserService {
+func NewUserService(
+	userRepo UserRepository,
+	userBatchRepo UserRepositoryForBatch,
+	prRepo PRRepositoryForBatch,
+	availabilityRepo AvailabilityRepository,
+	assigner *ReviewerAssigner,
+	seeds SeedSource,
+) *UserService {
 	return &UserService{
-		userRepo:      userRepo,
-		userBatchRepo: userBatchRepo,
-		prRepo:        prRepo,
+		userRepo:         userRepo,
+		userBatchRepo:    userBatchRepo,
+		prRepo:           prRepo,
+		availabilityRepo: availabilityRepo,
+		assigner:         assigner,
+		seeds:            seeds,
 	}
 }
 
@@ -30,10 +50,13 @@ func (s *UserService) SetUserActive(ctx context.Context, userID string, isActive
 		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
 	}
 
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+
 	user, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
 	}
+	before := *user
 
 	// Disable deactivation of admins through this
 	if user.TeamName == domain.TeamAdmins && !isActive {
@@ -45,6 +68,168 @@ func (s *UserService) SetUserActive(ctx context.Context, userID string, isActive
 	}
 
 	user.IsActive = isActive
+	audit.Change(ctx, &before, user)
+	return user, nil
+}
+
+// SetUserCapacity sets the personal open reviews cap (nil removes it) and the review weight of the user
+func (s *UserService) SetUserCapacity(ctx context.Context, userID string, maxOpenReviews *int, reviewWeight float64) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+	if maxOpenReviews != nil && *maxOpenReviews <= 0 {
+		return nil, fmt.Errorf("max_open_reviews must be positive: %w", my_errors.ErrInvalidInput)
+	}
+	if reviewWeight <= 0 {
+		return nil, fmt.Errorf("review_weight must be positive: %w", my_errors.ErrInvalidInput)
+	}
+
+	before, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.userRepo.SetUserCapacity(ctx, userID, maxOpenReviews, reviewWeight); err != nil {
+		return nil, fmt.Errorf("failed to set user capacity: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+	audit.Change(ctx, before, user)
+	return user, nil
+}
+
+// GetUser returns the user with their skills and review capacity
+func (s *UserService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// SetUserSeniority sets the seniority level of the user
+func (s *UserService) SetUserSeniority(ctx context.Context, userID, seniority string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+	if !domain.ValidSeniority(seniority) {
+		return nil, fmt.Errorf("seniority must be junior, middle, senior or lead: %w", my_errors.ErrInvalidInput)
+	}
+
+	before, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.userRepo.SetUserSeniority(ctx, userID, seniority); err != nil {
+		return nil, fmt.Errorf("failed to set user seniority: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+	audit.Change(ctx, before, user)
+	return user, nil
+}
+
+// SetUserNotifications sets where and how often the user is notified by email
+func (s *UserService) SetUserNotifications(ctx context.Context, userID, email, preference string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+	if !domain.ValidNotificationPreference(preference) {
+		return nil, fmt.Errorf("notification_preference must be immediate, digest or off: %w", my_errors.ErrInvalidInput)
+	}
+	email = strings.TrimSpace(email)
+	if email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return nil, fmt.Errorf("email must be a plain address: %w", my_errors.ErrInvalidInput)
+		}
+	}
+
+	before, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.userRepo.SetUserNotifications(ctx, userID, email, preference); err != nil {
+		return nil, fmt.Errorf("failed to set user notifications: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+	audit.Change(ctx, before, user)
+	return user, nil
+}
+
+// SetUserDigestSchedule sets the IANA timezone and the local hour the user's daily digest is sent at
+func (s *UserService) SetUserDigestSchedule(ctx context.Context, userID, timezone string, digestHour int) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+	if timezone == "" {
+		return nil, fmt.Errorf("timezone: %w", my_errors.ErrEmptyField)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("timezone must be an IANA zone name such as Europe/Moscow: %w", my_errors.ErrInvalidInput)
+	}
+	if digestHour < 0 || digestHour > 23 {
+		return nil, fmt.Errorf("digest_hour must be between 0 and 23: %w", my_errors.ErrInvalidInput)
+	}
+
+	before, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.userRepo.SetUserDigestSchedule(ctx, userID, timezone, digestHour); err != nil {
+		return nil, fmt.Errorf("failed to set user digest schedule: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+	audit.Change(ctx, before, user)
+	return user, nil
+}
+
+// SetUserSkills replaces the skill tags of the user
+func (s *UserService) SetUserSkills(ctx context.Context, userID string, skills []string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, userID)
+
+	before, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.userRepo.SetUserSkills(ctx, userID, domain.NormalizeSkills(skills)); err != nil {
+		return nil, fmt.Errorf("failed to set user skills: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated user: %w", err)
+	}
+	audit.Change(ctx, before, user)
 	return user, nil
 }
 
@@ -56,6 +241,109 @@ func (s *UserService) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 	return users, nil
 }
 
+// Availability
+
+func (s *UserService) AddAbsence(ctx context.Context, absence *domain.Absence) (*domain.Absence, error) {
+	if absence.UserID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetUser, absence.UserID)
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, fmt.Errorf("ends_at must be after starts_at: %w", my_errors.ErrInvalidInput)
+	}
+	if !absence.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("absence is already over: %w", my_errors.ErrInvalidInput)
+	}
+
+	if _, err := s.userRepo.GetUserByID(ctx, absence.UserID); err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrUserNotFound)
+	}
+
+	if err := s.availabilityRepo.CreateAbsence(ctx, absence); err != nil {
+		return nil, fmt.Errorf("failed to add absence: %w", err)
+	}
+	audit.Change(ctx, nil, absence)
+
+	return absence, nil
+}
+
+func (s *UserService) GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+
+	absences, err := s.availabilityRepo.GetUserAbsences(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+	return absences, nil
+}
+
+func (s *UserService) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	if userID == "" {
+		return fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
+	}
+	audit.Target(ctx, domain.AuditTargetAbsence, strconv.FormatInt(absenceID, 10))
+
+	if err := s.availabilityRepo.DeleteAbsence(ctx, userID, absenceID); err != nil {
+		return fmt.Errorf("%w", my_errors.ErrAbsenceNotFound)
+	}
+	return nil
+}
+
+// HandOverAbsentReviews reassigns open reviews of users whose absence has started by now
+// and who asked for their reviews to be handed over
+func (s *UserService) HandOverAbsentReviews(ctx context.Context, now time.Time) (*domain.HandoverResult, error) {
+	result := &domain.HandoverResult{
+		HandedOverUsers:      []string{},
+		ReassignedPRs:        []domain.PRReassignment{},
+		UnderstaffedPRs:      []string{},
+		CapacityExhaustedPRs: []string{},
+		SeniorMissingPRs:     []string{},
+	}
+
+	absences, err := s.availabilityRepo.GetPendingHandovers(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending handovers: %w", err)
+	}
+	if len(absences) == 0 {
+		return result, nil
+	}
+
+	away := make(map[string]bool)
+	absenceIDs := make([]int64, 0, len(absences))
+	for _, absence := range absences {
+		if !away[absence.UserID] {
+			away[absence.UserID] = true
+			result.HandedOverUsers = append(result.HandedOverUsers, absence.UserID)
+		}
+		absenceIDs = append(absenceIDs, absence.ID)
+	}
+
+	prsByReviewer, err := s.prRepo.GetOpenPRsByReviewers(ctx, result.HandedOverUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open PRs: %w", err)
+	}
+
+	if len(prsByReviewer) > 0 {
+		result.Seed = s.seeds.NextSeed()
+		outcome, err := s.reassignReviews(ctx, prsByReviewer, away, result.Seed, now, domain.EventReasonAbsence)
+		if err != nil {
+			return nil, err
+		}
+		result.ReassignedPRs = outcome.reassigned
+		result.UnderstaffedPRs = outcome.understaffed
+		result.CapacityExhaustedPRs = outcome.capacityExhausted
+		result.SeniorMissingPRs = outcome.seniorMissing
+	}
+
+	if err := s.availabilityRepo.MarkHandedOver(ctx, absenceIDs, now); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // Batch commands
 
 func (s *UserService) BatchDeactivateTeam(ctx context.Context, teamName string) (*domain.BatchDeactivateResult, error) {
@@ -64,6 +352,7 @@ func (s *UserService) BatchDeactivateTeam(ctx context.Context, teamName string)
 	if teamName == "" {
 		return nil, fmt.Errorf("team_name: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetTeam, teamName)
 
 	if teamName == domain.TeamAdmins {
 		return nil, fmt.Errorf("%w", my_errors.ErrCannotDeactivateAdminTeam)
@@ -76,10 +365,13 @@ func (s *UserService) BatchDeactivateTeam(ctx context.Context, teamName string)
 
 	if len(userIDs) == 0 {
 		return &domain.BatchDeactivateResult{
-			DeactivatedUsers: []string{},
-			ReassignedPRs:    []domain.PRReassignment{},
-			SkippedUsers:     []string{},
-			ProcessingTime:   time.Since(startTime),
+			DeactivatedUsers:     []string{},
+			ReassignedPRs:        []domain.PRReassignment{},
+			SkippedUsers:         []string{},
+			UnderstaffedPRs:      []string{},
+			CapacityExhaustedPRs: []string{},
+			SeniorMissingPRs:     []string{},
+			ProcessingTime:       time.Since(startTime),
 		}, nil
 	}
 
@@ -92,15 +384,19 @@ func (s *UserService) BatchDeactivateUsers(ctx context.Context, userIDs []string
 	if len(userIDs) == 0 {
 		return nil, fmt.Errorf("user_id: %w", my_errors.ErrEmptyField)
 	}
+	audit.Target(ctx, domain.AuditTargetDeactivated, strings.Join(userIDs, ","))
 
 	return s.batchDeactivateUsers(ctx, userIDs, startTime)
 }
 
 func (s *UserService) batchDeactivateUsers(ctx context.Context, userIDs []string, startTime time.Time) (*domain.BatchDeactivateResult, error) {
 	result := &domain.BatchDeactivateResult{
-		DeactivatedUsers: []string{},
-		ReassignedPRs:    []domain.PRReassignment{},
-		SkippedUsers:     []string{},
+		DeactivatedUsers:     []string{},
+		ReassignedPRs:        []domain.PRReassignment{},
+		SkippedUsers:         []string{},
+		UnderstaffedPRs:      []string{},
+		CapacityExhaustedPRs: []string{},
+		SeniorMissingPRs:     []string{},
 	}
 
 	// get open prs for all users
@@ -131,14 +427,72 @@ func (s *UserService) batchDeactivateUsers(ctx context.Context, userIDs []string
 	// if there are no open prs, return result
 	if len(prsByReviewer) == 0 {
 		result.ProcessingTime = time.Since(startTime)
+		auditBatchDeactivation(ctx, result)
 		return result, nil
 	}
 
+	result.Seed = s.seeds.NextSeed()
+	outcome, err := s.reassignReviews(ctx, prsByReviewer, deactivatedMap, result.Seed, startTime, domain.EventReasonDeactivation)
+	if err != nil {
+		return nil, err
+	}
+	result.ReassignedPRs = outcome.reassigned
+	result.UnderstaffedPRs = outcome.understaffed
+	result.CapacityExhaustedPRs = outcome.capacityExhausted
+	result.SeniorMissingPRs = outcome.seniorMissing
+
+	result.ProcessingTime = time.Since(startTime)
+	auditBatchDeactivation(ctx, result)
+	return result, nil
+}
+
+// auditBatchDeactivation records who was deactivated and which PRs got new reviewers
+func auditBatchDeactivation(ctx context.Context, result *domain.BatchDeactivateResult) {
+	reassignedPRs := make([]string, len(result.ReassignedPRs))
+	for i, reassignment := range result.ReassignedPRs {
+		reassignedPRs[i] = reassignment.PullRequestID
+	}
+	audit.Change(ctx, nil, map[string][]string{
+		"deactivated_users": result.DeactivatedUsers,
+		"reassigned_prs":    reassignedPRs,
+		"understaffed_prs":  result.UnderstaffedPRs,
+	})
+}
+
+// reassignOutcome is the result of reassignReviews
+type reassignOutcome struct {
+	reassigned []domain.PRReassignment
+	// understaffed PRs are left with fewer reviewers than their team requires
+	understaffed []string
+	// capacityExhausted PRs got fewer replacements than needed because candidates are at their limits
+	capacityExhausted []string
+	// seniorMissing PRs are left with fewer senior reviewers than their team requires
+	seniorMissing []string
+}
+
+// reassignReviews replaces the leaving reviewers of the given open PRs (map[pr_id][]reviewer_ids).
+// PRs are processed in a fixed order with per-PR seeds derived from seed, so the result is reproducible.
+// Replacements must be available at now, reason is recorded in the timelines of the PRs
+func (s *UserService) reassignReviews(
+	ctx context.Context,
+	prsByReviewer map[string][]string,
+	leaving map[string]bool,
+	seed int64,
+	now time.Time,
+	reason string,
+) (*reassignOutcome, error) {
+	outcome := &reassignOutcome{
+		reassigned:        []domain.PRReassignment{},
+		understaffed:      []string{},
+		capacityExhausted: []string{},
+		seniorMissing:     []string{},
+	}
+
 	// group PRs by unique IDs
 	uniquePRs := make(map[string][]string) // map[pr_id]deactivated_reviewers
 	for prID, reviewers := range prsByReviewer {
 		for _, reviewerID := range reviewers {
-			if deactivatedMap[reviewerID] {
+			if leaving[reviewerID] {
 				uniquePRs[prID] = append(uniquePRs[prID], reviewerID)
 			}
 		}
@@ -156,20 +510,15 @@ func (s *UserService) batchDeactivateUsers(ctx context.Context, userIDs []string
 		go func(prID string, deactivatedRevs []string) {
 			defer wg.Done()
 
-			authorID, teamName, currentReviewers, err := s.prRepo.GetPRWithReviewersAndAuthor(ctx, prID)
+			task, err := s.prRepo.GetPRWithReviewersAndAuthor(ctx, prID)
 			if err != nil {
 				errChan <- fmt.Errorf("failed to get PR %s info: %w", prID, err)
 				return
 			}
+			task.DeactivatedRevs = deactivatedRevs
 
 			mu.Lock()
-			tasks = append(tasks, domain.ReassignmentTask{
-				PrID:             prID,
-				AuthorID:         authorID,
-				DeactivatedRevs:  deactivatedRevs,
-				TeamName:         teamName,
-				CurrentReviewers: currentReviewers,
-			})
+			tasks = append(tasks, *task)
 			mu.Unlock()
 		}(prID, deactivatedRevs)
 	}
@@ -181,36 +530,24 @@ func (s *UserService) batchDeactivateUsers(ctx context.Context, userIDs []string
 		return nil, <-errChan
 	}
 
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].PrID < tasks[j].PrID
+	})
+	rng := rand.New(rand.NewSource(seed))
+
 	// find replacements for each pr
-	reassignments := make(map[string]map[string]string) // map[pr_id]map[old_reviewer]new_reviewer
+	reassignments := make(map[string]map[string]domain.ReviewerReplacement) // map[pr_id]map[old_reviewer]new_reviewer
 
-	for _, task := range tasks {
-		// get active members except pr author
-		activeMembers, err := s.userRepo.GetActiveTeamMembers(ctx, task.TeamName, task.AuthorID)
-		if err != nil {
-			continue
-		}
+	// assignments made in this batch are not stored yet, so their load is tracked here
+	pendingLoad := make(map[string]int)
+	settingsByTeam := make(map[string]*domain.TeamSettings)
 
+	for _, task := range tasks {
 		currentReviewerSet := make(map[string]bool)
 		for _, rev := range task.CurrentReviewers {
 			currentReviewerSet[rev] = true
 		}
 
-		// filter candidates (except current and deactivated reviewers)
-		var candidates []domain.User
-		for _, member := range activeMembers {
-			if !currentReviewerSet[member.UserID] && !deactivatedMap[member.UserID] {
-				candidates = append(candidates, member)
-			}
-		}
-
-		if len(candidates) == 0 {
-			continue // no free candidates
-		}
-
-		prReassignments := make(map[string]string)
-		usedCandidates := make(map[string]bool)
-
 		deactivatedReviewersForPR := make([]string, 0)
 		for _, oldReviewerID := range task.DeactivatedRevs {
 			if currentReviewerSet[oldReviewerID] {
@@ -218,53 +555,106 @@ func (s *UserService) batchDeactivateUsers(ctx context.Context, userIDs []string
 			}
 		}
 
-		// reassign each deactivated reviewer to a unique candidate
-		for _, oldReviewerID := range deactivatedReviewersForPR {
-			var newReviewer *domain.User
-
-			for i := range candidates {
-				candidateID := candidates[i].UserID
-				if !usedCandidates[candidateID] && !currentReviewerSet[candidateID] {
-					newReviewer = &candidates[i]
-					usedCandidates[candidateID] = true
-					currentReviewerSet[candidateID] = true
-					break
-				}
+		keptReviewers := make([]string, 0, len(task.CurrentReviewers))
+		for _, rev := range task.CurrentReviewers {
+			if !leaving[rev] {
+				keptReviewers = append(keptReviewers, rev)
 			}
+		}
 
-			if newReviewer == nil {
-				continue
+		// candidates must not be the author, current or leaving reviewers
+		exclude := map[string]bool{task.AuthorID: true}
+		for rev := range currentReviewerSet {
+			exclude[rev] = true
+		}
+		for uid := range leaving {
+			exclude[uid] = true
+		}
+
+		settings, ok := settingsByTeam[task.TeamName]
+		if !ok {
+			var err error
+			settings, err = s.assigner.TeamSettings(ctx, task.TeamName)
+			if err != nil {
+				return nil, err
 			}
+			settingsByTeam[task.TeamName] = settings
+		}
 
-			prReassignments[oldReviewerID] = newReviewer.UserID
+		assignment, err := s.assigner.Assign(ctx, AssignmentRequest{
+			Settings:       settings,
+			AuthorID:       task.AuthorID,
+			Exclude:        exclude,
+			PendingLoad:    pendingLoad,
+			ChangedFiles:   task.ChangedFiles,
+			RequiredSkills: task.RequiredSkills,
+			KeptReviewers:  keptReviewers,
+			Count:          len(deactivatedReviewersForPR),
+			Seed:           rng.Int63(),
+			Now:            now,
+		})
+		if err != nil {
+			// the PR keeps the leaving reviewers, the rest of the batch still goes on
+			slog.Warn("failed to find replacement reviewers", "pr_id", task.PrID, "reason", reason, "error", err)
+			continue
 		}
 
-		if len(prReassignments) > 0 {
-			reassignments[task.PrID] = prReassignments
+		// PR is left with fewer reviewers than the team requires
+		remaining := len(task.CurrentReviewers) - len(deactivatedReviewersForPR) + len(assignment.Reviewers)
+		if remaining < settings.MinReviewers {
+			outcome.understaffed = append(outcome.understaffed, task.PrID)
 		}
+		if assignment.CapacityExhausted() {
+			outcome.capacityExhausted = append(outcome.capacityExhausted, task.PrID)
+		}
+		if assignment.SeniorShortfall > 0 {
+			outcome.seniorMissing = append(outcome.seniorMissing, task.PrID)
+		}
+
+		if len(assignment.Reviewers) == 0 {
+			continue // no free candidates
+		}
+
+		// reassign each deactivated reviewer to a unique candidate, all of them share the pick's trace
+		trace := assignment.Trace()
+		prReassignments := make(map[string]domain.ReviewerReplacement)
+		for i, newReviewerID := range assignment.Reviewers {
+			prReassignments[deactivatedReviewersForPR[i]] = domain.ReviewerReplacement{
+				Trace:      trace,
+				UserID:     newReviewerID,
+				IsFallback: assignment.FallbackReviewers[newReviewerID],
+			}
+			pendingLoad[newReviewerID]++
+		}
+
+		reassignments[task.PrID] = prReassignments
 	}
 
 	if len(reassignments) > 0 {
-		if err := s.prRepo.BatchReassignReviewers(ctx, reassignments); err != nil {
+		if err := s.prRepo.BatchReassignReviewers(ctx, reassignments, eventSource(ctx, reason)); err != nil {
 			return nil, fmt.Errorf("failed to batch reassign reviewers: %w", err)
 		}
 
 		for prID, reviewerMap := range reassignments {
 			oldRevs := make([]string, 0, len(reviewerMap))
 			newRevs := make([]string, 0, len(reviewerMap))
-			for old, new := range reviewerMap {
+			fallbackRevs := make([]string, 0)
+			for old, replacement := range reviewerMap {
 				oldRevs = append(oldRevs, old)
-				newRevs = append(newRevs, new)
+				newRevs = append(newRevs, replacement.UserID)
+				if replacement.IsFallback {
+					fallbackRevs = append(fallbackRevs, replacement.UserID)
+				}
 			}
 
-			result.ReassignedPRs = append(result.ReassignedPRs, domain.PRReassignment{
-				PullRequestID: prID,
-				OldReviewers:  oldRevs,
-				NewReviewers:  newRevs,
+			outcome.reassigned = append(outcome.reassigned, domain.PRReassignment{
+				PullRequestID:     prID,
+				OldReviewers:      oldRevs,
+				NewReviewers:      newRevs,
+				FallbackReviewers: fallbackRevs,
 			})
 		}
 	}
 
-	result.ProcessingTime = time.Since(startTime)
-	return result, nil
+	return outcome, nil
 }
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service/internal/audit"
+	"pr-reviewer-service/internal/my_errors"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+const (
+	// maxErrorBodySize limits the part of the receiver's error response kept in the delivery log
+	maxErrorBodySize = 512
+)
+
+type WebhookService struct {
+	repo   WebhookRepository
+	client *http.Client
+}
+
+func NewWebhookService(repo WebhookRepository, client *http.Client) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		client: client,
+	}
+}
+
+// CreateSubscription registers a webhook, a random secret is generated when none is given
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http or https URL: %w", my_errors.ErrInvalidInput)
+	}
+	if len(subscription.EventTypes) == 0 {
+		return nil, fmt.Errorf("event_types: %w", my_errors.ErrEmptyField)
+	}
+	seen := make(map[string]bool, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		if !domain.ValidWebhookEventType(eventType) {
+			return nil, fmt.Errorf("unknown event type %s: %w", eventType, my_errors.ErrInvalidInput)
+		}
+		if seen[eventType] {
+			return nil, fmt.Errorf("duplicate event type %s: %w", eventType, my_errors.ErrInvalidInput)
+		}
+		seen[eventType] = true
+	}
+	if subscription.Secret == "" {
+		if subscription.Secret, err = randomHex(32); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.CreatedBy = actorID(ctx)
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	audit.Target(ctx, domain.AuditTargetWebhook, strconv.FormatInt(subscription.ID, 10))
+	audit.Change(ctx, nil, subscription)
+
+	return subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subscriptions, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// DisableSubscription stops deliveries to the webhook, pending deliveries are kept in the log
+func (s *WebhookService) DisableSubscription(ctx context.Context, subscriptionID int64) error {
+	audit.Target(ctx, domain.AuditTargetWebhook, strconv.FormatInt(subscriptionID, 10))
+	if err := s.repo.DisableSubscription(ctx, subscriptionID); err != nil {
+		return fmt.Errorf("%w", my_errors.ErrWebhookNotFound)
+	}
+	return nil
+}
+
+// ListDeliveries returns one page of the delivery log matching the filter, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) (*domain.DeliveryPage, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = domain.DefaultDeliveryListLimit
+	case filter.Limit < 0 || filter.Limit > domain.MaxDeliveryListLimit:
+		return nil, fmt.Errorf("limit must be between 1 and %d: %w", domain.MaxDeliveryListLimit, my_errors.ErrInvalidInput)
+	}
+	switch filter.Status {
+	case "", domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryFailed:
+	default:
+		return nil, fmt.Errorf("status must be pending, delivered or failed: %w", my_errors.ErrInvalidInput)
+	}
+
+	// one extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	deliveries, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	page := &domain.DeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		page.NextCursor = page.Deliveries[limit-1].ID
+	}
+	return page, nil
+}
+
+// Redeliver queues a new delivery of the same event to the same subscription
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error) {
+	audit.Target(ctx, domain.AuditTargetWebhookDelivery, strconv.FormatInt(deliveryID, 10))
+
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", my_errors.ErrDeliveryNotFound)
+	}
+
+	deliveries := []domain.WebhookDelivery{{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		RedeliveryOf:   &original.ID,
+	}}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	audit.Change(ctx, nil, &deliveries[0])
+
+	return &deliveries[0], nil
+}
+
+// Publish queues the outbox message for every active subscription to its type.
+// A message published twice is queued once per subscription, the event ID is derived from the message
+// so that receivers can also drop the duplicates of redeliveries
+func (s *WebhookService) Publish(ctx context.Context, message domain.OutboxMessage) error {
+	subscriptions, err := s.repo.GetSubscriptionsForEvent(ctx, message.EventType)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	eventID := strconv.FormatInt(message.ID, 10)
+	payload, err := json.Marshal(domain.WebhookEvent{
+		OccurredAt: message.CreatedAt,
+		ID:         eventID,
+		Type:       message.EventType,
+		Data:       message.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", message.EventType, err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = domain.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      message.EventType,
+			Payload:        payload,
+			OutboxID:       message.ID,
+		}
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to queue %s deliveries: %w", message.EventType, err)
+	}
+	return nil
+}
+
+// DeliverDue sends up to limit deliveries whose attempt is due and returns how many were attempted.
+// Failed attempts are retried with exponential backoff until domain.MaxDeliveryAttempts is reached
+func (s *WebhookService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, now, now.Add(domain.DeliveryLease(limit, s.client.Timeout)), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		statusCode, sendErr := s.send(ctx, delivery)
+
+		attemptedAt := time.Now()
+		delivery.Attempts++
+		delivery.LastAttemptAt = &attemptedAt
+		delivery.LastStatusCode = statusCode
+		switch {
+		case sendErr == nil:
+			delivery.Status = domain.DeliveryDelivered
+			delivery.DeliveredAt = &attemptedAt
+			delivery.LastError = ""
+		case delivery.Attempts >= domain.MaxDeliveryAttempts:
+			delivery.Status = domain.DeliveryFailed
+			delivery.LastError = sendErr.Error()
+		default:
+			delivery.NextAttemptAt = attemptedAt.Add(domain.DeliveryBackoff(delivery.Attempts))
+			delivery.LastError = sendErr.Error()
+		}
+
+		if err := s.repo.SaveAttempt(ctx, delivery); err != nil {
+			return i, fmt.Errorf("failed to save webhook delivery attempt: %w", err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// send posts the payload signed with the subscription secret, any non-2xx response is an error
+func (s *WebhookService) send(ctx context.Context, delivery *domain.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pr-reviewer-service-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Signature-256", domain.WebhookSignature(delivery.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	statusCode := resp.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return &statusCode, fmt.Errorf("receiver responded with %d: %s", statusCode, bytes.TrimSpace(body))
+	}
+	return &statusCode, nil
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
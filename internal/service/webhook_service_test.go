@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// leasingWebhookRepo keeps deliveries in memory and claims them like the database does:
+// a claimed delivery is hidden until its lease ends
+type leasingWebhookRepo struct {
+	WebhookRepository
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery
+}
+
+func (r *leasingWebhookRepo) ClaimDueDeliveries(_ context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claimed := []domain.WebhookDelivery{}
+	for i := range r.deliveries {
+		delivery := &r.deliveries[i]
+		if len(claimed) == limit || delivery.Status != domain.DeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.NextAttemptAt = leaseUntil
+		claimed = append(claimed, *delivery)
+	}
+	return claimed, nil
+}
+
+func (r *leasingWebhookRepo) SaveAttempt(_ context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == delivery.ID {
+			r.deliveries[i] = *delivery
+		}
+	}
+	return nil
+}
+
+func TestWebhookService_DeliverDueKeepsBatchLeased(t *testing.T) {
+	const (
+		batch   = 50
+		timeout = 5 * time.Second
+	)
+	start := time.Now()
+
+	repo := &leasingWebhookRepo{}
+	for id := int64(1); id <= batch; id++ {
+		repo.deliveries = append(repo.deliveries, domain.WebhookDelivery{
+			ID:            id,
+			EventType:     domain.WebhookPRCreated,
+			Payload:       []byte(`{}`),
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: start.Add(-time.Second),
+		})
+	}
+
+	// while the batch is being sent, another sender looks for due deliveries at the moment
+	// the batch would end if every request took the whole client timeout
+	var reclaimed atomic.Int64
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claimed, err := repo.ClaimDueDeliveries(r.Context(), start.Add(batch*timeout), start.Add(time.Hour), batch)
+		assert.NoError(t, err)
+		reclaimed.Add(int64(len(claimed)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	for i := range repo.deliveries {
+		repo.deliveries[i].URL = receiver.URL
+	}
+
+	service := NewWebhookService(repo, &http.Client{Timeout: timeout})
+	sent, err := service.DeliverDue(context.Background(), batch)
+	require.NoError(t, err)
+	assert.Equal(t, batch, sent)
+	assert.Zero(t, reclaimed.Load(), "deliveries of the batch were claimed again before it ended")
+
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, domain.DeliveryDelivered, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+	}
+}
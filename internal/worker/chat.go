@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// chatBatchSize is the number of chat posts sent per claim
+const chatBatchSize = 50
+
+type ChatSender interface {
+	DeliverDue(ctx context.Context, limit int) (int, error)
+}
+
+// ChatWorker periodically sends queued chat posts whose attempt is due
+type ChatWorker struct {
+	sender   ChatSender
+	interval time.Duration
+}
+
+func NewChatWorker(sender ChatSender, interval time.Duration) *ChatWorker {
+	return &ChatWorker{
+		sender:   sender,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *ChatWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce drains due posts batch by batch, a short batch means the queue is empty
+func (w *ChatWorker) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := w.sender.DeliverDue(ctx, chatBatchSize)
+		if err != nil {
+			slog.Error("failed to send chat posts", "error", err)
+			return
+		}
+		if claimed < chatBatchSize {
+			return
+		}
+	}
+}
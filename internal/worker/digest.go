@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type DigestSender interface {
+	SendDueDigests(ctx context.Context, now time.Time) (int, error)
+}
+
+// DigestWorker periodically sends the daily digests whose local hour has come.
+// The interval only bounds the delay, each user gets one digest per local day
+type DigestWorker struct {
+	sender   DigestSender
+	interval time.Duration
+}
+
+func NewDigestWorker(sender DigestSender, interval time.Duration) *DigestWorker {
+	return &DigestWorker{
+		sender:   sender,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *DigestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *DigestWorker) runOnce(ctx context.Context) {
+	sent, err := w.sender.SendDueDigests(ctx, time.Now())
+	if err != nil {
+		slog.Error("failed to send digests", "error", err)
+		return
+	}
+	if sent > 0 {
+		slog.Info("sent daily digests", "count", sent)
+	}
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// emailBatchSize is the number of emails sent per claim, it is small since every email is an SMTP session
+const emailBatchSize = 10
+
+type EmailSender interface {
+	DeliverDue(ctx context.Context, limit int) (int, error)
+}
+
+// EmailWorker periodically sends queued emails whose attempt is due
+type EmailWorker struct {
+	sender   EmailSender
+	interval time.Duration
+}
+
+func NewEmailWorker(sender EmailSender, interval time.Duration) *EmailWorker {
+	return &EmailWorker{
+		sender:   sender,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *EmailWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce drains due emails batch by batch, a short batch means the queue is empty
+func (w *EmailWorker) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := w.sender.DeliverDue(ctx, emailBatchSize)
+		if err != nil {
+			slog.Error("failed to send emails", "error", err)
+			return
+		}
+		if claimed < emailBatchSize {
+			return
+		}
+	}
+}
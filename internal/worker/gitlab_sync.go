@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// gitlabSyncBatchSize is the number of merge requests whose reviewers are set per claim
+const gitlabSyncBatchSize = 10
+
+type GitLabReviewerSyncer interface {
+	SyncDueReviewers(ctx context.Context, limit int) (int, error)
+}
+
+// GitLabSyncWorker periodically sets the queued reviewers of merge requests in GitLab
+type GitLabSyncWorker struct {
+	syncer   GitLabReviewerSyncer
+	interval time.Duration
+}
+
+func NewGitLabSyncWorker(syncer GitLabReviewerSyncer, interval time.Duration) *GitLabSyncWorker {
+	return &GitLabSyncWorker{
+		syncer:   syncer,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *GitLabSyncWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce drains due syncs batch by batch, a short batch means the queue is empty
+func (w *GitLabSyncWorker) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := w.syncer.SyncDueReviewers(ctx, gitlabSyncBatchSize)
+		if err != nil {
+			slog.Error("failed to set gitlab reviewers", "error", err)
+			return
+		}
+		if claimed < gitlabSyncBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type HandoverService interface {
+	HandOverAbsentReviews(ctx context.Context, now time.Time) (*domain.HandoverResult, error)
+}
+
+// HandoverWorker periodically hands over open reviews of users whose absence has started
+type HandoverWorker struct {
+	service  HandoverService
+	interval time.Duration
+}
+
+func NewHandoverWorker(service HandoverService, interval time.Duration) *HandoverWorker {
+	return &HandoverWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *HandoverWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *HandoverWorker) runOnce(ctx context.Context) {
+	result, err := w.service.HandOverAbsentReviews(ctx, time.Now())
+	if err != nil {
+		slog.Error("failed to hand over reviews of absent users", "error", err)
+		return
+	}
+
+	if len(result.HandedOverUsers) > 0 {
+		slog.Info("handed over reviews of absent users",
+			"users", result.HandedOverUsers,
+			"reassigned_prs", len(result.ReassignedPRs),
+			"understaffed_prs", result.UnderstaffedPRs,
+			"senior_missing_prs", result.SeniorMissingPRs,
+		)
+	}
+}
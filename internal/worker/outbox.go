@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// outboxBatchSize is the number of events relayed per transaction
+const outboxBatchSize = 100
+
+type OutboxRelay interface {
+	Relay(ctx context.Context, limit int) (int, error)
+}
+
+// OutboxWorker periodically publishes domain events stored in the outbox.
+// Several replicas can run it at once, each event is locked by one of them
+type OutboxWorker struct {
+	relay    OutboxRelay
+	interval time.Duration
+}
+
+func NewOutboxWorker(relay OutboxRelay, interval time.Duration) *OutboxWorker {
+	return &OutboxWorker{
+		relay:    relay,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce drains the outbox batch by batch, a short batch means it is empty or the rest is locked
+func (w *OutboxWorker) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := w.relay.Relay(ctx, outboxBatchSize)
+		if err != nil {
+			slog.Error("failed to relay outbox events", "published", published, "error", err)
+			return
+		}
+		if published < outboxBatchSize {
+			return
+		}
+	}
+}
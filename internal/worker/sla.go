@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service/internal/domain"
+)
+
+type SLAChecker interface {
+	CheckBreaches(ctx context.Context, now time.Time) (*domain.SLACheckResult, error)
+}
+
+// SLAWorker periodically records review SLA breaches and escalates them to team leads.
+// The interval only bounds the delay, each breach is notified once
+type SLAWorker struct {
+	checker  SLAChecker
+	interval time.Duration
+}
+
+func NewSLAWorker(checker SLAChecker, interval time.Duration) *SLAWorker {
+	return &SLAWorker{
+		checker:  checker,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *SLAWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *SLAWorker) runOnce(ctx context.Context) {
+	result, err := w.checker.CheckBreaches(ctx, time.Now())
+	if err != nil {
+		slog.Error("failed to check review SLAs", "error", err)
+		return
+	}
+	if result.Breached > 0 || result.Escalated > 0 || result.Resolved > 0 {
+		slog.Info("checked review SLAs", "breached", result.Breached, "escalated", result.Escalated, "resolved", result.Resolved)
+	}
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// webhookBatchSize is the number of deliveries sent per claim
+const webhookBatchSize = 50
+
+type WebhookSender interface {
+	DeliverDue(ctx context.Context, limit int) (int, error)
+}
+
+// WebhookWorker periodically sends webhook deliveries whose attempt is due
+type WebhookWorker struct {
+	sender   WebhookSender
+	interval time.Duration
+}
+
+func NewWebhookWorker(sender WebhookSender, interval time.Duration) *WebhookWorker {
+	return &WebhookWorker{
+		sender:   sender,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce drains due deliveries batch by batch, a short batch means the queue is empty
+func (w *WebhookWorker) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := w.sender.DeliverDue(ctx, webhookBatchSize)
+		if err != nil {
+			slog.Error("failed to send webhook deliveries", "error", err)
+			return
+		}
+		if claimed < webhookBatchSize {
+			return
+		}
+	}
+}
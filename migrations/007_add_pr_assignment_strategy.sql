@@ -0,0 +1,7 @@
+-- +goose Up
+-- Стратегия, по которой были выбраны ревьюеры PR. Для уже существующих PR это был случайный выбор
+ALTER TABLE pull_requests
+    ADD COLUMN assignment_strategy VARCHAR(32) NOT NULL DEFAULT 'random';
+
+-- +goose Down
+ALTER TABLE pull_requests DROP COLUMN assignment_strategy;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE team_settings (
+                               team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+                               reviewer_count INT NOT NULL DEFAULT 2 CHECK (reviewer_count BETWEEN 0 AND 10),
+                               min_reviewers INT NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0),
+                               reviewer_strategy VARCHAR(32) NOT NULL DEFAULT 'least_loaded'
+                                   CHECK (reviewer_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted')),
+                               max_open_reviews INT CHECK (max_open_reviews > 0),
+                               updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                               CHECK (min_reviewers <= reviewer_count)
+);
+
+-- Существующие команды получают настройки по умолчанию
+INSERT INTO team_settings (team_name)
+SELECT team_name FROM teams;
+
+-- +goose Down
+DROP TABLE team_settings;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE code_owner_rules (
+                                  id SERIAL PRIMARY KEY,
+                                  position INT NOT NULL UNIQUE,
+                                  pattern TEXT NOT NULL,
+                                  owner_users TEXT[] NOT NULL DEFAULT '{}',
+                                  owner_teams TEXT[] NOT NULL DEFAULT '{}',
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE team_settings
+    ADD COLUMN code_owners_mode VARCHAR(16) NOT NULL DEFAULT 'prefer'
+        CHECK (code_owners_mode IN ('off', 'prefer', 'require'));
+
+-- +goose Down
+ALTER TABLE team_settings DROP COLUMN code_owners_mode;
+ALTER TABLE pull_requests DROP COLUMN changed_files;
+DROP TABLE code_owner_rules;
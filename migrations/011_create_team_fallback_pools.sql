@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE team_fallback_pools (
+                                     team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+                                     fallback_team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+                                     priority INT NOT NULL,
+                                     PRIMARY KEY (team_name, fallback_team_name),
+                                     UNIQUE (team_name, priority),
+                                     CHECK (team_name <> fallback_team_name)
+);
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN is_fallback BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE pr_reviewers DROP COLUMN is_fallback;
+DROP TABLE team_fallback_pools;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Периоды отсутствия пользователей (отпуск, больничный и т.д.)
+CREATE TABLE user_availability (
+                                   id BIGSERIAL PRIMARY KEY,
+                                   user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                   starts_at TIMESTAMP NOT NULL,
+                                   ends_at TIMESTAMP NOT NULL,
+                                   reason TEXT NOT NULL DEFAULT '',
+                                   hand_over_reviews BOOLEAN NOT NULL DEFAULT false,
+                                   handed_over_at TIMESTAMP,
+                                   created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                   CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_availability_user_period ON user_availability(user_id, starts_at, ends_at);
+CREATE INDEX idx_user_availability_pending_handover ON user_availability(starts_at)
+    WHERE hand_over_reviews AND handed_over_at IS NULL;
+
+-- +goose Down
+DROP TABLE user_availability;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Личный лимит открытых ревью (NULL - без лимита) и вес при выборе ревьюеров
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INT CHECK (max_open_reviews > 0),
+    ADD COLUMN review_weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (review_weight > 0);
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN review_weight,
+    DROP COLUMN max_open_reviews;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Сид генератора и входные данные первичного выбора ревьюеров, чтобы его можно было воспроизвести
+ALTER TABLE pull_requests
+    ADD COLUMN assignment_seed BIGINT,
+    ADD COLUMN assignment_trace JSONB;
+
+-- +goose Down
+ALTER TABLE pull_requests
+    DROP COLUMN assignment_trace,
+    DROP COLUMN assignment_seed;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Навыки пользователей (go, postgres, frontend, security...) и навыки, которые требуются для ревью PR
+ALTER TABLE users
+    ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests
+    ADD COLUMN required_skills TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE pull_requests
+    DROP COLUMN required_skills;
+
+ALTER TABLE users
+    DROP COLUMN skills;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Уровень пользователя и политика команды: минимум ревьюеров уровня senior_level и выше
+ALTER TABLE users
+    ADD COLUMN seniority VARCHAR(16) NOT NULL DEFAULT 'middle'
+        CHECK (seniority IN ('junior', 'middle', 'senior', 'lead'));
+
+ALTER TABLE team_settings
+    ADD COLUMN min_senior_reviewers INT NOT NULL DEFAULT 0 CHECK (min_senior_reviewers >= 0),
+    ADD COLUMN senior_level VARCHAR(16) NOT NULL DEFAULT 'senior'
+        CHECK (senior_level IN ('junior', 'middle', 'senior', 'lead')),
+    ADD CONSTRAINT team_settings_min_senior_reviewers_check CHECK (min_senior_reviewers <= reviewer_count);
+
+-- +goose Down
+ALTER TABLE team_settings
+    DROP CONSTRAINT team_settings_min_senior_reviewers_check,
+    DROP COLUMN senior_level,
+    DROP COLUMN min_senior_reviewers;
+
+ALTER TABLE users
+    DROP COLUMN seniority;
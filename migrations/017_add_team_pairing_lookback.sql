@@ -0,0 +1,12 @@
+-- +goose Up
+-- Сколько последних PR автора учитывать, чтобы реже назначать ему одних и тех же ревьюеров (0 - не учитывать)
+ALTER TABLE team_settings
+    ADD COLUMN pairing_lookback INT NOT NULL DEFAULT 10 CHECK (pairing_lookback BETWEEN 0 AND 100);
+
+CREATE INDEX idx_pull_requests_author_created_at ON pull_requests(author_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pull_requests_author_created_at;
+
+ALTER TABLE team_settings
+    DROP COLUMN pairing_lookback;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Состояние ревью у каждого ревьюера и политика мержа команды
+ALTER TABLE pr_reviewers
+    ADD COLUMN review_state VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (review_state IN ('pending', 'approved', 'changes_requested', 'commented')),
+    ADD COLUMN reviewed_at TIMESTAMP;
+
+ALTER TABLE team_settings
+    ADD COLUMN merge_min_approvals INT NOT NULL DEFAULT 0 CHECK (merge_min_approvals >= 0),
+    ADD COLUMN merge_block_on_changes_requested BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN merge_require_team_approval BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE team_settings
+    DROP COLUMN merge_require_team_approval,
+    DROP COLUMN merge_block_on_changes_requested,
+    DROP COLUMN merge_min_approvals;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN reviewed_at,
+    DROP COLUMN review_state;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Статусы CLOSED (закрыт без мержа) и DRAFT (ревьюеры назначаются только после выхода из черновика)
+ALTER TABLE pull_requests
+    DROP CONSTRAINT pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED', 'DRAFT')),
+    ADD COLUMN closed_at TIMESTAMP;
+
+-- +goose Down
+UPDATE pull_requests SET status = 'OPEN' WHERE status IN ('CLOSED', 'DRAFT');
+
+ALTER TABLE pull_requests
+    DROP COLUMN closed_at,
+    DROP CONSTRAINT pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED'));
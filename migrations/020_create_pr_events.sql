@@ -0,0 +1,42 @@
+-- +goose Up
+-- История PR (append-only): создание, назначения и замены ревьюеров, ревью, мерж и смены статуса.
+-- actor_id пустой, если изменение сделано фоновой задачей
+CREATE TABLE pr_events (
+                           id BIGSERIAL PRIMARY KEY,
+                           pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+                           event_type VARCHAR(32) NOT NULL CHECK (event_type IN (
+                               'created', 'assigned', 'unassigned', 'reassigned', 'reviewed', 'merged', 'status_changed'
+                           )),
+                           actor_id VARCHAR(255),
+                           reviewer_id VARCHAR(255),
+                           previous_reviewer_id VARCHAR(255),
+                           from_status VARCHAR(20),
+                           to_status VARCHAR(20),
+                           review_state VARCHAR(32),
+                           reason VARCHAR(32) NOT NULL DEFAULT '',
+                           created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pr_events_pr_created ON pr_events(pull_request_id, created_at, id);
+
+-- Восстанавливаем то, что известно о существующих PR; прежние замены ревьюеров не сохранились
+INSERT INTO pr_events (pull_request_id, event_type, to_status, reason, created_at)
+SELECT pull_request_id, 'created', CASE WHEN status = 'DRAFT' THEN 'DRAFT' ELSE 'OPEN' END, 'backfill', created_at
+FROM pull_requests;
+
+INSERT INTO pr_events (pull_request_id, event_type, reviewer_id, reason, created_at)
+SELECT pull_request_id, 'assigned', user_id, 'backfill', assigned_at
+FROM pr_reviewers;
+
+INSERT INTO pr_events (pull_request_id, event_type, from_status, to_status, reason, created_at)
+SELECT pull_request_id, 'merged', 'OPEN', 'MERGED', 'backfill', merged_at
+FROM pull_requests
+WHERE merged_at IS NOT NULL;
+
+INSERT INTO pr_events (pull_request_id, event_type, to_status, reason, created_at)
+SELECT pull_request_id, 'status_changed', 'CLOSED', 'backfill', closed_at
+FROM pull_requests
+WHERE closed_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE pr_events;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Журнал изменяющих вызовов API: кто, в рамках какого запроса, что изменил и чем закончилось.
+-- changes хранит только отличающиеся поля: {"поле": {"before": ..., "after": ...}}
+CREATE TABLE audit_log (
+                           id BIGSERIAL PRIMARY KEY,
+                           actor_id VARCHAR(255) NOT NULL,
+                           request_id VARCHAR(255) NOT NULL DEFAULT '',
+                           action VARCHAR(255) NOT NULL,
+                           target_type VARCHAR(64) NOT NULL DEFAULT '',
+                           target_id VARCHAR(255) NOT NULL DEFAULT '',
+                           changes JSONB NOT NULL DEFAULT '{}',
+                           outcome VARCHAR(16) NOT NULL CHECK (outcome IN ('success', 'failure')),
+                           status_code INT NOT NULL,
+                           error TEXT NOT NULL DEFAULT '',
+                           created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id);
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id);
+
+-- +goose Down
+DROP TABLE audit_log;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Подписки на исходящие вебхуки: URL, типы событий и секрет для HMAC-подписи
+CREATE TABLE webhook_subscriptions (
+                                       id BIGSERIAL PRIMARY KEY,
+                                       url TEXT NOT NULL,
+                                       event_types TEXT[] NOT NULL,
+                                       secret VARCHAR(255) NOT NULL,
+                                       is_active BOOLEAN NOT NULL DEFAULT true,
+                                       created_by VARCHAR(255) NOT NULL DEFAULT '',
+                                       created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Журнал доставок: каждая доставка повторяется с экспоненциальной задержкой до успеха или исчерпания попыток.
+-- Ручная повторная доставка создаёт новую запись со ссылкой на исходную (redelivery_of)
+CREATE TABLE webhook_deliveries (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+                                    event_id VARCHAR(64) NOT NULL,
+                                    event_type VARCHAR(64) NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    last_attempt_at TIMESTAMP,
+                                    last_status_code INT,
+                                    last_error TEXT NOT NULL DEFAULT '',
+                                    delivered_at TIMESTAMP,
+                                    redelivery_of BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, id);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
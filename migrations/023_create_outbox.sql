@@ -0,0 +1,15 @@
+-- +goose Up
+-- Outbox доменных событий: строки пишутся в той же транзакции, что и изменение состояния,
+-- а фоновый relay публикует их по порядку id и проставляет published_at (доставка at-least-once)
+CREATE TABLE outbox (
+                        id BIGSERIAL PRIMARY KEY,
+                        event_type VARCHAR(64) NOT NULL,
+                        payload JSONB NOT NULL,
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        published_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE outbox;
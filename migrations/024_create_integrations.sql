@@ -0,0 +1,24 @@
+-- +goose Up
+-- Связь логинов во внешних системах (GitHub) с пользователями сервиса
+CREATE TABLE integration_accounts (
+                                      provider VARCHAR(32) NOT NULL,
+                                      login VARCHAR(255) NOT NULL,
+                                      user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                      created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                      PRIMARY KEY (provider, login)
+);
+
+-- Принятые входящие вебхуки: повторная доставка с тем же delivery_id не применяется второй раз
+CREATE TABLE integration_deliveries (
+                                        provider VARCHAR(32) NOT NULL,
+                                        delivery_id VARCHAR(255) NOT NULL,
+                                        event VARCHAR(64) NOT NULL,
+                                        action VARCHAR(64) NOT NULL DEFAULT '',
+                                        pull_request_id VARCHAR(255) NOT NULL DEFAULT '',
+                                        received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                        PRIMARY KEY (provider, delivery_id)
+);
+
+-- +goose Down
+DROP TABLE integration_deliveries;
+DROP TABLE integration_accounts;
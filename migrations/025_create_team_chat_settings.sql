@@ -0,0 +1,13 @@
+-- +goose Up
+-- Уведомления команды в чат через incoming webhook (Slack/Mattermost). Пустой шаблон - шаблон по умолчанию
+CREATE TABLE team_chat_settings (
+                                    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+                                    webhook_url TEXT NOT NULL,
+                                    assigned_template TEXT NOT NULL DEFAULT '',
+                                    reassigned_template TEXT NOT NULL DEFAULT '',
+                                    merged_template TEXT NOT NULL DEFAULT '',
+                                    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE team_chat_settings;
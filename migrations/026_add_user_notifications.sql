@@ -0,0 +1,26 @@
+-- +goose Up
+-- Почта пользователя и способ уведомлений: immediate - письмо сразу, digest - в ежедневной сводке, off - без писем
+ALTER TABLE users
+    ADD COLUMN email VARCHAR(320),
+    ADD COLUMN notification_preference VARCHAR(16) NOT NULL DEFAULT 'immediate'
+        CHECK (notification_preference IN ('immediate', 'digest', 'off'));
+
+-- Уведомления пользователей с digest, ждущие ежедневной сводки
+CREATE TABLE notification_digest_items (
+                                           id BIGSERIAL PRIMARY KEY,
+                                           user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                           event_type VARCHAR(64) NOT NULL,
+                                           pull_request_id VARCHAR(255) NOT NULL,
+                                           summary TEXT NOT NULL,
+                                           created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                           sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_notification_digest_items_pending ON notification_digest_items(user_id, id) WHERE sent_at IS NULL;
+
+-- +goose Down
+DROP TABLE notification_digest_items;
+
+ALTER TABLE users
+    DROP COLUMN notification_preference,
+    DROP COLUMN email;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Часовой пояс пользователя и час отправки ежедневной сводки по его местному времени
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN digest_hour SMALLINT NOT NULL DEFAULT 9 CHECK (digest_hour BETWEEN 0 AND 23);
+
+-- Отправленные сводки: не больше одной на пользователя за местный день, даже при нескольких репликах
+CREATE TABLE user_digests (
+                              user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                              local_date DATE NOT NULL,
+                              sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              PRIMARY KEY (user_id, local_date)
+);
+
+-- +goose Down
+DROP TABLE user_digests;
+
+ALTER TABLE users
+    DROP COLUMN digest_hour,
+    DROP COLUMN timezone;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- SLA ревью команды в рабочих часах (0 - без SLA) и через сколько рабочих часов после нарушения эскалировать лиду
+ALTER TABLE team_settings
+    ADD COLUMN review_sla_hours INT NOT NULL DEFAULT 0 CHECK (review_sla_hours BETWEEN 0 AND 1000),
+    ADD COLUMN sla_escalation_hours INT NOT NULL DEFAULT 8 CHECK (sla_escalation_hours BETWEEN 0 AND 1000);
+
+-- Лид команды, не больше одного на команду
+ALTER TABLE users
+    ADD COLUMN is_team_lead BOOLEAN NOT NULL DEFAULT false;
+
+CREATE UNIQUE INDEX idx_users_team_lead ON users(team_name) WHERE is_team_lead;
+
+-- Нарушения SLA: одно на назначение ревьюера, закрывается ревью, снятием ревьюера или закрытием PR
+CREATE TABLE sla_breaches (
+                              id BIGSERIAL PRIMARY KEY,
+                              pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+                              reviewer_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                              team_name VARCHAR(255) NOT NULL,
+                              sla_hours INT NOT NULL,
+                              assigned_at TIMESTAMP NOT NULL,
+                              due_at TIMESTAMP NOT NULL,
+                              breached_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              escalated_at TIMESTAMP,
+                              escalated_to VARCHAR(255),
+                              resolved_at TIMESTAMP,
+                              UNIQUE (pull_request_id, reviewer_id, assigned_at)
+);
+
+CREATE INDEX idx_sla_breaches_open ON sla_breaches(id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_sla_breaches_team ON sla_breaches(team_name, id DESC);
+
+-- +goose Down
+DROP TABLE sla_breaches;
+
+DROP INDEX IF EXISTS idx_users_team_lead;
+
+ALTER TABLE users
+    DROP COLUMN is_team_lead;
+
+ALTER TABLE team_settings
+    DROP COLUMN sla_escalation_hours,
+    DROP COLUMN review_sla_hours;
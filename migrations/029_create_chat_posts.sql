@@ -0,0 +1,21 @@
+-- +goose Up
+-- Очередь сообщений в чат команды: сообщение отправляется фоновой задачей вне транзакции outbox
+-- и повторяется с экспоненциальной задержкой, как доставки вебхуков. Отключение чата удаляет неотправленные сообщения
+CREATE TABLE chat_posts (
+                            id BIGSERIAL PRIMARY KEY,
+                            team_name VARCHAR(255) NOT NULL REFERENCES team_chat_settings(team_name) ON DELETE CASCADE,
+                            event_type VARCHAR(64) NOT NULL,
+                            text TEXT NOT NULL,
+                            status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+                            attempts INT NOT NULL DEFAULT 0,
+                            next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                            last_attempt_at TIMESTAMP,
+                            last_error TEXT NOT NULL DEFAULT '',
+                            delivered_at TIMESTAMP,
+                            created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_chat_posts_due ON chat_posts(next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE chat_posts;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Очередь писем режима immediate: письмо отрисовывается при публикации события, а отправляется фоновой задачей
+-- вне транзакции outbox и повторяется с экспоненциальной задержкой, как доставки вебхуков
+CREATE TABLE outgoing_emails (
+                                 id BIGSERIAL PRIMARY KEY,
+                                 user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                 event_type VARCHAR(64) NOT NULL,
+                                 recipient VARCHAR(320) NOT NULL,
+                                 subject TEXT NOT NULL,
+                                 html TEXT NOT NULL,
+                                 status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+                                 attempts INT NOT NULL DEFAULT 0,
+                                 next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 last_attempt_at TIMESTAMP,
+                                 last_error TEXT NOT NULL DEFAULT '',
+                                 delivered_at TIMESTAMP,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outgoing_emails_due ON outgoing_emails(next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE outgoing_emails;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Сид и кандидаты выбора нового ревьюера при замене стратегией команды, чтобы замену можно было воспроизвести.
+-- У ручных замен и событий других типов пусто
+ALTER TABLE pr_events ADD COLUMN assignment_trace JSONB;
+
+-- +goose Down
+ALTER TABLE pr_events DROP COLUMN assignment_trace;
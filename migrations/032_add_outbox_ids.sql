@@ -0,0 +1,24 @@
+-- +goose Up
+-- Событие outbox, из которого создана строка очереди. Relay может опубликовать событие повторно
+-- (сбой другого паблишера или падение до отметки о публикации), уникальные индексы не дают создать его строки дважды.
+-- У повторных доставок вебхуков, запрошенных вручную, и у строк, созданных до миграции, outbox_id пуст
+ALTER TABLE webhook_deliveries ADD COLUMN outbox_id BIGINT;
+ALTER TABLE chat_posts ADD COLUMN outbox_id BIGINT;
+ALTER TABLE outgoing_emails ADD COLUMN outbox_id BIGINT;
+ALTER TABLE notification_digest_items ADD COLUMN outbox_id BIGINT;
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_outbox ON webhook_deliveries(outbox_id, subscription_id);
+CREATE UNIQUE INDEX idx_chat_posts_outbox ON chat_posts(outbox_id);
+CREATE UNIQUE INDEX idx_outgoing_emails_outbox ON outgoing_emails(outbox_id);
+CREATE UNIQUE INDEX idx_notification_digest_items_outbox ON notification_digest_items(outbox_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notification_digest_items_outbox;
+DROP INDEX IF EXISTS idx_outgoing_emails_outbox;
+DROP INDEX IF EXISTS idx_chat_posts_outbox;
+DROP INDEX IF EXISTS idx_webhook_deliveries_outbox;
+
+ALTER TABLE notification_digest_items DROP COLUMN outbox_id;
+ALTER TABLE outgoing_emails DROP COLUMN outbox_id;
+ALTER TABLE chat_posts DROP COLUMN outbox_id;
+ALTER TABLE webhook_deliveries DROP COLUMN outbox_id;
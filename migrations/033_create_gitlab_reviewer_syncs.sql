@@ -0,0 +1,23 @@
+-- +goose Up
+-- Очередь выставления ревьюеров merge request в GitLab: запись создаётся при применении вебхука GitLab,
+-- а отправляется фоновой задачей и повторяется с экспоненциальной задержкой, как доставки вебхуков.
+-- Логины GitLab берутся при отправке, поэтому учитываются аккаунты, привязанные после постановки в очередь
+CREATE TABLE gitlab_reviewer_syncs (
+                                       id BIGSERIAL PRIMARY KEY,
+                                       pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+                                       project_id BIGINT NOT NULL,
+                                       iid INT NOT NULL,
+                                       reviewer_ids TEXT[] NOT NULL,
+                                       status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+                                       attempts INT NOT NULL DEFAULT 0,
+                                       next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                       last_attempt_at TIMESTAMP,
+                                       last_error TEXT NOT NULL DEFAULT '',
+                                       delivered_at TIMESTAMP,
+                                       created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_gitlab_reviewer_syncs_due ON gitlab_reviewer_syncs(next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE gitlab_reviewer_syncs;
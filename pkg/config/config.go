@@ -20,6 +20,33 @@ type Config struct {
 	PostgresDatabase string
 	PostgresSSLMode  string
 	JWTSecret        string
+	// GitHubWebhookSecret verifies GitHub webhooks, they are rejected when it is empty
+	GitHubWebhookSecret string
+	// GitLabWebhookToken verifies GitLab webhooks, they are rejected when it is empty
+	GitLabWebhookToken string
+	// GitLabURL and GitLabAPIToken enable posting the assigned reviewers back to GitLab
+	GitLabURL      string
+	GitLabAPIToken string
+	// SMTPHost enables email notifications, SMTPUsername is empty when the server needs no authentication
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	HandoverInterval time.Duration
+	OutboxInterval   time.Duration
+	WebhookInterval  time.Duration
+	// EmailInterval is how often queued emails are sent
+	EmailInterval time.Duration
+	// DigestInterval is how often due daily digests are looked for
+	DigestInterval time.Duration
+	// SLACheckInterval is how often review SLA breaches are looked for
+	SLACheckInterval time.Duration
+	// WebhookTimeout limits a single webhook request
+	WebhookTimeout time.Duration
+	// AssignmentSeed makes reviewer assignment reproducible when set
+	AssignmentSeed *int64
 
 	MaxConns          int32
 	MinConns          int32
@@ -83,6 +110,31 @@ func Load(envFiles ...string) (*Config, error) {
 		MaxConnLifetime:   getEnvAsDuration("DB_MAX_CONN_LIFETIME", time.Hour),
 		MaxConnIdleTime:   getEnvAsDuration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute),
 		HealthCheckPeriod: getEnvAsDuration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+		HandoverInterval:  getEnvAsDuration("HANDOVER_INTERVAL", 5*time.Minute),
+		OutboxInterval:    getEnvAsDuration("OUTBOX_INTERVAL", time.Second),
+		WebhookInterval:   getEnvAsDuration("WEBHOOK_INTERVAL", 10*time.Second),
+		WebhookTimeout:    getEnvAsDuration("WEBHOOK_TIMEOUT", 5*time.Second),
+		EmailInterval:     getEnvAsDuration("EMAIL_INTERVAL", 10*time.Second),
+		DigestInterval:    getEnvAsDuration("DIGEST_INTERVAL", 5*time.Minute),
+		SLACheckInterval:  getEnvAsDuration("SLA_CHECK_INTERVAL", 5*time.Minute),
+	}
+
+	cfg.GitHubWebhookSecret = os.Getenv("GITHUB_WEBHOOK_SECRET")
+	cfg.GitLabWebhookToken = os.Getenv("GITLAB_WEBHOOK_TOKEN")
+	cfg.GitLabURL = os.Getenv("GITLAB_URL")
+	cfg.GitLabAPIToken = os.Getenv("GITLAB_API_TOKEN")
+	cfg.SMTPHost = os.Getenv("SMTP_HOST")
+	cfg.SMTPPort = getEnvWithDefault("SMTP_PORT", "587")
+	cfg.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	cfg.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	cfg.SMTPFrom = getEnvWithDefault("SMTP_FROM", "pr-reviewer@localhost")
+
+	if value := os.Getenv("ASSIGNMENT_SEED"); value != "" {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ASSIGNMENT_SEED must be an integer: %w", err)
+		}
+		cfg.AssignmentSeed = &seed
 	}
 
 	slog.Info("configuration loaded", "port", cfg.Port, "db_host", cfg.PostgresHost)
@@ -45,7 +45,9 @@ func BenchmarkBatchDeactivateUsers(b *testing.B) {
 	prRepo := repository.NewPRRepository(pool)
 
 	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, userRepo, prRepo)
+	availabilityRepo := repository.NewAvailabilityRepository(pool)
+	reviewerAssigner := service.NewReviewerAssigner(userRepo, prRepo, teamRepo, repository.NewCodeOwnersRepository(pool), availabilityRepo)
+	userService := service.NewUserService(userRepo, userRepo, prRepo, availabilityRepo, reviewerAssigner, service.NewRandomSeedSource())
 
 	testCases := []struct {
 		name       string
@@ -105,7 +107,7 @@ func BenchmarkBatchDeactivateUsers(b *testing.B) {
 				}
 				pr.AssignedReviewers = reviewers
 
-				err := prRepo.CreatePR(ctx, pr)
+				err := prRepo.CreatePR(ctx, pr, domain.EventSource{Reason: domain.EventReasonAssignment})
 				require.NoError(b, err)
 
 				originalAssignments = append(originalAssignments, prReviewers{
@@ -171,7 +173,7 @@ func BenchmarkSingleUserDeactivation(b *testing.B) {
 	userRepo := repository.NewUserRepository(pool)
 	teamRepo := repository.NewTeamRepository(pool)
 
-	teamRepo.CreateTeam(ctx, "testteam")
+	teamRepo.CreateTeam(ctx, "testteam", domain.DefaultReviewerStrategy)
 	user := &domain.User{
 		UserID:   "testuser",
 		Username: "Test User",
@@ -222,6 +224,7 @@ func cleanupBenchmarkDB(b testing.TB, pool *pgxpool.Pool) {
 		"TRUNCATE TABLE users CASCADE",
 		"TRUNCATE TABLE teams CASCADE",
 		"TRUNCATE TABLE auth_tokens CASCADE",
+		"TRUNCATE TABLE outbox",
 	}
 
 	for _, query := range queries {
@@ -4,18 +4,31 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
+	"mime"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"pr-reviewer-service/internal/domain"
+	"pr-reviewer-service/internal/dto"
 	"pr-reviewer-service/internal/request"
 	"pr-reviewer-service/internal/response"
 	"pr-reviewer-service/pkg/config"
 
+	"pr-reviewer-service/internal/gitlab"
 	"pr-reviewer-service/internal/handler"
+	"pr-reviewer-service/internal/mail"
 	"pr-reviewer-service/internal/repository"
 	"pr-reviewer-service/internal/router"
 	"pr-reviewer-service/internal/service"
@@ -26,10 +39,213 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const e2eAssignmentSeed = 42
+
 type E2ETestSuite struct {
 	pool   *pgxpool.Pool
 	server *httptest.Server
 	token  string
+	// outbox and webhooks run the background relay and sender on demand
+	outbox   *service.OutboxService
+	webhooks *service.WebhookService
+	// chat sends the queued chat posts on demand
+	chat *service.ChatService
+	// gitlab is the GitLab API the reviewers are posted back to
+	gitlab *fakeGitLab
+	// integrations posts the queued reviewers back to GitLab on demand
+	integrations *service.IntegrationService
+	// smtp receives the email notifications
+	smtp *smtpSink
+	// emails sends the queued email notifications on demand
+	emails *service.EmailService
+	// digests sends the daily digests on demand
+	digests *service.DigestService
+	// sla checks the review SLAs on demand
+	sla *service.SLAService
+	// users hands over reviews of absent users on demand
+	users *service.UserService
+}
+
+// fakeGitLab serves the part of the GitLab API used to set merge request reviewers
+type fakeGitLab struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+	// userIDs are the GitLab users by username
+	userIDs map[string]int64
+	// reviewers are the reviewer IDs set per request path
+	reviewers map[string][]int64
+	// failing makes setting the reviewers fail
+	failing bool
+}
+
+const fakeGitLabToken = "gitlab-api-token"
+
+func newFakeGitLab() *fakeGitLab {
+	fake := &fakeGitLab{
+		userIDs:   map[string]int64{},
+		reviewers: map[string][]int64{},
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != fakeGitLabToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/users":
+			users := []map[string]int64{}
+			fake.mu.Lock()
+			if id, ok := fake.userIDs[r.URL.Query().Get("username")]; ok {
+				users = append(users, map[string]int64{"id": id})
+			}
+			fake.mu.Unlock()
+			_ = json.NewEncoder(w).Encode(users)
+		case r.Method == http.MethodPut && fake.isFailing():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Method == http.MethodPut:
+			var body struct {
+				ReviewerIDs []int64 `json:"reviewer_ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fake.mu.Lock()
+			fake.reviewers[r.URL.Path] = body.ReviewerIDs
+			fake.mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return fake
+}
+
+func (f *fakeGitLab) addUser(username string, id int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.userIDs[username] = id
+}
+
+func (f *fakeGitLab) setFailing(failing bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failing = failing
+}
+
+func (f *fakeGitLab) isFailing() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.failing
+}
+
+func (f *fakeGitLab) reviewersOf(path string) ([]int64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	reviewers, ok := f.reviewers[path]
+	return reviewers, ok
+}
+
+// smtpSink is an SMTP server that keeps the messages it accepts, it rejects them while failing is set
+type smtpSink struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []sinkMessage
+	failing  bool
+}
+
+type sinkMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	sink := &smtpSink{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go sink.serve(conn)
+		}
+	}()
+	return sink
+}
+
+func (s *smtpSink) port() string {
+	return strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 sink ready")
+
+	var message sinkMessage
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250 sink")
+		case "RCPT":
+			message.To = strings.Trim(strings.TrimPrefix(line[len("RCPT TO:"):], " "), "<>")
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 end with <CRLF>.<CRLF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			headers, body, _ := strings.Cut(string(data), "\n\n")
+			for _, header := range strings.Split(headers, "\n") {
+				if value, ok := strings.CutPrefix(header, "Subject: "); ok {
+					message.Subject, _ = new(mime.WordDecoder).DecodeHeader(value)
+				}
+			}
+			message.Body = body
+			s.mu.Lock()
+			failing := s.failing
+			if !failing {
+				s.messages = append(s.messages, message)
+			}
+			s.mu.Unlock()
+			message = sinkMessage{}
+			if failing {
+				_ = text.PrintfLine("451 try later")
+			} else {
+				_ = text.PrintfLine("250 OK")
+			}
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpSink) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+// received returns the messages since the previous call
+func (s *smtpSink) received() []sinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := s.messages
+	s.messages = nil
+	return messages
 }
 
 func setupE2ETest(t *testing.T) *E2ETestSuite {
@@ -51,14 +267,45 @@ func setupE2ETest(t *testing.T) *E2ETestSuite {
 	userRepo := repository.NewUserRepository(pool)
 	prRepo := repository.NewPRRepository(pool)
 	statsRepo := repository.NewStatisticsRepository(pool)
+	codeOwnersRepo := repository.NewCodeOwnersRepository(pool)
+	availabilityRepo := repository.NewAvailabilityRepository(pool)
+	auditRepo := repository.NewAuditRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
+	integrationRepo := repository.NewIntegrationRepository(pool)
+	chatRepo := repository.NewChatRepository(pool)
+	notificationRepo := repository.NewNotificationRepository(pool)
+	slaRepo := repository.NewSLARepository(pool)
 
 	validate := validator.New()
 
 	authService := service.NewAuthService(authRepo, userRepo, cfg.JWTSecret)
 	teamService := service.NewTeamService(teamRepo, userRepo)
-	userService := service.NewUserService(userRepo, userRepo, prRepo)
-	prService := service.NewPRService(prRepo, userRepo)
-	statsService := service.NewStatisticsService(statsRepo)
+	// fixed seed makes reviewer assignment reproducible between runs
+	seeds := service.NewSeedSource(e2eAssignmentSeed)
+	webhookService := service.NewWebhookService(webhookRepo, &http.Client{Timeout: 5 * time.Second})
+	reviewerAssigner := service.NewReviewerAssigner(userRepo, prRepo, teamRepo, codeOwnersRepo, availabilityRepo)
+	userService := service.NewUserService(userRepo, userRepo, prRepo, availabilityRepo, reviewerAssigner, seeds)
+	prService := service.NewPRService(prRepo, userRepo, reviewerAssigner, seeds)
+	statsService := service.NewStatisticsService(statsRepo, teamRepo)
+	codeOwnersService := service.NewCodeOwnersService(codeOwnersRepo)
+	auditService := service.NewAuditService(auditRepo)
+	chatService := service.NewChatService(chatRepo, teamRepo, userRepo, prRepo, &http.Client{Timeout: 5 * time.Second})
+	smtp := newSMTPSink(t)
+	mailer := mail.NewSMTPMailer("127.0.0.1", smtp.port(), "", "", "reviewer@example.com")
+	emailService := service.NewEmailService(notificationRepo, userRepo, prRepo, mailer)
+	outboxService := service.NewOutboxService(outboxRepo, webhookService, chatService, emailService)
+	digestService := service.NewDigestService(notificationRepo, userRepo, prRepo, mailer)
+	slaService := service.NewSLAService(slaRepo)
+	gitlabAPI := newFakeGitLab()
+	integrationService := service.NewIntegrationService(
+		integrationRepo,
+		userRepo,
+		prService,
+		cfg.GitHubWebhookSecret,
+		cfg.GitLabWebhookToken,
+		gitlab.NewClient(gitlabAPI.server.URL, fakeGitLabToken, &http.Client{Timeout: 5 * time.Second}),
+	)
 
 	authHandler := handler.NewAuthHandler(authService, validate)
 	teamHandler := handler.NewTeamHandler(teamService, validate)
@@ -66,6 +313,13 @@ func setupE2ETest(t *testing.T) *E2ETestSuite {
 	prHandler := handler.NewPRHandler(prService, validate)
 	healthHandler := handler.NewHealthHandler()
 	statisticsHandler := handler.NewStatisticsHandler(statsService)
+	codeOwnersHandler := handler.NewCodeOwnersHandler(codeOwnersService, validate)
+	auditHandler := handler.NewAuditHandler(auditService)
+	webhookHandler := handler.NewWebhookHandler(webhookService, validate)
+	integrationHandler := handler.NewIntegrationHandler(integrationService, validate)
+	chatHandler := handler.NewChatHandler(chatService, validate)
+	digestHandler := handler.NewDigestHandler(digestService)
+	slaHandler := handler.NewSLAHandler(slaService)
 
 	r := router.SetupRouter(
 		authHandler,
@@ -74,7 +328,15 @@ func setupE2ETest(t *testing.T) *E2ETestSuite {
 		prHandler,
 		healthHandler,
 		statisticsHandler,
+		codeOwnersHandler,
+		auditHandler,
+		webhookHandler,
+		integrationHandler,
+		chatHandler,
+		digestHandler,
+		slaHandler,
 		authService,
+		auditService,
 	)
 
 	server := httptest.NewServer(r)
@@ -84,15 +346,27 @@ func setupE2ETest(t *testing.T) *E2ETestSuite {
 	token := getAdminToken(t, server.URL)
 
 	return &E2ETestSuite{
-		pool:   pool,
-		server: server,
-		token:  token,
+		pool:         pool,
+		server:       server,
+		token:        token,
+		outbox:       outboxService,
+		webhooks:     webhookService,
+		chat:         chatService,
+		gitlab:       gitlabAPI,
+		integrations: integrationService,
+		smtp:         smtp,
+		emails:       emailService,
+		digests:      digestService,
+		sla:          slaService,
+		users:        userService,
 	}
 }
 
 func (s *E2ETestSuite) teardown() {
 	cleanupDB(nil, s.pool)
 	s.server.Close()
+	s.gitlab.server.Close()
+	_ = s.smtp.listener.Close()
 	s.pool.Close()
 }
 
@@ -104,6 +378,11 @@ func cleanupDB(t testing.TB, pool *pgxpool.Pool) {
 		"TRUNCATE TABLE users CASCADE",
 		"TRUNCATE TABLE teams CASCADE",
 		"TRUNCATE TABLE auth_tokens CASCADE",
+		"TRUNCATE TABLE code_owner_rules CASCADE",
+		"TRUNCATE TABLE audit_log",
+		"TRUNCATE TABLE webhook_subscriptions CASCADE",
+		"TRUNCATE TABLE outbox",
+		"TRUNCATE TABLE integration_deliveries",
 	}
 
 	for _, query := range queries {
@@ -318,3 +597,3111 @@ func TestE2E_BatchDeactivateUsers(t *testing.T) {
 	// check, that no one was assined on PR because of team of three members (there is no another reviewer)
 	assert.True(t, batchResp.TotalPRsReassigned == 0)
 }
+
+func TestE2E_LeastLoadedAssignment(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	createPR := func(prID string) dto.PullRequestDTO {
+		resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Change " + prID,
+			AuthorID:        "l1",
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+
+	members := []string{"l2", "l3", "l4", "l5", "l6"}
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "ledger",
+		Members: []request.TeamMemberInput{
+			{UserID: "l1", Username: "Lena", IsActive: true},
+			{UserID: "l2", Username: "Leon", IsActive: true},
+			{UserID: "l3", Username: "Lily", IsActive: true},
+			{UserID: "l4", Username: "Luca", IsActive: true},
+			{UserID: "l5", Username: "Lara", IsActive: true},
+			{UserID: "l6", Username: "Liam", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	first := createPR("pr-80")
+	assert.Equal(t, "least_loaded", first.AssignmentStrategy)
+	require.Len(t, first.AssignedReviewers, 2)
+
+	// members without open reviews are picked before the reviewers of the first PR
+	second := createPR("pr-81")
+	require.Len(t, second.AssignedReviewers, 2)
+	for _, userID := range second.AssignedReviewers {
+		assert.NotContains(t, first.AssignedReviewers, userID)
+	}
+
+	var idle string
+	for _, userID := range members {
+		if !slices.Contains(first.AssignedReviewers, userID) && !slices.Contains(second.AssignedReviewers, userID) {
+			idle = userID
+		}
+	}
+	require.NotEmpty(t, idle)
+
+	// on reassignment the only member without open reviews replaces the old reviewer
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{
+		PullRequestID: "pr-80",
+		OldUserID:     first.AssignedReviewers[0],
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var reassigned response.ReassignResponse
+	err := json.NewDecoder(resp.Body).Decode(&reassigned)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, idle, reassigned.ReplacedBy)
+}
+
+func TestE2E_ReviewerStrategy(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	createPR := func(prID string) dto.PullRequestDTO {
+		resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Release " + prID,
+			AuthorID:        "r1",
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName:         "release",
+		ReviewerStrategy: "round_robin",
+		Members: []request.TeamMemberInput{
+			{UserID: "r1", Username: "Rick", IsActive: true},
+			{UserID: "r2", Username: "Rosa", IsActive: true},
+			{UserID: "r3", Username: "Ruth", IsActive: true},
+			{UserID: "r4", Username: "Ryan", IsActive: true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var teamResp response.TeamResponse
+	err := json.NewDecoder(resp.Body).Decode(&teamResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "round_robin", teamResp.Team.ReviewerStrategy)
+
+	first := createPR("pr-90")
+	assert.Equal(t, "round_robin", first.AssignmentStrategy)
+	require.Len(t, first.AssignedReviewers, 2)
+
+	// round robin gives the next PR to the member who has never been assigned
+	second := createPR("pr-91")
+	assert.Equal(t, "round_robin", second.AssignmentStrategy)
+	var neverAssigned string
+	for _, userID := range []string{"r2", "r3", "r4"} {
+		if !slices.Contains(first.AssignedReviewers, userID) {
+			neverAssigned = userID
+		}
+	}
+	assert.Contains(t, second.AssignedReviewers, neverAssigned)
+
+	resp = do("POST", "/team/setReviewerStrategy", request.SetReviewerStrategyRequest{TeamName: "release", ReviewerStrategy: "weighted"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	err = json.NewDecoder(resp.Body).Decode(&teamResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "weighted", teamResp.Team.ReviewerStrategy)
+	assert.Equal(t, "weighted", createPR("pr-92").AssignmentStrategy)
+
+	// the deprecated endpoint only changes the strategy in the team settings
+	resp = do("GET", "/team/settings?team_name=release", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var settingsResp response.TeamSettingsResponse
+	err = json.NewDecoder(resp.Body).Decode(&settingsResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "weighted", settingsResp.Settings.ReviewerStrategy)
+	assert.Equal(t, 2, settingsResp.Settings.ReviewerCount)
+
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:         "release",
+		ReviewerStrategy: "random",
+		ReviewerCount:    2,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	err = json.NewDecoder(resp.Body).Decode(&settingsResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "random", settingsResp.Settings.ReviewerStrategy)
+	assert.Equal(t, "random", createPR("pr-93").AssignmentStrategy)
+
+	resp = do("POST", "/team/setReviewerStrategy", request.SetReviewerStrategyRequest{TeamName: "release", ReviewerStrategy: "fastest"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName:         "release-2",
+		ReviewerStrategy: "fastest",
+		Members:          []request.TeamMemberInput{{UserID: "r5", Username: "Rene", IsActive: true}},
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// teams created without a strategy use least loaded
+	resp = do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "release-3",
+		Members:  []request.TeamMemberInput{{UserID: "r6", Username: "Remy", IsActive: true}},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	err = json.NewDecoder(resp.Body).Decode(&teamResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "least_loaded", teamResp.Team.ReviewerStrategy)
+}
+
+func TestE2E_TeamSettings(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	decodeSettings := func(resp *http.Response) dto.TeamSettingsDTO {
+		defer resp.Body.Close()
+		var settingsResp response.TeamSettingsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&settingsResp))
+		return settingsResp.Settings
+	}
+	decodePR := func(resp *http.Response) dto.PullRequestDTO {
+		defer resp.Body.Close()
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+
+	for _, team := range []request.CreateTeamRequest{
+		{
+			TeamName: "billing",
+			Members: []request.TeamMemberInput{
+				{UserID: "b1", Username: "Bart", IsActive: true},
+				{UserID: "b2", Username: "Beth", IsActive: true},
+				{UserID: "b3", Username: "Bill", IsActive: true},
+				{UserID: "b4", Username: "Bree", IsActive: true},
+			},
+		},
+		{
+			TeamName: "ledger",
+			Members: []request.TeamMemberInput{
+				{UserID: "l1", Username: "Liam", IsActive: true},
+				{UserID: "l2", Username: "Lily", IsActive: true},
+				{UserID: "l3", Username: "Luke", IsActive: false},
+			},
+		},
+	} {
+		resp := do("POST", "/team/add", team)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	resp := do("GET", "/team/settings?team_name=billing", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	settings := decodeSettings(resp)
+	assert.Equal(t, 2, settings.ReviewerCount)
+	assert.Equal(t, 0, settings.MinReviewers)
+
+	t.Run("reviewer_count sets the number of reviewers", func(t *testing.T) {
+		for _, tc := range []struct {
+			prID  string
+			count int
+		}{
+			{prID: "pr-100", count: 1},
+			{prID: "pr-101", count: 3},
+		} {
+			resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+				TeamName:         "billing",
+				ReviewerStrategy: "least_loaded",
+				ReviewerCount:    tc.count,
+				MinReviewers:     1,
+			})
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.count, decodeSettings(resp).ReviewerCount)
+
+			resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+				PullRequestID:   tc.prID,
+				PullRequestName: "Invoices " + tc.prID,
+				AuthorID:        "b1",
+			})
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			pr := decodePR(resp)
+			assert.Len(t, pr.AssignedReviewers, tc.count)
+			assert.NotContains(t, pr.AssignedReviewers, "b1")
+			require.NotNil(t, pr.Assignment)
+			assert.Equal(t, tc.count, pr.Assignment.RequestedReviewers)
+		}
+	})
+
+	t.Run("min_reviewers above reviewer_count is rejected", func(t *testing.T) {
+		resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "billing",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    2,
+			MinReviewers:     3,
+		})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "billing",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    11,
+		})
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		// the rejected updates leave the settings as they were
+		resp = do("GET", "/team/settings?team_name=billing", nil)
+		settings := decodeSettings(resp)
+		assert.Equal(t, 3, settings.ReviewerCount)
+		assert.Equal(t, 1, settings.MinReviewers)
+	})
+
+	t.Run("team too small for min_reviewers", func(t *testing.T) {
+		resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "ledger",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    3,
+			MinReviewers:     2,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// l3 is inactive, so l2 is the only candidate
+		resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   "pr-102",
+			PullRequestName: "Ledger export",
+			AuthorID:        "l1",
+		})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		assert.Equal(t, dto.ErrCodeNotEnough, errResp.Error.Code)
+
+		// with a lower minimum the PR is created with the reviewers available
+		resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "ledger",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    3,
+			MinReviewers:     1,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   "pr-102",
+			PullRequestName: "Ledger export",
+			AuthorID:        "l1",
+		})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		pr := decodePR(resp)
+		assert.Equal(t, []string{"l2"}, pr.AssignedReviewers)
+		require.NotNil(t, pr.Assignment)
+		assert.Equal(t, 3, pr.Assignment.RequestedReviewers)
+		assert.Equal(t, 1, pr.Assignment.AssignedReviewers)
+	})
+}
+
+func TestE2E_CodeOwners(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	createPR := func(prID string, changedFiles ...string) dto.PullRequestDTO {
+		resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Owned change " + prID,
+			AuthorID:        "k1",
+			ChangedFiles:    changedFiles,
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+	setMode := func(mode string, count int) {
+		resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "kernel",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    count,
+			CodeOwnersMode:   mode,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	for _, team := range []request.CreateTeamRequest{
+		{
+			TeamName: "kernel",
+			Members: []request.TeamMemberInput{
+				{UserID: "k1", Username: "Kai", IsActive: true},
+				{UserID: "k2", Username: "Kim", IsActive: true},
+				{UserID: "k3", Username: "Kit", IsActive: true},
+				{UserID: "k4", Username: "Kya", IsActive: true},
+			},
+		},
+		{
+			TeamName: "writers",
+			Members:  []request.TeamMemberInput{{UserID: "w1", Username: "Wes", IsActive: true}},
+		},
+	} {
+		resp := do("POST", "/team/add", team)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	resp := do("PUT", "/codeowners", request.UploadCodeOwnersRequest{Content: "*.go @k4\n/docs/ @acme/writers\n"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// owners are preferred by default, the rest of the reviewers come from the team
+	setMode("prefer", 1)
+	for _, prID := range []string{"pr-100", "pr-101"} {
+		assert.Equal(t, []string{"k4"}, createPR(prID, "cmd/main.go").AssignedReviewers)
+	}
+	setMode("prefer", 2)
+	pr := createPR("pr-102", "cmd/main.go")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Contains(t, pr.AssignedReviewers, "k4")
+
+	// files without owners are reviewed by the team
+	pr = createPR("pr-103", "README.md")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.NotContains(t, pr.AssignedReviewers, "k4")
+
+	// required owners are the only reviewers, even from another team
+	setMode("require", 2)
+	assert.Equal(t, []string{"k4"}, createPR("pr-104", "cmd/main.go").AssignedReviewers)
+	assert.Equal(t, []string{"w1"}, createPR("pr-105", "docs/guide.md").AssignedReviewers)
+	assert.ElementsMatch(t, []string{"k4", "w1"}, createPR("pr-106", "cmd/main.go", "docs/guide.md").AssignedReviewers)
+
+	// owners are ignored when the mode is off
+	setMode("off", 1)
+	pr = createPR("pr-107", "docs/guide.md")
+	require.Len(t, pr.AssignedReviewers, 1)
+	assert.NotEqual(t, "w1", pr.AssignedReviewers[0])
+}
+
+func TestE2E_FallbackReviewers(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	createPR := func(prID string) dto.PullRequestDTO {
+		resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Crash fix " + prID,
+			AuthorID:        "o1",
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+	setFallbacks := func(fallbackTeams ...string) {
+		resp := do("PUT", "/team/fallbacks", request.SetFallbackTeamsRequest{TeamName: "oncall", FallbackTeams: fallbackTeams})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var fallbackResp response.FallbackTeamsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&fallbackResp))
+		assert.Equal(t, fallbackTeams, fallbackResp.FallbackTeams)
+	}
+	setReviewerCount := func(count int) {
+		resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "oncall",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    count,
+			MinReviewers:     count,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	for _, team := range []request.CreateTeamRequest{
+		{
+			TeamName: "oncall",
+			Members: []request.TeamMemberInput{
+				{UserID: "o1", Username: "Otto", IsActive: true},
+				{UserID: "o2", Username: "Olav", IsActive: false},
+			},
+		},
+		{
+			TeamName: "sre",
+			Members: []request.TeamMemberInput{
+				{UserID: "e1", Username: "Elle", IsActive: true},
+				{UserID: "e2", Username: "Emil", IsActive: true},
+			},
+		},
+		{
+			TeamName: "dba",
+			Members:  []request.TeamMemberInput{{UserID: "d1", Username: "Dora", IsActive: true}},
+		},
+	} {
+		resp := do("POST", "/team/add", team)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	// without fallback pools the single member team has nobody to review
+	setReviewerCount(1)
+	resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-110",
+		PullRequestName: "Crash fix pr-110",
+		AuthorID:        "o1",
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	setFallbacks("dba", "sre")
+
+	// the first fallback team is used even when its member is busier than the second team's
+	for _, prID := range []string{"pr-110", "pr-111"} {
+		pr := createPR(prID)
+		assert.Equal(t, []string{"d1"}, pr.AssignedReviewers)
+		assert.Equal(t, []string{"d1"}, pr.FallbackReviewers)
+	}
+
+	// the next team tops up what the first one cannot provide
+	setReviewerCount(3)
+	pr := createPR("pr-112")
+	require.Len(t, pr.AssignedReviewers, 3)
+	assert.ElementsMatch(t, []string{"d1", "e1", "e2"}, pr.AssignedReviewers)
+	assert.ElementsMatch(t, []string{"d1", "e1", "e2"}, pr.FallbackReviewers)
+
+	setReviewerCount(1)
+	setFallbacks("sre", "dba")
+	pr = createPR("pr-113")
+	require.Len(t, pr.AssignedReviewers, 1)
+	assert.Contains(t, []string{"e1", "e2"}, pr.AssignedReviewers[0])
+	assert.Equal(t, pr.AssignedReviewers, pr.FallbackReviewers)
+
+	resp = do("GET", "/pullRequest/get?pull_request_id=pr-113", nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var prResp response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+	assert.Equal(t, pr.AssignedReviewers, prResp.PR.FallbackReviewers)
+
+	// a reviewer from the team itself is not marked as fallback
+	resp = do("POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "o2", IsActive: true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	setReviewerCount(2)
+	pr = createPR("pr-114")
+	require.Len(t, pr.AssignedReviewers, 2)
+	assert.Contains(t, pr.AssignedReviewers, "o2")
+	assert.NotContains(t, pr.FallbackReviewers, "o2")
+	assert.Len(t, pr.FallbackReviewers, 1)
+
+	// a team cannot fall back to itself
+	resp = do("PUT", "/team/fallbacks", request.SetFallbackTeamsRequest{TeamName: "oncall", FallbackTeams: []string{"oncall"}})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_AvailabilityHandover(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(token, method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	login := func(userID string) string {
+		resp, err := http.Post(suite.server.URL+"/auth/login", "application/json",
+			bytes.NewBufferString(`{"user_id":"`+userID+`"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var loginResp response.LoginResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&loginResp))
+		return loginResp.Token
+	}
+	getPR := func(prID string) dto.PullRequestDTO {
+		resp := do(suite.token, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+
+	resp := do(suite.token, "POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "support",
+		Members: []request.TeamMemberInput{
+			{UserID: "v1", Username: "Vera", IsActive: true},
+			{UserID: "v2", Username: "Vick", IsActive: true},
+			{UserID: "v3", Username: "Vlad", IsActive: true},
+			{UserID: "v4", Username: "Vova", IsActive: true},
+			{UserID: "v5", Username: "Vita", IsActive: false},
+			{UserID: "v6", Username: "Vlas", IsActive: false},
+		},
+	})
+	resp.Body.Close()
+
+	now := time.Now()
+
+	// v2 is away right now, v3 leaves in an hour and hands the reviews over
+	for _, absence := range []struct {
+		userID string
+		req    request.AddAbsenceRequest
+	}{
+		{userID: "v2", req: request.AddAbsenceRequest{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(24 * time.Hour), Reason: "vacation"}},
+		{userID: "v3", req: request.AddAbsenceRequest{StartsAt: now.Add(time.Hour), EndsAt: now.Add(48 * time.Hour), HandOverReviews: true}},
+	} {
+		resp = do(login(absence.userID), "POST", "/users/availability", absence.req)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	resp = do(suite.token, "POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-120",
+		PullRequestName: "Ticket macros",
+		AuthorID:        "v1",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.ElementsMatch(t, []string{"v3", "v4"}, getPR("pr-120").AssignedReviewers)
+
+	for _, userID := range []string{"v5", "v6"} {
+		resp = do(suite.token, "POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: userID, IsActive: true})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// v6 is available now but is away by the time the handover runs
+	resp = do(login("v6"), "POST", "/users/availability", request.AddAbsenceRequest{
+		StartsAt: now.Add(90 * time.Minute),
+		EndsAt:   now.Add(24 * time.Hour),
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	ctx := context.Background()
+
+	// nothing is handed over before the absence starts
+	result, err := suite.users.HandOverAbsentReviews(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, result.HandedOverUsers)
+	assert.ElementsMatch(t, []string{"v3", "v4"}, getPR("pr-120").AssignedReviewers)
+
+	// once it starts, v3's review goes to v5 since v2 and v6 are away and v4 already reviews the PR
+	result, err = suite.users.HandOverAbsentReviews(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v3"}, result.HandedOverUsers)
+	require.Len(t, result.ReassignedPRs, 1)
+	assert.Equal(t, "pr-120", result.ReassignedPRs[0].PullRequestID)
+	assert.Equal(t, []string{"v3"}, result.ReassignedPRs[0].OldReviewers)
+	assert.Equal(t, []string{"v5"}, result.ReassignedPRs[0].NewReviewers)
+	assert.ElementsMatch(t, []string{"v4", "v5"}, getPR("pr-120").AssignedReviewers)
+
+	// each absence is handed over once
+	result, err = suite.users.HandOverAbsentReviews(ctx, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, result.HandedOverUsers)
+
+	resp = do(login("v3"), "GET", "/users/availability", nil)
+	defer resp.Body.Close()
+	var absences response.AbsencesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&absences))
+	require.Len(t, absences.Absences, 1)
+	assert.NotNil(t, absences.Absences[0].HandedOverAt)
+}
+
+func TestE2E_ReviewCapacity(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	decodePR := func(resp *http.Response) dto.PullRequestDTO {
+		defer resp.Body.Close()
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+	errorCode := func(resp *http.Response) string {
+		defer resp.Body.Close()
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		return errResp.Error.Code
+	}
+	setCapacity := func(userID string, maxOpenReviews *int, weight float64) {
+		resp := do("POST", "/users/setCapacity", request.SetUserCapacityRequest{
+			UserID:         userID,
+			MaxOpenReviews: maxOpenReviews,
+			ReviewWeight:   weight,
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var userResp response.UserResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&userResp))
+		assert.Equal(t, maxOpenReviews, userResp.User.MaxOpenReviews)
+		assert.Equal(t, weight, userResp.User.ReviewWeight)
+	}
+	// pairing history is off, so that only load and weight decide
+	noLookback := 0
+	setSettings := func(teamName string, count, minReviewers int) {
+		resp := do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         teamName,
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    count,
+			MinReviewers:     minReviewers,
+			PairingLookback:  &noLookback,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	createPR := func(prID, authorID string) *http.Response {
+		return do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Pipeline " + prID,
+			AuthorID:        authorID,
+		})
+	}
+
+	for _, team := range []request.CreateTeamRequest{
+		{
+			TeamName: "data",
+			Members: []request.TeamMemberInput{
+				{UserID: "w1", Username: "Walt", IsActive: true},
+				{UserID: "w2", Username: "Wanda", IsActive: true},
+				{UserID: "w3", Username: "Wes", IsActive: true},
+				{UserID: "w4", Username: "Willa", IsActive: true},
+			},
+		},
+		{
+			TeamName: "ml",
+			Members: []request.TeamMemberInput{
+				{UserID: "x1", Username: "Xena", IsActive: true},
+				{UserID: "x2", Username: "Xavi", IsActive: true},
+				{UserID: "x3", Username: "Xiao", IsActive: true},
+			},
+		},
+	} {
+		resp := do("POST", "/team/add", team)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	t.Run("weight scales the load", func(t *testing.T) {
+		setSettings("ml", 1, 1)
+		setCapacity("x3", nil, 4)
+
+		picks := map[string]int{}
+		for i := range 5 {
+			resp := createPR("pr-ml-"+strconv.Itoa(i), "x1")
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+			pr := decodePR(resp)
+			require.Len(t, pr.AssignedReviewers, 1)
+			picks[pr.AssignedReviewers[0]]++
+		}
+		// x3 takes up to four reviews per review of x2
+		assert.GreaterOrEqual(t, picks["x3"], 3)
+		assert.LessOrEqual(t, picks["x2"], 2)
+	})
+
+	one := 1
+	setCapacity("w2", &one, 1)
+	setCapacity("w4", &one, 1)
+	setCapacity("w3", nil, 3)
+
+	setSettings("data", 3, 1)
+	resp := createPR("pr-130", "w1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	pr := decodePR(resp)
+	assert.ElementsMatch(t, []string{"w2", "w3", "w4"}, pr.AssignedReviewers)
+	require.NotNil(t, pr.Assignment)
+	assert.False(t, pr.Assignment.CapacityExhausted)
+
+	t.Run("capped reviewers are skipped", func(t *testing.T) {
+		resp := createPR("pr-131", "w1")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		pr := decodePR(resp)
+		assert.Equal(t, []string{"w3"}, pr.AssignedReviewers)
+		require.NotNil(t, pr.Assignment)
+		assert.True(t, pr.Assignment.CapacityExhausted)
+		assert.ElementsMatch(t, []string{"w2", "w4"}, pr.Assignment.AtCapacity)
+		assert.Equal(t, 3, pr.Assignment.RequestedReviewers)
+		assert.Equal(t, 1, pr.Assignment.AssignedReviewers)
+	})
+
+	t.Run("every candidate at the cap", func(t *testing.T) {
+		two := 2
+		setCapacity("w3", &two, 3)
+
+		resp := createPR("pr-132", "w1")
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, dto.ErrCodeAtCapacity, errorCode(resp))
+
+		// the team limit applies on top of the personal ones
+		setCapacity("w3", nil, 3)
+		resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+			TeamName:         "data",
+			ReviewerStrategy: "least_loaded",
+			ReviewerCount:    1,
+			MinReviewers:     1,
+			MaxOpenReviews:   &two,
+			PairingLookback:  &noLookback,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = createPR("pr-132", "w1")
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, dto.ErrCodeAtCapacity, errorCode(resp))
+
+		resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-131", OldUserID: "w3"})
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, dto.ErrCodeAtCapacity, errorCode(resp))
+
+		// merging frees a seat
+		resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-131"})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = createPR("pr-132", "w1")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, []string{"w3"}, decodePR(resp).AssignedReviewers)
+	})
+}
+
+func TestE2E_DeterministicAssignment(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	reqBody := request.CreateTeamRequest{
+		TeamName: "platform",
+		Members: []request.TeamMemberInput{
+			{UserID: "p1", Username: "Paul", IsActive: true},
+			{UserID: "p2", Username: "Quinn", IsActive: true},
+			{UserID: "p3", Username: "Rita", IsActive: true},
+			{UserID: "p4", Username: "Sam", IsActive: true},
+			{UserID: "p5", Username: "Tina", IsActive: true},
+			{UserID: "p6", Username: "Uma", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", suite.server.URL+"/team/add", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := http.DefaultClient.Do(req)
+	resp.Body.Close()
+
+	prReq := request.CreatePRRequest{
+		PullRequestID:   "pr-20",
+		PullRequestName: "Platform Feature",
+		AuthorID:        "p1",
+	}
+	body, _ = json.Marshal(prReq)
+	req, _ = http.NewRequest("POST", suite.server.URL+"/pullRequest/create", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var prResp response.PRResponse
+	err = json.NewDecoder(resp.Body).Decode(&prResp)
+	require.NoError(t, err)
+	require.NotNil(t, prResp.PR.AssignmentSeed)
+
+	req, _ = http.NewRequest("GET", suite.server.URL+"/admin/pullRequest/explain?pull_request_id=pr-20", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	explainResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer explainResp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, explainResp.StatusCode)
+
+	var explanation response.AssignmentExplanationResponse
+	err = json.NewDecoder(explainResp.Body).Decode(&explanation)
+	require.NoError(t, err)
+
+	// replaying the recorded seed over the recorded candidates gives the same reviewers
+	assert.Equal(t, *prResp.PR.AssignmentSeed, explanation.Seed)
+	assert.True(t, explanation.Reproducible)
+	assert.Equal(t, explanation.RecordedReviewers, explanation.ReplayedReviewers)
+	assert.ElementsMatch(t, prResp.PR.AssignedReviewers, explanation.RecordedReviewers)
+	assert.Len(t, explanation.Candidates, 5)
+	assert.Empty(t, explanation.Reassignments)
+
+	// replacements picked by the strategy are recorded and replayed too
+	require.Len(t, prResp.PR.AssignedReviewers, 2)
+	reassigned, deactivated := prResp.PR.AssignedReviewers[0], prResp.PR.AssignedReviewers[1]
+	body, _ = json.Marshal(request.ReassignPRRequest{PullRequestID: "pr-20", OldUserID: reassigned})
+	req, _ = http.NewRequest("POST", suite.server.URL+"/pullRequest/reassign", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	reassignResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer reassignResp.Body.Close()
+	require.Equal(t, http.StatusOK, reassignResp.StatusCode)
+	var reassignResult response.ReassignResponse
+	require.NoError(t, json.NewDecoder(reassignResp.Body).Decode(&reassignResult))
+
+	body, _ = json.Marshal(request.BatchDeactivateUsersRequest{UserIDs: []string{deactivated}})
+	req, _ = http.NewRequest("POST", suite.server.URL+"/users/batchDeactivateUsers", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	batchResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer batchResp.Body.Close()
+	require.Equal(t, http.StatusOK, batchResp.StatusCode)
+	var batchResult response.BatchDeactivateResponse
+	require.NoError(t, json.NewDecoder(batchResp.Body).Decode(&batchResult))
+	require.Len(t, batchResult.ReassignedPRs, 1)
+
+	req, _ = http.NewRequest("GET", suite.server.URL+"/admin/pullRequest/explain?pull_request_id=pr-20", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	explainResp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer explainResp.Body.Close()
+	require.Equal(t, http.StatusOK, explainResp.StatusCode)
+	require.NoError(t, json.NewDecoder(explainResp.Body).Decode(&explanation))
+
+	require.Len(t, explanation.Reassignments, 2)
+	first, second := explanation.Reassignments[0], explanation.Reassignments[1]
+	assert.Equal(t, reassigned, first.PreviousReviewerID)
+	assert.Equal(t, reassignResult.ReplacedBy, first.ReviewerID)
+	assert.Equal(t, "assignment", first.Reason)
+	assert.Equal(t, []string{first.ReviewerID}, first.RecordedReviewers)
+	assert.Equal(t, deactivated, second.PreviousReviewerID)
+	assert.Equal(t, "deactivation", second.Reason)
+	assert.Equal(t, []string{second.ReviewerID}, batchResult.ReassignedPRs[0].NewReviewers)
+	for _, r := range explanation.Reassignments {
+		assert.True(t, r.Reproducible)
+		assert.Equal(t, r.RecordedReviewers, r.ReplayedReviewers)
+		assert.NotEmpty(t, r.Candidates)
+	}
+}
+
+func TestE2E_SkillMatchedAssignment(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	reqBody := request.CreateTeamRequest{
+		TeamName: "core",
+		Members: []request.TeamMemberInput{
+			{UserID: "c1", Username: "Carl", IsActive: true, Skills: []string{"go"}},
+			{UserID: "c2", Username: "Dana", IsActive: true, Skills: []string{"go", "postgres"}},
+			{UserID: "c3", Username: "Eric", IsActive: true, Skills: []string{"frontend"}},
+			{UserID: "c4", Username: "Fiona", IsActive: true, Skills: []string{"Security"}},
+		},
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", suite.server.URL+"/team/add", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := http.DefaultClient.Do(req)
+	resp.Body.Close()
+
+	prReq := request.CreatePRRequest{
+		PullRequestID:   "pr-30",
+		PullRequestName: "Harden queries",
+		AuthorID:        "c1",
+		RequiredSkills:  []string{"security", "postgres", "rust"},
+	}
+	body, _ = json.Marshal(prReq)
+	req, _ = http.NewRequest("POST", suite.server.URL+"/pullRequest/create", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var prResp response.PRResponse
+	err = json.NewDecoder(resp.Body).Decode(&prResp)
+	require.NoError(t, err)
+
+	// both seats go to the only users covering security and postgres
+	assert.ElementsMatch(t, []string{"c2", "c4"}, prResp.PR.AssignedReviewers)
+	require.NotNil(t, prResp.PR.Assignment)
+	assert.Equal(t, []string{"rust"}, prResp.PR.Assignment.MissingSkills)
+
+	skillsReq := request.SetUserSkillsRequest{UserID: "c3", Skills: []string{"rust", "frontend"}}
+	body, _ = json.Marshal(skillsReq)
+	req, _ = http.NewRequest("POST", suite.server.URL+"/users/setSkills", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	skillsResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer skillsResp.Body.Close()
+
+	var userResp response.UserResponse
+	err = json.NewDecoder(skillsResp.Body).Decode(&userResp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "rust"}, userResp.User.Skills)
+
+	// replacing the security reviewer keeps postgres covered by the other one and looks for security and rust
+	reassignReq := request.ReassignPRRequest{PullRequestID: "pr-30", OldUserID: "c4"}
+	body, _ = json.Marshal(reassignReq)
+	req, _ = http.NewRequest("POST", suite.server.URL+"/pullRequest/reassign", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	req.Header.Set("Content-Type", "application/json")
+	reassignResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer reassignResp.Body.Close()
+
+	require.Equal(t, http.StatusOK, reassignResp.StatusCode)
+
+	var reassigned response.ReassignResponse
+	err = json.NewDecoder(reassignResp.Body).Decode(&reassigned)
+	require.NoError(t, err)
+	assert.Equal(t, "c3", reassigned.ReplacedBy)
+	assert.Equal(t, []string{"security"}, reassigned.PR.Assignment.MissingSkills)
+}
+
+func TestE2E_SeniorReviewerPolicy(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "mobile",
+		Members: []request.TeamMemberInput{
+			{UserID: "m1", Username: "Mia", IsActive: true},
+			{UserID: "m2", Username: "Noah", IsActive: true, Seniority: "senior"},
+			{UserID: "m3", Username: "Olga", IsActive: true, Seniority: "junior"},
+			{UserID: "m4", Username: "Pete", IsActive: true, Seniority: "lead"},
+			{UserID: "m5", Username: "Rosa", IsActive: true, Seniority: "junior"},
+		},
+	})
+	resp.Body.Close()
+
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:           "mobile",
+		ReviewerStrategy:   "least_loaded",
+		ReviewerCount:      1,
+		MinSeniorReviewers: 1,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-40",
+		PullRequestName: "Offline mode",
+		AuthorID:        "m1",
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var prResp response.PRResponse
+	err := json.NewDecoder(resp.Body).Decode(&prResp)
+	require.NoError(t, err)
+	require.Len(t, prResp.PR.AssignedReviewers, 1)
+	senior := prResp.PR.AssignedReviewers[0]
+	assert.Contains(t, []string{"m2", "m4"}, senior)
+
+	// the senior reviewer is replaced by the other senior, not by a junior
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-40", OldUserID: senior})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var reassigned response.ReassignResponse
+	err = json.NewDecoder(resp.Body).Decode(&reassigned)
+	require.NoError(t, err)
+	assert.Contains(t, []string{"m2", "m4"}, reassigned.ReplacedBy)
+	assert.NotEqual(t, senior, reassigned.ReplacedBy)
+
+	// the last senior cannot be removed, even when a junior stays on the PR
+	resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-40", UserID: "m3"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/removeReviewer", request.RemoveReviewerRequest{PullRequestID: "pr-40", UserID: reassigned.ReplacedBy})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var removeErr dto.ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&removeErr)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrCodeNeedSenior, removeErr.Error.Code)
+
+	// juniors can be removed, and so can a senior while another one stays
+	resp = do("POST", "/pullRequest/removeReviewer", request.RemoveReviewerRequest{PullRequestID: "pr-40", UserID: "m3"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-40", UserID: senior})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/removeReviewer", request.RemoveReviewerRequest{PullRequestID: "pr-40", UserID: reassigned.ReplacedBy})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var removed response.PRResponse
+	err = json.NewDecoder(resp.Body).Decode(&removed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{senior}, removed.PR.AssignedReviewers)
+
+	// a manually chosen replacement of the last senior must be a senior too
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-40", OldUserID: senior, NewUserID: "m5"})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var reassignErr dto.ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&reassignErr)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrCodeNeedSenior, reassignErr.Error.Code)
+
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-40", OldUserID: senior, NewUserID: reassigned.ReplacedBy})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	err = json.NewDecoder(resp.Body).Decode(&reassigned)
+	require.NoError(t, err)
+	assert.Equal(t, []string{reassigned.ReplacedBy}, reassigned.PR.AssignedReviewers)
+
+	// without seniors left in the team the PR cannot be created
+	for _, userID := range []string{"m2", "m4"} {
+		resp = do("POST", "/users/setSeniority", request.SetUserSeniorityRequest{UserID: userID, Seniority: "middle"})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-41",
+		PullRequestName: "Dark theme",
+		AuthorID:        "m1",
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var errResp dto.ErrorResponse
+	err = json.NewDecoder(resp.Body).Decode(&errResp)
+	require.NoError(t, err)
+	assert.Equal(t, dto.ErrCodeNeedSenior, errResp.Error.Code)
+}
+
+func TestE2E_PairingRotation(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "search",
+		Members: []request.TeamMemberInput{
+			{UserID: "s1", Username: "Sara", IsActive: true},
+			{UserID: "s2", Username: "Tom", IsActive: true},
+			{UserID: "s3", Username: "Uma", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+
+	lookback := 10
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:         "search",
+		ReviewerStrategy: "least_loaded",
+		ReviewerCount:    1,
+		PairingLookback:  &lookback,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var reviewers []string
+	for _, prID := range []string{"pr-50", "pr-51"} {
+		resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Ranking " + prID,
+			AuthorID:        "s1",
+		})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var prResp response.PRResponse
+		err := json.NewDecoder(resp.Body).Decode(&prResp)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Len(t, prResp.PR.AssignedReviewers, 1)
+		reviewers = append(reviewers, prResp.PR.AssignedReviewers[0])
+
+		// merging frees the reviewer, so only the pairing history separates the candidates
+		resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: prID})
+		resp.Body.Close()
+	}
+
+	assert.NotEqual(t, reviewers[0], reviewers[1])
+
+	resp = do("GET", "/statistics/pairingDiversity?team_name=search", nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var diversity response.PairingDiversityResponse
+	err := json.NewDecoder(resp.Body).Decode(&diversity)
+	require.NoError(t, err)
+	assert.Equal(t, 2, diversity.Overall.Assignments)
+	assert.Equal(t, 2, diversity.Overall.DistinctPairs)
+	assert.InDelta(t, 1.0, diversity.Overall.Diversity, 0.001)
+}
+
+func TestE2E_ManualReviewers(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "infra",
+		Members: []request.TeamMemberInput{
+			{UserID: "i1", Username: "Ivan", IsActive: true},
+			{UserID: "i2", Username: "Jane", IsActive: true},
+			{UserID: "i3", Username: "Karl", IsActive: true},
+			{UserID: "i4", Username: "Lena", IsActive: false},
+		},
+	})
+	resp.Body.Close()
+
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:         "infra",
+		ReviewerStrategy: "least_loaded",
+		ReviewerCount:    1,
+		MinReviewers:     1,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-60",
+		PullRequestName: "Terraform upgrade",
+		AuthorID:        "i1",
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var prResp response.PRResponse
+	err := json.NewDecoder(resp.Body).Decode(&prResp)
+	require.NoError(t, err)
+	require.Len(t, prResp.PR.AssignedReviewers, 1)
+	first := prResp.PR.AssignedReviewers[0]
+	other := "i2"
+	if first == "i2" {
+		other = "i3"
+	}
+
+	for _, tc := range []struct {
+		userID string
+		code   string
+	}{
+		{userID: "i1", code: dto.ErrCodeIsAuthor},
+		{userID: "i4", code: dto.ErrCodeInactive},
+		{userID: first, code: dto.ErrCodeAssigned},
+	} {
+		resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-60", UserID: tc.userID})
+		var errResp dto.ErrorResponse
+		err = json.NewDecoder(resp.Body).Decode(&errResp)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, tc.code, errResp.Error.Code)
+	}
+
+	// the only reviewer cannot be removed while the team requires one
+	resp = do("POST", "/pullRequest/removeReviewer", request.RemoveReviewerRequest{PullRequestID: "pr-60", UserID: first})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-60", OldUserID: first, NewUserID: other})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var reassigned response.ReassignResponse
+	err = json.NewDecoder(resp.Body).Decode(&reassigned)
+	require.NoError(t, err)
+	assert.Equal(t, other, reassigned.ReplacedBy)
+	assert.Equal(t, []string{other}, reassigned.PR.AssignedReviewers)
+
+	resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-60", UserID: first})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/removeReviewer", request.RemoveReviewerRequest{PullRequestID: "pr-60", UserID: other})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var removed response.PRResponse
+	err = json.NewDecoder(resp.Body).Decode(&removed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first}, removed.PR.AssignedReviewers)
+}
+
+func TestE2E_MergePolicy(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(token, method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do(suite.token, "POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "payments",
+		Members: []request.TeamMemberInput{
+			{UserID: "y1", Username: "Yara", IsActive: true},
+			{UserID: "y2", Username: "Zack", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+
+	resp = do(suite.token, "PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:                     "payments",
+		ReviewerStrategy:             "least_loaded",
+		ReviewerCount:                1,
+		MergeMinApprovals:            1,
+		MergeBlockOnChangesRequested: true,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do(suite.token, "POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-70",
+		PullRequestName: "Refunds",
+		AuthorID:        "y1",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err := http.Post(suite.server.URL+"/auth/login", "application/json",
+		bytes.NewBufferString(`{"user_id":"y2"}`))
+	require.NoError(t, err)
+	var loginResp response.LoginResponse
+	err = json.NewDecoder(resp.Body).Decode(&loginResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	reviewerToken := loginResp.Token
+
+	merge := func() (int, string) {
+		resp := do(suite.token, "POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-70"})
+		defer resp.Body.Close()
+		var errResp dto.ErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return resp.StatusCode, errResp.Error.Code
+	}
+
+	status, code := merge()
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, dto.ErrCodeMergeBlock, code)
+
+	// the admin is not a reviewer of the PR
+	resp = do(suite.token, "POST", "/pullRequest/review", request.SubmitReviewRequest{PullRequestID: "pr-70", State: "approved"})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = do(reviewerToken, "POST", "/pullRequest/review", request.SubmitReviewRequest{PullRequestID: "pr-70", State: "changes_requested"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	status, code = merge()
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, dto.ErrCodeMergeBlock, code)
+
+	resp = do(reviewerToken, "POST", "/pullRequest/review", request.SubmitReviewRequest{PullRequestID: "pr-70", State: "approved"})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var prResp response.PRResponse
+	err = json.NewDecoder(resp.Body).Decode(&prResp)
+	require.NoError(t, err)
+	require.Len(t, prResp.PR.Reviews, 1)
+	assert.Equal(t, "approved", prResp.PR.Reviews[0].State)
+
+	status, _ = merge()
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestE2E_PRLifecycle(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	decodePR := func(resp *http.Response) dto.PullRequestDTO {
+		defer resp.Body.Close()
+		var prResp response.PRResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+		return prResp.PR
+	}
+	errorCode := func(resp *http.Response) string {
+		defer resp.Body.Close()
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		return errResp.Error.Code
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "growth",
+		Members: []request.TeamMemberInput{
+			{UserID: "g1", Username: "Gina", IsActive: true},
+			{UserID: "g2", Username: "Hugo", IsActive: true},
+			{UserID: "g3", Username: "Iris", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-80",
+		PullRequestName: "Onboarding experiment",
+		AuthorID:        "g1",
+		Draft:           true,
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	pr := decodePR(resp)
+	assert.Equal(t, "DRAFT", pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-80", UserID: "g2"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, dto.ErrCodePRNotOpen, errorCode(resp))
+
+	resp = do("POST", "/pullRequest/markReady", request.MarkReadyRequest{PullRequestID: "pr-80"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	pr = decodePR(resp)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.ElementsMatch(t, []string{"g2", "g3"}, pr.AssignedReviewers)
+	require.NotNil(t, pr.AssignmentSeed)
+
+	resp = do("POST", "/pullRequest/close", request.ClosePRRequest{PullRequestID: "pr-80"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	pr = decodePR(resp)
+	assert.Equal(t, "CLOSED", pr.Status)
+	assert.NotNil(t, pr.ClosedAt)
+
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-80"})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, dto.ErrCodeTransition, errorCode(resp))
+
+	resp = do("POST", "/pullRequest/reopen", request.ReopenPRRequest{PullRequestID: "pr-80"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	pr = decodePR(resp)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.Nil(t, pr.ClosedAt)
+
+	// a draft closed before getting reviewers is reopened as a draft
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-81",
+		PullRequestName: "Abandoned idea",
+		AuthorID:        "g1",
+		Draft:           true,
+	})
+	resp.Body.Close()
+	resp = do("POST", "/pullRequest/close", request.ClosePRRequest{PullRequestID: "pr-81"})
+	resp.Body.Close()
+	resp = do("POST", "/pullRequest/reopen", request.ReopenPRRequest{PullRequestID: "pr-81"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "DRAFT", decodePR(resp).Status)
+}
+
+func TestE2E_ListPRs(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "data",
+		Members: []request.TeamMemberInput{
+			{UserID: "d1", Username: "Dora", IsActive: true},
+			{UserID: "d2", Username: "Egor", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+
+	prIDs := []string{"pr-90", "pr-91", "pr-92", "pr-93", "pr-94"}
+	for _, prID := range prIDs {
+		resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Pipeline " + prID,
+			AuthorID:        "d1",
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-92"})
+	resp.Body.Close()
+
+	resp = do("GET", "/pullRequest/get?pull_request_id=pr-92", nil)
+	var prResp response.PRResponse
+	err := json.NewDecoder(resp.Body).Decode(&prResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", prResp.PR.Status)
+	assert.Equal(t, []string{"d2"}, prResp.PR.AssignedReviewers)
+
+	// walking the pages returns every PR once, newest first
+	var listed []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		path := "/pullRequest/list?team_name=data&reviewer_id=d2&limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		resp = do("GET", path, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var listResp response.PRListResponse
+		err = json.NewDecoder(resp.Body).Decode(&listResp)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		for _, pr := range listResp.PullRequests {
+			listed = append(listed, pr.PullRequestID)
+		}
+		cursor = listResp.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"pr-94", "pr-93", "pr-92", "pr-91", "pr-90"}, listed)
+
+	resp = do("GET", "/pullRequest/list?status=MERGED", nil)
+	var merged response.PRListResponse
+	err = json.NewDecoder(resp.Body).Decode(&merged)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Len(t, merged.PullRequests, 1)
+	assert.Equal(t, "pr-92", merged.PullRequests[0].PullRequestID)
+	assert.Empty(t, merged.NextCursor)
+
+	resp = do("GET", "/pullRequest/list?created_from=yesterday", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_PRTimeline(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "infra",
+		Members: []request.TeamMemberInput{
+			{UserID: "i1", Username: "Ilya", IsActive: true},
+			{UserID: "i2", Username: "Inna", IsActive: true},
+			{UserID: "i3", Username: "Igor", IsActive: true},
+			{UserID: "i4", Username: "Ira", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-100",
+		PullRequestName: "Terraform modules",
+		AuthorID:        "i1",
+	})
+	var prResp response.PRResponse
+	err := json.NewDecoder(resp.Body).Decode(&prResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Len(t, prResp.PR.AssignedReviewers, 2)
+	first, second := prResp.PR.AssignedReviewers[0], prResp.PR.AssignedReviewers[1]
+	free := ""
+	for _, userID := range []string{"i2", "i3", "i4"} {
+		if userID != first && userID != second {
+			free = userID
+		}
+	}
+
+	// the first reviewer is replaced manually, the second one leaves the company
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{
+		PullRequestID: "pr-100",
+		OldUserID:     first,
+		NewUserID:     free,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/users/batchDeactivateUsers", request.BatchDeactivateUsersRequest{UserIDs: []string{second}})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-100"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("GET", "/pullRequest/timeline?pull_request_id=pr-100", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var timeline response.PRTimelineResponse
+	err = json.NewDecoder(resp.Body).Decode(&timeline)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	types := make([]string, len(timeline.Events))
+	for i, event := range timeline.Events {
+		types[i] = event.Type
+		assert.Equal(t, "admin", event.ActorID)
+	}
+	require.Equal(t, []string{"created", "assigned", "assigned", "reassigned", "reassigned", "merged"}, types)
+
+	assert.Equal(t, "OPEN", timeline.Events[0].ToStatus)
+	assert.ElementsMatch(t, []string{first, second}, []string{timeline.Events[1].ReviewerID, timeline.Events[2].ReviewerID})
+	assert.Equal(t, "assignment", timeline.Events[1].Reason)
+
+	manual := timeline.Events[3]
+	assert.Equal(t, first, manual.PreviousReviewerID)
+	assert.Equal(t, free, manual.ReviewerID)
+	assert.Equal(t, "manual", manual.Reason)
+
+	// the only free member is the first reviewer
+	deactivation := timeline.Events[4]
+	assert.Equal(t, second, deactivation.PreviousReviewerID)
+	assert.Equal(t, first, deactivation.ReviewerID)
+	assert.Equal(t, "deactivation", deactivation.Reason)
+
+	assert.Equal(t, "MERGED", timeline.Events[5].ToStatus)
+
+	resp = do("GET", "/pullRequest/timeline?pull_request_id=unknown", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestE2E_AuditLog(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(token, method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := do(suite.token, "POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "legal",
+		Members: []request.TeamMemberInput{
+			{UserID: "l1", Username: "Lena", IsActive: true},
+			{UserID: "l2", Username: "Lev", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = do(suite.token, "POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "l2", IsActive: false})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do(suite.token, "POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "nobody", IsActive: false})
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// non-admins are rejected, the attempt is still recorded
+	resp, err := http.Post(suite.server.URL+"/auth/login", "application/json", bytes.NewBufferString(`{"user_id":"l1"}`))
+	require.NoError(t, err)
+	var loginResp response.LoginResponse
+	err = json.NewDecoder(resp.Body).Decode(&loginResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	resp = do(loginResp.Token, "POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "l2", IsActive: true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	getLog := func(query string) response.AuditLogResponse {
+		resp := do(suite.token, "GET", "/admin/audit?"+query, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var logResp response.AuditLogResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&logResp))
+		return logResp
+	}
+
+	logResp := getLog("action=POST%20/users/setIsActive")
+	require.Len(t, logResp.Entries, 3)
+	forbidden, notFound, deactivated := logResp.Entries[0], logResp.Entries[1], logResp.Entries[2]
+
+	assert.Equal(t, "l1", forbidden.ActorID)
+	assert.Equal(t, "failure", forbidden.Outcome)
+	assert.Equal(t, http.StatusForbidden, forbidden.StatusCode)
+
+	assert.Equal(t, "failure", notFound.Outcome)
+	assert.Equal(t, "user", notFound.TargetType)
+	assert.Equal(t, "nobody", notFound.TargetID)
+	assert.NotEmpty(t, notFound.Error)
+
+	assert.Equal(t, "admin", deactivated.ActorID)
+	assert.NotEmpty(t, deactivated.RequestID)
+	assert.Equal(t, "success", deactivated.Outcome)
+	assert.Equal(t, "l2", deactivated.TargetID)
+	assert.Equal(t, dto.AuditChangeDTO{Before: true, After: false}, deactivated.Changes["is_active"])
+	assert.Len(t, deactivated.Changes, 1)
+
+	logResp = getLog("target_type=team&target_id=legal")
+	require.Len(t, logResp.Entries, 1)
+	assert.Equal(t, "POST /team/add", logResp.Entries[0].Action)
+	assert.Equal(t, "legal", logResp.Entries[0].Changes["team_name"].After)
+
+	// pages follow each other without gaps
+	first := getLog("limit=2")
+	require.Len(t, first.Entries, 2)
+	require.NotZero(t, first.NextCursor)
+	second := getLog("limit=2&cursor=" + strconv.FormatInt(first.NextCursor, 10))
+	require.Len(t, second.Entries, 2)
+	assert.Zero(t, second.NextCursor)
+	assert.Equal(t, "POST /team/add", second.Entries[1].Action)
+
+	resp = do(suite.token, "GET", "/admin/audit?format=jsonl&outcome=failure", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	var lines []dto.AuditEntryDTO
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var entry dto.AuditEntryDTO
+		require.NoError(t, decoder.Decode(&entry))
+		lines = append(lines, entry)
+	}
+	resp.Body.Close()
+	assert.Len(t, lines, 2)
+
+	resp = do(suite.token, "GET", "/admin/audit?outcome=maybe", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_AuditLogExport(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	// far more entries than can be streamed within the 300ms deadline of regular requests
+	const entries = 200000
+	_, err := suite.pool.Exec(context.Background(), `
+        INSERT INTO audit_log (actor_id, action, target_type, target_id, outcome, status_code, created_at)
+        SELECT 'bulk', 'POST /users/setIsActive', 'user', 'u' || n, 'success', 200, NOW() - n * INTERVAL '1 second'
+        FROM generate_series(1, $1) AS n
+    `, entries)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", suite.server.URL+"/admin/audit?format=jsonl&actor_id=bulk", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	exported := 0
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var entry dto.AuditEntryDTO
+		require.NoError(t, decoder.Decode(&entry))
+		exported++
+	}
+	t.Logf("exported %d audit entries in %v", exported, time.Since(start))
+	assert.Equal(t, entries, exported)
+
+	// pages are still served within the regular deadline
+	req, _ = http.NewRequest("GET", suite.server.URL+"/admin/audit?actor_id=bulk&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	pageResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer pageResp.Body.Close()
+	require.Equal(t, http.StatusOK, pageResp.StatusCode)
+	var page response.AuditLogResponse
+	require.NoError(t, json.NewDecoder(pageResp.Body).Decode(&page))
+	assert.Len(t, page.Entries, 10)
+}
+
+func TestE2E_Webhooks(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// the receiver rejects the first request, then accepts everything with a valid signature
+	var secret string
+	var mu sync.Mutex
+	var received []domain.WebhookEvent
+	failNext := true
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("X-Webhook-Signature-256") != domain.WebhookSignature(secret, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if failNext {
+			failNext = false
+			http.Error(w, "try later", http.StatusInternalServerError)
+			return
+		}
+		var event domain.WebhookEvent
+		if err := json.Unmarshal(body, &event); err == nil && event.Type == r.Header.Get("X-Webhook-Event") {
+			received = append(received, event)
+		}
+	}))
+	defer receiver.Close()
+
+	resp := do("POST", "/admin/webhooks", request.CreateWebhookRequest{
+		URL:        receiver.URL,
+		EventTypes: []string{domain.WebhookPRCreated, "pr.unknown"},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = do("POST", "/admin/webhooks", request.CreateWebhookRequest{
+		URL:        receiver.URL,
+		EventTypes: []string{domain.WebhookPRCreated, domain.WebhookPRMerged},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created response.WebhookCreatedResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+	require.NotEmpty(t, created.Secret)
+	assert.Equal(t, "admin", created.Webhook.CreatedBy)
+	mu.Lock()
+	secret = created.Secret
+	mu.Unlock()
+
+	resp = do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "hooks",
+		Members: []request.TeamMemberInput{
+			{UserID: "h1", Username: "Hana", IsActive: true},
+			{UserID: "h2", Username: "Hugo", IsActive: true},
+			{UserID: "h3", Username: "Hilda", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-hook",
+		PullRequestName: "Send webhooks",
+		AuthorID:        "h1",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	getDeliveries := func(query string) response.WebhookDeliveriesResponse {
+		resp := do("GET", "/admin/webhooks/deliveries?"+query, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var deliveriesResp response.WebhookDeliveriesResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&deliveriesResp))
+		return deliveriesResp
+	}
+
+	_, err := suite.outbox.Relay(context.Background(), 100)
+	require.NoError(t, err)
+
+	// only the subscribed pr.created event is queued
+	deliveries := getDeliveries("")
+	require.Len(t, deliveries.Deliveries, 1)
+	assert.Equal(t, domain.WebhookPRCreated, deliveries.Deliveries[0].EventType)
+	assert.Equal(t, "pending", deliveries.Deliveries[0].Status)
+
+	// the failed attempt is logged and retried later
+	sent, err := suite.webhooks.DeliverDue(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	failed := getDeliveries("").Deliveries[0]
+	assert.Equal(t, "pending", failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	require.NotNil(t, failed.LastStatusCode)
+	assert.Equal(t, http.StatusInternalServerError, *failed.LastStatusCode)
+	assert.Contains(t, failed.LastError, "try later")
+	assert.True(t, failed.NextAttemptAt.After(time.Now()))
+
+	sent, err = suite.webhooks.DeliverDue(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	resp = do("POST", "/admin/webhooks/deliveries/redeliver", request.RedeliverRequest{DeliveryID: failed.ID})
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var redelivery response.WebhookDeliveryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&redelivery))
+	resp.Body.Close()
+	require.NotNil(t, redelivery.Delivery.RedeliveryOf)
+	assert.Equal(t, failed.ID, *redelivery.Delivery.RedeliveryOf)
+
+	_, err = suite.webhooks.DeliverDue(context.Background(), 10)
+	require.NoError(t, err)
+
+	delivered := getDeliveries("status=delivered")
+	require.Len(t, delivered.Deliveries, 1)
+	assert.Equal(t, redelivery.Delivery.ID, delivered.Deliveries[0].ID)
+	assert.NotNil(t, delivered.Deliveries[0].DeliveredAt)
+
+	mu.Lock()
+	require.Len(t, received, 1)
+	event := received[0]
+	mu.Unlock()
+	assert.Equal(t, failed.EventID, event.ID)
+	var data domain.WebhookPRData
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, "pr-hook", data.PullRequest.PullRequestID)
+	assert.ElementsMatch(t, []string{"h2", "h3"}, data.Reviewers)
+
+	// disabled webhooks get no new events
+	resp = do("DELETE", "/admin/webhooks?id="+strconv.FormatInt(created.Webhook.ID, 10), nil)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-hook"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = suite.outbox.Relay(context.Background(), 100)
+	require.NoError(t, err)
+	assert.Len(t, getDeliveries("").Deliveries, 2)
+
+	resp = do("DELETE", "/admin/webhooks?id=999999", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestE2E_Outbox(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+	ctx := context.Background()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	unpublished := func() []string {
+		rows, err := suite.pool.Query(ctx, "SELECT event_type FROM outbox WHERE published_at IS NULL ORDER BY id")
+		require.NoError(t, err)
+		defer rows.Close()
+		eventTypes := []string{}
+		for rows.Next() {
+			var eventType string
+			require.NoError(t, rows.Scan(&eventType))
+			eventTypes = append(eventTypes, eventType)
+		}
+		return eventTypes
+	}
+
+	resp := do("POST", "/admin/webhooks", request.CreateWebhookRequest{
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{domain.WebhookPRCreated, domain.WebhookReviewerAssigned, domain.WebhookUserDeactivated},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "relay",
+		Members: []request.TeamMemberInput{
+			{UserID: "r1", Username: "Rita", IsActive: true},
+			{UserID: "r2", Username: "Ron", IsActive: true},
+			{UserID: "r3", Username: "Rosa", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	createPR := func() int {
+		resp := do("POST", "/pullRequest/create", request.CreatePRRequest{
+			PullRequestID:   "pr-outbox",
+			PullRequestName: "Relay events",
+			AuthorID:        "r1",
+		})
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// events are stored with the change and wait for the relay
+	require.Equal(t, http.StatusCreated, createPR())
+	assert.Equal(t, []string{domain.WebhookPRCreated, domain.WebhookReviewerAssigned, domain.WebhookReviewerAssigned}, unpublished())
+
+	// a rejected change stores no events
+	require.Equal(t, http.StatusConflict, createPR())
+	assert.Len(t, unpublished(), 3)
+
+	resp = do("POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "r3", IsActive: false})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, domain.WebhookUserDeactivated, unpublished()[3])
+
+	// a message locked by another relay is skipped, not waited for
+	tx, err := suite.pool.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "SELECT id FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT 1 FOR UPDATE")
+	require.NoError(t, err)
+
+	published, err := suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, []string{domain.WebhookPRCreated}, unpublished())
+	require.NoError(t, tx.Rollback(ctx))
+
+	published, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Empty(t, unpublished())
+
+	published, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	resp = do("GET", "/admin/webhooks/deliveries?limit=10", nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var deliveries response.WebhookDeliveriesResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&deliveries))
+	require.Len(t, deliveries.Deliveries, 4)
+	eventTypes := make([]string, len(deliveries.Deliveries))
+	for i, delivery := range deliveries.Deliveries {
+		eventTypes[i] = delivery.EventType
+	}
+	assert.ElementsMatch(t, []string{
+		domain.WebhookPRCreated, domain.WebhookReviewerAssigned, domain.WebhookReviewerAssigned, domain.WebhookUserDeactivated,
+	}, eventTypes)
+
+	// events published again, as after a relay that stopped before its commit, are not queued twice
+	_, err = suite.pool.Exec(ctx, "UPDATE outbox SET published_at = NULL")
+	require.NoError(t, err)
+	published, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 4, published)
+
+	var queued int
+	require.NoError(t, suite.pool.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries").Scan(&queued))
+	assert.Equal(t, 4, queued)
+}
+
+func TestE2E_GitHubWebhook(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	cfg, err := config.Load(".env.tests")
+	require.NoError(t, err)
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// deliver posts a recorded payload from testdata the way GitHub does
+	deliver := func(event, file, deliveryID, secret string) (int, response.IngestResponse) {
+		body, err := os.ReadFile("testdata/github/" + file)
+		require.NoError(t, err)
+		req, _ := http.NewRequest("POST", suite.server.URL+"/integrations/github/webhook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-GitHub-Delivery", deliveryID)
+		req.Header.Set("X-Hub-Signature-256", domain.WebhookSignature(secret, body))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var ingestResp response.IngestResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&ingestResp))
+		}
+		return resp.StatusCode, ingestResp
+	}
+	secret := cfg.GitHubWebhookSecret
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "octo",
+		Members: []request.TeamMemberInput{
+			{UserID: "g1", Username: "Gina", IsActive: true},
+			{UserID: "g2", Username: "Gus", IsActive: true},
+			{UserID: "g3", Username: "Greta", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	status, _ := deliver("pull_request", "pull_request_opened.json", "d-open-42", "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, ping := deliver("ping", "ping.json", "d-ping", secret)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestIgnored, ping.Outcome)
+
+	// the author is unknown until the login is mapped, the failed delivery can be redelivered
+	status, _ = deliver("pull_request", "pull_request_opened.json", "d-open-42", secret)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	resp = do("PUT", "/admin/integrations/accounts", request.SetIntegrationAccountRequest{
+		Provider: domain.ProviderGitHub,
+		Login:    "octocat",
+		UserID:   "g1",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	status, opened := deliver("pull_request", "pull_request_opened.json", "d-open-42", secret)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestApplied, opened.Outcome)
+	assert.Equal(t, "octo-org/api#42", opened.PullRequestID)
+	require.NotNil(t, opened.PullRequest)
+	assert.Equal(t, "Add rate limiting to the public API", opened.PullRequest.PullRequestName)
+	assert.Equal(t, "g1", opened.PullRequest.AuthorID)
+	assert.Equal(t, domain.StatusOpen, opened.PullRequest.Status)
+	assert.ElementsMatch(t, []string{"g2", "g3"}, opened.PullRequest.AssignedReviewers)
+
+	status, duplicate := deliver("pull_request", "pull_request_opened.json", "d-open-42", secret)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestDuplicate, duplicate.Outcome)
+
+	// a draft gets reviewers when it is ready, then follows GitHub through close and reopen
+	steps := []struct {
+		file   string
+		status string
+	}{
+		{"pull_request_opened_draft.json", domain.StatusDraft},
+		{"pull_request_ready_for_review.json", domain.StatusOpen},
+		{"pull_request_closed.json", domain.StatusClosed},
+		{"pull_request_reopened.json", domain.StatusOpen},
+	}
+	for i, step := range steps {
+		status, result := deliver("pull_request", step.file, "d-43-"+strconv.Itoa(i), secret)
+		require.Equal(t, http.StatusOK, status, step.file)
+		assert.Equal(t, domain.IngestApplied, result.Outcome, step.file)
+		require.NotNil(t, result.PullRequest, step.file)
+		assert.Equal(t, "octo-org/api#43", result.PullRequest.PullRequestID)
+		assert.Equal(t, step.status, result.PullRequest.Status, step.file)
+	}
+
+	// the team policy blocks merging here without approvals
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:          "octo",
+		ReviewerStrategy:  "least_loaded",
+		ReviewerCount:     2,
+		MergeMinApprovals: 1,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "octo-org/api#42"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// but a merge that has already happened on GitHub is recorded anyway
+	status, merged := deliver("pull_request", "pull_request_closed_merged.json", "d-merge-42", secret)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestApplied, merged.Outcome)
+	require.NotNil(t, merged.PullRequest)
+	assert.Equal(t, domain.StatusMerged, merged.PullRequest.Status)
+
+	resp = do("GET", "/pullRequest/get?pull_request_id="+url.QueryEscape("octo-org/api#42"), nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var prResp response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+	assert.Equal(t, domain.StatusMerged, prResp.PR.Status)
+}
+
+func TestE2E_GitLabWebhook(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+
+	cfg, err := config.Load(".env.tests")
+	require.NoError(t, err)
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// deliver posts a recorded payload from testdata the way GitLab does
+	deliver := func(event, file, eventUUID, token string) (int, response.IngestResponse) {
+		body, err := os.ReadFile("testdata/gitlab/" + file)
+		require.NoError(t, err)
+		req, _ := http.NewRequest("POST", suite.server.URL+"/integrations/gitlab/webhook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", event)
+		req.Header.Set("X-Gitlab-Event-UUID", eventUUID)
+		req.Header.Set("X-Gitlab-Token", token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var ingestResp response.IngestResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&ingestResp))
+		}
+		return resp.StatusCode, ingestResp
+	}
+	token := cfg.GitLabWebhookToken
+	const hook = "Merge Request Hook"
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "tanuki",
+		Members: []request.TeamMemberInput{
+			{UserID: "t1", Username: "Tara", IsActive: true},
+			{UserID: "t2", Username: "Tom", IsActive: true},
+			{UserID: "t3", Username: "Tess", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	status, _ := deliver(hook, "merge_request_open.json", "uuid-open-7", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, push := deliver("Push Hook", "merge_request_open.json", "uuid-push", token)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestIgnored, push.Outcome)
+
+	status, _ = deliver(hook, "merge_request_open.json", "uuid-open-7", token)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	// only t2 has a GitLab account known to the service and to GitLab, t3 is left out of the post-back
+	for _, account := range []request.SetIntegrationAccountRequest{
+		{Provider: domain.ProviderGitLab, Login: "tanuki", UserID: "t1"},
+		{Provider: domain.ProviderGitLab, Login: "rev-two", UserID: "t2"},
+	} {
+		resp = do("PUT", "/admin/integrations/accounts", account)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	suite.gitlab.addUser("rev-two", 102)
+
+	// syncReviewers posts the queued reviewers back like the GitLab sync worker does
+	syncReviewers := func() int {
+		synced, err := suite.integrations.SyncDueReviewers(context.Background(), 100)
+		require.NoError(t, err)
+		return synced
+	}
+
+	// GitLab is down when the merge request is opened, the reviewers are posted back once it is up again
+	suite.gitlab.setFailing(true)
+	status, opened := deliver(hook, "merge_request_open.json", "uuid-open-7", token)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestApplied, opened.Outcome)
+	assert.Equal(t, "tanuki-group/api!7", opened.PullRequestID)
+	require.NotNil(t, opened.PullRequest)
+	assert.Equal(t, "t1", opened.PullRequest.AuthorID)
+	assert.Equal(t, domain.StatusOpen, opened.PullRequest.Status)
+	assert.ElementsMatch(t, []string{"t2", "t3"}, opened.PullRequest.AssignedReviewers)
+
+	assert.Equal(t, 1, syncReviewers())
+	_, ok := suite.gitlab.reviewersOf("/api/v4/projects/15/merge_requests/7")
+	assert.False(t, ok)
+	assert.Zero(t, syncReviewers())
+
+	suite.gitlab.setFailing(false)
+	_, err = suite.pool.Exec(context.Background(), `UPDATE gitlab_reviewer_syncs SET next_attempt_at = NOW() WHERE status = 'pending'`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, syncReviewers())
+	reviewers, ok := suite.gitlab.reviewersOf("/api/v4/projects/15/merge_requests/7")
+	require.True(t, ok)
+	assert.Equal(t, []int64{102}, reviewers)
+
+	status, duplicate := deliver(hook, "merge_request_open.json", "uuid-open-7", token)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestDuplicate, duplicate.Outcome)
+
+	// a draft ignores unrelated updates, gets reviewers when the draft flag is removed, then follows close and reopen
+	steps := []struct {
+		file    string
+		outcome string
+		status  string
+	}{
+		{"merge_request_open_draft.json", domain.IngestApplied, domain.StatusDraft},
+		{"merge_request_update_title.json", domain.IngestIgnored, ""},
+		{"merge_request_update_ready.json", domain.IngestApplied, domain.StatusOpen},
+		{"merge_request_close.json", domain.IngestApplied, domain.StatusClosed},
+		{"merge_request_reopen.json", domain.IngestApplied, domain.StatusOpen},
+	}
+	for i, step := range steps {
+		status, result := deliver(hook, step.file, "uuid-8-"+strconv.Itoa(i), token)
+		require.Equal(t, http.StatusOK, status, step.file)
+		assert.Equal(t, step.outcome, result.Outcome, step.file)
+		assert.Equal(t, "tanuki-group/api!8", result.PullRequestID, step.file)
+		if step.status == "" {
+			assert.Nil(t, result.PullRequest, step.file)
+			continue
+		}
+		require.NotNil(t, result.PullRequest, step.file)
+		assert.Equal(t, step.status, result.PullRequest.Status, step.file)
+	}
+
+	assert.Equal(t, 1, syncReviewers())
+	_, ok = suite.gitlab.reviewersOf("/api/v4/projects/15/merge_requests/8")
+	assert.True(t, ok)
+
+	// the team policy blocks merging here without approvals
+	resp = do("PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:          "tanuki",
+		ReviewerStrategy:  "least_loaded",
+		ReviewerCount:     2,
+		MergeMinApprovals: 1,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "tanuki-group/api!7"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// but a merge that has already happened on GitLab is recorded anyway
+	status, merged := deliver(hook, "merge_request_merge.json", "uuid-merge-7", token)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, domain.IngestApplied, merged.Outcome)
+	require.NotNil(t, merged.PullRequest)
+	assert.Equal(t, domain.StatusMerged, merged.PullRequest.Status)
+
+	resp = do("GET", "/pullRequest/get?pull_request_id="+url.QueryEscape("tanuki-group/api!7"), nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var prResp response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&prResp))
+	assert.Equal(t, domain.StatusMerged, prResp.PR.Status)
+}
+
+func TestE2E_ChatNotifications(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+	ctx := context.Background()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// chat is an incoming webhook that records the posted messages, it fails while broken is set
+	var (
+		mu       sync.Mutex
+		messages []string
+		broken   bool
+	)
+	chat := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		if broken {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		messages = append(messages, body.Text)
+	}))
+	defer chat.Close()
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		result := messages
+		messages = nil
+		return result
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "chatty",
+		Members: []request.TeamMemberInput{
+			{UserID: "c1", Username: "Carl", IsActive: true},
+			{UserID: "c2", Username: "Cleo", IsActive: true},
+			{UserID: "c3", Username: "Cody", IsActive: true},
+			{UserID: "c4", Username: "Cora", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = do("PUT", "/team/chat", request.SetTeamChatRequest{
+		TeamName:   "chatty",
+		WebhookURL: chat.URL,
+		Templates:  request.ChatTemplatesInput{Merged: "{{.Unknown}}"},
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = do("PUT", "/team/chat", request.SetTeamChatRequest{TeamName: "nobody", WebhookURL: chat.URL})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = do("PUT", "/team/chat", request.SetTeamChatRequest{
+		TeamName:   "chatty",
+		WebhookURL: chat.URL,
+		Templates:  request.ChatTemplatesInput{Merged: "{{.PullRequest.ID}} merged, thanks {{mentions .Reviewers}}"},
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var saved response.TeamChatSettingsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&saved))
+	assert.Equal(t, chat.URL, saved.Chat.WebhookURL)
+	assert.Empty(t, saved.Chat.Templates.Assigned)
+
+	// relay queues the posts of the outbox events and deliver sends them like the chat worker does
+	relay := func() {
+		_, err := suite.outbox.Relay(ctx, 100)
+		require.NoError(t, err)
+	}
+	deliver := func() int {
+		sent, err := suite.chat.DeliverDue(ctx, 100)
+		require.NoError(t, err)
+		return sent
+	}
+
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-chat",
+		PullRequestName: "Notify the chat",
+		AuthorID:        "c1",
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	reviewers := created.PR.AssignedReviewers
+	require.Len(t, reviewers, 2)
+
+	// the posts are sent by the chat worker, not by the outbox relay
+	usernames := map[string]string{"c2": "Cleo", "c3": "Cody", "c4": "Cora"}
+	relay()
+	assert.Empty(t, received())
+
+	// the initial reviewers are announced in one post
+	assert.Equal(t, 1, deliver())
+	assert.Equal(t, []string{
+		"@" + usernames[reviewers[0]] + ", @" + usernames[reviewers[1]] + ` you are assigned to review "Notify the chat" (pr-chat) by Carl`,
+	}, received())
+
+	// the events published again are not posted twice
+	_, err := suite.pool.Exec(ctx, "UPDATE outbox SET published_at = NULL")
+	require.NoError(t, err)
+	relay()
+	assert.Zero(t, deliver())
+
+	var free string
+	for userID := range usernames {
+		if userID != reviewers[0] && userID != reviewers[1] {
+			free = userID
+		}
+	}
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-chat", OldUserID: reviewers[0], NewUserID: free})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	relay()
+	deliver()
+	assert.Equal(t, []string{
+		"@" + usernames[free] + ` you are assigned to review "Notify the chat" (pr-chat) by Carl instead of ` + usernames[reviewers[0]],
+	}, received())
+
+	// a reviewer added later is announced with the assigned template
+	resp = do("POST", "/pullRequest/addReviewer", request.AddReviewerRequest{PullRequestID: "pr-chat", UserID: reviewers[0]})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	relay()
+	assert.Equal(t, 1, deliver())
+	assert.Equal(t, []string{
+		"@" + usernames[reviewers[0]] + ` you are assigned to review "Notify the chat" (pr-chat) by Carl`,
+	}, received())
+
+	// a failing chat neither fails the merge nor holds back the outbox, the post is retried later
+	mu.Lock()
+	broken = true
+	mu.Unlock()
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-chat"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	published, err := suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 1, deliver())
+	assert.Empty(t, received())
+	assert.Zero(t, deliver())
+
+	mu.Lock()
+	broken = false
+	mu.Unlock()
+	_, err = suite.pool.Exec(ctx, `UPDATE chat_posts SET next_attempt_at = NOW() WHERE status = 'pending'`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deliver())
+	merged := received()
+	require.Len(t, merged, 1)
+	// the custom template is used for merges
+	assert.Regexp(t, `^pr-chat merged, thanks @C\w+, @C\w+, @C\w+$`, merged[0])
+
+	// drafts are announced once they are ready for review
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{PullRequestID: "pr-chat-2", PullRequestName: "Second", AuthorID: "c1", Draft: true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	relay()
+	assert.Zero(t, deliver())
+
+	resp = do("POST", "/pullRequest/markReady", request.MarkReadyRequest{PullRequestID: "pr-chat-2"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	relay()
+	assert.Equal(t, 1, deliver())
+	ready := received()
+	require.Len(t, ready, 1)
+	assert.Regexp(t, `^@C\w+, @C\w+ you are assigned to review "Second" \(pr-chat-2\) by Carl$`, ready[0])
+
+	resp = do("DELETE", "/team/chat?team_name=chatty", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = do("GET", "/team/chat?team_name=chatty", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestE2E_EmailNotifications(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+	ctx := context.Background()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	pendingDigest := func(userID string) []string {
+		rows, err := suite.pool.Query(ctx, `
+            SELECT summary FROM notification_digest_items WHERE user_id = $1 AND sent_at IS NULL ORDER BY id
+        `, userID)
+		require.NoError(t, err)
+		defer rows.Close()
+		summaries := []string{}
+		for rows.Next() {
+			var summary string
+			require.NoError(t, rows.Scan(&summary))
+			summaries = append(summaries, summary)
+		}
+		require.NoError(t, rows.Err())
+		return summaries
+	}
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "mailers",
+		Members: []request.TeamMemberInput{
+			{UserID: "e1", Username: "Emma", IsActive: true},
+			{UserID: "e2", Username: "Eric", IsActive: true},
+			{UserID: "e3", Username: "Eden", IsActive: true},
+			{UserID: "e4", Username: "Elsa", IsActive: false},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	for _, invalid := range []request.SetUserNotificationsRequest{
+		{UserID: "e1", Email: "not-an-email", NotificationPreference: domain.NotifyImmediate},
+		{UserID: "e1", Email: "emma@example.com", NotificationPreference: "weekly"},
+	} {
+		resp = do("POST", "/users/setNotifications", invalid)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+	resp = do("POST", "/users/setNotifications", request.SetUserNotificationsRequest{
+		UserID: "nobody", Email: "nobody@example.com", NotificationPreference: domain.NotifyOff,
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for userID, preference := range map[string]string{
+		"e1": domain.NotifyImmediate,
+		"e2": domain.NotifyImmediate,
+		"e3": domain.NotifyDigest,
+		"e4": domain.NotifyOff,
+	} {
+		resp = do("POST", "/users/setNotifications", request.SetUserNotificationsRequest{
+			UserID: userID, Email: userID + "@example.com", NotificationPreference: preference,
+		})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var updated response.UserResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+		assert.Equal(t, userID+"@example.com", updated.User.Email)
+		assert.Equal(t, preference, updated.User.NotificationPreference)
+	}
+
+	// with two active teammates both are assigned, e2 gets an email and e3 a digest item
+	resp = do("POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-mail",
+		PullRequestName: "Escape <b>this</b>",
+		AuthorID:        "e1",
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.ElementsMatch(t, []string{"e2", "e3"}, created.PR.AssignedReviewers)
+
+	// the relay only queues the emails, the email worker sends them
+	_, err := suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	assert.Empty(t, suite.smtp.received())
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	messages := suite.smtp.received()
+	require.Len(t, messages, 2)
+	byRecipient := map[string]sinkMessage{}
+	for _, message := range messages {
+		byRecipient[message.To] = message
+	}
+
+	assigned := byRecipient["e2@example.com"]
+	assert.Equal(t, "Review requested: Escape <b>this</b>", assigned.Subject)
+	assert.Contains(t, assigned.Body, "Escape &lt;b&gt;this&lt;/b&gt;")
+	assert.NotContains(t, assigned.Body, "<b>this</b>")
+	assert.Contains(t, assigned.Body, "Emma")
+
+	ready := byRecipient["e1@example.com"]
+	assert.Equal(t, "Reviewers assigned: Escape <b>this</b>", ready.Subject)
+	assert.Contains(t, ready.Body, "Eric")
+	assert.Contains(t, ready.Body, "Eden")
+
+	assert.Len(t, pendingDigest("e3"), 1)
+
+	// the events published again queue neither emails nor digest items twice
+	_, err = suite.pool.Exec(ctx, "UPDATE outbox SET published_at = NULL")
+	require.NoError(t, err)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	sent, err := suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Len(t, pendingDigest("e3"), 1)
+
+	// e4 does not want any notification
+	resp = do("POST", "/users/setIsActive", request.SetUserActiveRequest{UserID: "e4", IsActive: true})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-mail", OldUserID: "e3", NewUserID: "e4"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Empty(t, suite.smtp.received())
+
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-mail", OldUserID: "e2", NewUserID: "e3"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Empty(t, suite.smtp.received())
+	digest := pendingDigest("e3")
+	require.Len(t, digest, 2)
+	assert.Contains(t, digest[1], "from Eric")
+
+	// an email rejected by the SMTP server is kept and retried later
+	suite.smtp.setFailing(true)
+	resp = do("POST", "/pullRequest/reassign", request.ReassignPRRequest{PullRequestID: "pr-mail", OldUserID: "e4", NewUserID: "e2"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	sent, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Empty(t, suite.smtp.received())
+	sent, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	suite.smtp.setFailing(false)
+	_, err = suite.pool.Exec(ctx, `UPDATE outgoing_emails SET next_attempt_at = NOW() WHERE status = 'pending'`)
+	require.NoError(t, err)
+	sent, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	messages = suite.smtp.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "e2@example.com", messages[0].To)
+	assert.Equal(t, "Review requested: Escape <b>this</b>", messages[0].Subject)
+}
+
+func TestE2E_DailyDigest(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+	ctx := context.Background()
+
+	do := func(method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	preview := func(userID string) response.DigestPreviewResponse {
+		resp := do("GET", "/admin/users/digest?user_id="+userID, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result response.DigestPreviewResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+	hour := func(h int) *int { return &h }
+
+	resp := do("POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "digesters",
+		Members: []request.TeamMemberInput{
+			{UserID: "d1", Username: "Dana", IsActive: true},
+			{UserID: "d2", Username: "Dean", IsActive: true},
+			{UserID: "d3", Username: "Dora", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	for _, invalid := range []request.SetUserDigestScheduleRequest{
+		{UserID: "d2", Timezone: "Mars/Olympus", DigestHour: hour(9)},
+		{UserID: "d2", Timezone: "Asia/Tokyo", DigestHour: hour(24)},
+		{UserID: "d2", Timezone: "Asia/Tokyo"},
+	} {
+		resp = do("POST", "/users/setDigestSchedule", invalid)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	// zones without daylight saving keep the local hours below stable
+	for userID, schedule := range map[string]request.SetUserDigestScheduleRequest{
+		"d2": {Timezone: "Asia/Tokyo", DigestHour: hour(9)},
+		"d3": {Timezone: "Asia/Kolkata", DigestHour: hour(7)},
+	} {
+		schedule.UserID = userID
+		resp = do("POST", "/users/setDigestSchedule", schedule)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var updated response.UserResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+		assert.Equal(t, schedule.Timezone, updated.User.Timezone)
+		assert.Equal(t, *schedule.DigestHour, updated.User.DigestHour)
+	}
+	for userID, preference := range map[string]string{"d2": domain.NotifyImmediate, "d3": domain.NotifyDigest} {
+		resp = do("POST", "/users/setNotifications", request.SetUserNotificationsRequest{
+			UserID: userID, Email: userID + "@example.com", NotificationPreference: preference,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// both PRs are reviewed by d2 and d3, only the open one is pending
+	for _, prID := range []string{"pr-digest-1", "pr-digest-2"} {
+		resp = do("POST", "/pullRequest/create", request.CreatePRRequest{PullRequestID: prID, PullRequestName: "Digest " + prID, AuthorID: "d1"})
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	resp = do("POST", "/pullRequest/merge", request.MergePRRequest{PullRequestID: "pr-digest-2"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err := suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	suite.smtp.received()
+
+	_, err = suite.pool.Exec(ctx, `
+        UPDATE pr_reviewers SET assigned_at = NOW() - INTERVAL '2 days 3 hours 10 minutes'
+        WHERE pull_request_id = 'pr-digest-1' AND user_id = 'd2'
+    `)
+	require.NoError(t, err)
+
+	dean := preview("d2")
+	assert.True(t, dean.WillSend)
+	assert.Equal(t, "Asia/Tokyo", dean.Digest.Timezone)
+	require.Len(t, dean.Digest.Reviews, 1)
+	assert.Equal(t, "pr-digest-1", dean.Digest.Reviews[0].PullRequestID)
+	assert.Equal(t, "Dana", dean.Digest.Reviews[0].Author)
+	assert.GreaterOrEqual(t, dean.Digest.Reviews[0].WaitingSeconds, int64((51*time.Hour + 10*time.Minute).Seconds()))
+	assert.Contains(t, dean.HTML, "2d 3h")
+	assert.Empty(t, dean.Digest.Notifications)
+
+	dora := preview("d3")
+	assert.True(t, dora.WillSend)
+	require.Len(t, dora.Digest.Reviews, 1)
+	assert.Len(t, dora.Digest.Notifications, 2)
+
+	dana := preview("d1")
+	assert.False(t, dana.WillSend)
+	assert.Equal(t, "user has no email, it is the only digest channel", dana.Reason)
+
+	resp = do("GET", "/admin/users/digest?user_id=nobody", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// previews send nothing
+	assert.Empty(t, suite.smtp.received())
+
+	// at 22:00 UTC it is 07:00 in Tokyo and 03:30 in Kolkata, at 01:00 UTC 10:00 and 06:30, at 02:00 UTC 11:00 and 07:30
+	midnight := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	sent, err := suite.digests.SendDueDigests(ctx, midnight.Add(-2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	sent, err = suite.digests.SendDueDigests(ctx, midnight.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	messages := suite.smtp.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "d2@example.com", messages[0].To)
+	assert.Equal(t, "Your review digest for "+midnight.Format(time.DateOnly)+": 1 pending", messages[0].Subject)
+	assert.Contains(t, messages[0].Body, "Digest pr-digest-1")
+	assert.NotContains(t, messages[0].Body, "Digest pr-digest-2")
+
+	sent, err = suite.digests.SendDueDigests(ctx, midnight.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	messages = suite.smtp.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "d3@example.com", messages[0].To)
+	assert.Contains(t, messages[0].Body, "Since your previous digest")
+
+	// one digest per local day, the queued notifications are consumed
+	sent, err = suite.digests.SendDueDigests(ctx, midnight.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, preview("d3").Digest.Notifications)
+}
+
+func TestE2E_ReviewSLA(t *testing.T) {
+	suite := setupE2ETest(t)
+	defer suite.teardown()
+	ctx := context.Background()
+
+	do := func(token, method, path string, payload any) *http.Response {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	listBreaches := func(query string) response.SLABreachesResponse {
+		resp := do(suite.token, "GET", "/admin/sla/breaches?"+query, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result response.SLABreachesResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+	// 2024-01-08 is a Monday, deadlines below are in UTC, the default timezone of users
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.January, 8, hour, 0, 0, 0, time.UTC)
+	}
+
+	resp := do(suite.token, "POST", "/team/add", request.CreateTeamRequest{
+		TeamName: "slackers",
+		Members: []request.TeamMemberInput{
+			{UserID: "s1", Username: "Sam", IsActive: true},
+			{UserID: "s2", Username: "Sue", IsActive: true},
+			{UserID: "s3", Username: "Sid", IsActive: true},
+		},
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = do(suite.token, "PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:         "slackers",
+		ReviewerStrategy: "least_loaded",
+		ReviewerCount:    2,
+		ReviewSLAHours:   1001,
+	})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	escalationHours := 2
+	resp = do(suite.token, "PUT", "/team/settings", request.UpdateTeamSettingsRequest{
+		TeamName:           "slackers",
+		ReviewerStrategy:   "least_loaded",
+		ReviewerCount:      2,
+		ReviewSLAHours:     4,
+		SLAEscalationHours: &escalationHours,
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var settings response.TeamSettingsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&settings))
+	assert.Equal(t, 4, settings.Settings.ReviewSLAHours)
+	assert.Equal(t, 2, settings.Settings.SLAEscalationHours)
+
+	for _, invalid := range []request.SetTeamLeadRequest{
+		{TeamName: "nowhere", UserID: "s3"},
+		{TeamName: "slackers", UserID: "admin"},
+	} {
+		resp = do(suite.token, "POST", "/team/setLead", invalid)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+	for _, leadID := range []string{"s2", "s3"} {
+		resp = do(suite.token, "POST", "/team/setLead", request.SetTeamLeadRequest{TeamName: "slackers", UserID: leadID})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var team response.TeamResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&team))
+		for _, member := range team.Team.Members {
+			assert.Equal(t, member.UserID == leadID, member.IsTeamLead, member.UserID)
+		}
+	}
+
+	for _, userID := range []string{"s2", "s3"} {
+		resp = do(suite.token, "POST", "/users/setNotifications", request.SetUserNotificationsRequest{
+			UserID: userID, Email: userID + "@example.com", NotificationPreference: domain.NotifyImmediate,
+		})
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp = do(suite.token, "POST", "/pullRequest/create", request.CreatePRRequest{
+		PullRequestID:   "pr-sla",
+		PullRequestName: "Slow review",
+		AuthorID:        "s1",
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created response.PRResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.ElementsMatch(t, []string{"s2", "s3"}, created.PR.AssignedReviewers)
+	_, err := suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	suite.smtp.received()
+
+	// assigned on Monday 10:00, due at 14:00 and escalated from 16:00
+	_, err = suite.pool.Exec(ctx, `UPDATE pr_reviewers SET assigned_at = $1 WHERE pull_request_id = 'pr-sla'`, at(10))
+	require.NoError(t, err)
+
+	result, err := suite.sla.CheckBreaches(ctx, at(13))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SLACheckResult{}, *result)
+
+	result, err = suite.sla.CheckBreaches(ctx, at(15))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SLACheckResult{Breached: 2}, *result)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	messages := suite.smtp.received()
+	require.Len(t, messages, 2)
+	for _, message := range messages {
+		assert.Equal(t, "Review overdue: Slow review", message.Subject)
+		assert.Contains(t, message.Body, "Mon, 08 Jan 2024 14:00 UTC")
+		assert.Contains(t, message.Body, "Sam")
+	}
+
+	// breaches are recorded once
+	result, err = suite.sla.CheckBreaches(ctx, at(15))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SLACheckResult{}, *result)
+
+	// the lead is not escalated their own breach
+	result, err = suite.sla.CheckBreaches(ctx, at(17))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SLACheckResult{Escalated: 1}, *result)
+	_, err = suite.outbox.Relay(ctx, 100)
+	require.NoError(t, err)
+	_, err = suite.emails.DeliverDue(ctx, 100)
+	require.NoError(t, err)
+	messages = suite.smtp.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "s3@example.com", messages[0].To)
+	assert.Equal(t, "Review overdue in your team: Slow review", messages[0].Subject)
+	assert.Contains(t, messages[0].Body, "Sue")
+
+	resp, err = http.Post(suite.server.URL+"/auth/login", "application/json", bytes.NewBufferString(`{"user_id":"s2"}`))
+	require.NoError(t, err)
+	var loginResp response.LoginResponse
+	err = json.NewDecoder(resp.Body).Decode(&loginResp)
+	resp.Body.Close()
+	require.NoError(t, err)
+	resp = do(loginResp.Token, "POST", "/pullRequest/review", request.SubmitReviewRequest{PullRequestID: "pr-sla", State: "approved"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	result, err = suite.sla.CheckBreaches(ctx, at(17).Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SLACheckResult{Resolved: 1}, *result)
+
+	all := listBreaches("")
+	require.Len(t, all.Breaches, 2)
+	assert.Zero(t, all.NextCursor)
+
+	open := listBreaches("status=open")
+	require.Len(t, open.Breaches, 1)
+	assert.Equal(t, "s3", open.Breaches[0].ReviewerID)
+	assert.Equal(t, domain.SLABreachOpen, open.Breaches[0].Status)
+	assert.Nil(t, open.Breaches[0].EscalatedAt)
+
+	resolved := listBreaches("status=resolved&team_name=slackers")
+	require.Len(t, resolved.Breaches, 1)
+	breach := resolved.Breaches[0]
+	assert.Equal(t, "s2", breach.ReviewerID)
+	assert.Equal(t, "pr-sla", breach.PullRequestID)
+	assert.Equal(t, 4, breach.SLAHours)
+	assert.True(t, at(10).Equal(breach.AssignedAt))
+	assert.True(t, at(14).Equal(breach.DueAt))
+	assert.Equal(t, "s3", breach.EscalatedTo)
+	require.NotNil(t, breach.EscalatedAt)
+	assert.True(t, at(17).Equal(*breach.EscalatedAt))
+	require.NotNil(t, breach.ResolvedAt)
+
+	page := listBreaches("limit=1")
+	require.Len(t, page.Breaches, 1)
+	assert.NotZero(t, page.NextCursor)
+	next := listBreaches("limit=1&cursor=" + strconv.FormatInt(page.NextCursor, 10))
+	require.Len(t, next.Breaches, 1)
+	assert.NotEqual(t, page.Breaches[0].ID, next.Breaches[0].ID)
+
+	assert.Empty(t, listBreaches("team_name=others").Breaches)
+
+	for _, invalid := range []string{"status=late", "limit=500", "cursor=abc"} {
+		resp = do(suite.token, "GET", "/admin/sla/breaches?"+invalid, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, invalid)
+	}
+}